@@ -5,12 +5,21 @@ import (
 	"strings"
 	"time"
 	"uniswapper/internal/app/constants"
+	analyticsController "uniswapper/internal/app/controller/analytics"
+	candleController "uniswapper/internal/app/controller/candle"
 	"uniswapper/internal/app/controller/healthcheck"
+	liquidityController "uniswapper/internal/app/controller/liquidity"
 	poolController "uniswapper/internal/app/controller/pool"
+	positionController "uniswapper/internal/app/controller/position"
+	quoteController "uniswapper/internal/app/controller/quote"
+	registryController "uniswapper/internal/app/controller/registry"
+	stateController "uniswapper/internal/app/controller/state"
+	twapController "uniswapper/internal/app/controller/twap"
 	"uniswapper/internal/app/db"
 	poolDBClient "uniswapper/internal/app/db/repository/pool"
 	"uniswapper/internal/app/service/logger"
 	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	"uniswapper/internal/app/service/rpc"
 
 	helmet "github.com/danielkov/gin-helmet"
 	"github.com/gin-contrib/cors"
@@ -85,29 +94,86 @@ func NewRouter(ctx context.Context, dbConnection *db.DBService) *gin.Engine {
 
 	// DB Clients
 	var (
-		poolDBClient = poolDBClient.NewPoolLogsRepository(dbConnection)
+		poolEventsDBClient = poolDBClient.NewPoolEventsRepository(dbConnection)
+		checkpointDBClient = poolDBClient.NewCheckpointRepository(dbConnection)
+		registryDBClient   = poolDBClient.NewPoolRegistryRepository(dbConnection)
+		tokenDBClient      = poolDBClient.NewTokenRepository(dbConnection)
+		snapshotDBClient   = poolDBClient.NewSnapshotRepository(dbConnection)
+		liquidityDBClient  = poolDBClient.NewLiquidityRepository(dbConnection)
+		candleDBClient     = poolDBClient.NewCandleRepository(dbConnection)
+		rollupDBClient     = poolDBClient.NewRollupRepository(dbConnection)
+		positionDBClient   = poolDBClient.NewPositionRepository(dbConnection)
+		poolDBClient       = poolDBClient.NewPoolLogsRepository(dbConnection)
 	)
 
+	// RPC Client
+	rpcClient := rpc.NewClient(ctx)
+	go rpcClient.RunHealthChecks(ctx)
+
 	//Service
 	var (
-		uniswapV3Pool = uniswapv3_pool.NewUniswapV3Pool(ctx, poolDBClient)
+		poolRegistry  = uniswapv3_pool.NewPoolRegistry(ctx, rpcClient, registryDBClient)
+		poolMetadata  = uniswapv3_pool.NewPoolMetadataService(ctx, rpcClient, poolRegistry, tokenDBClient)
+		poolPrices    = uniswapv3_pool.NewPriceService(poolMetadata)
+		poolState     = uniswapv3_pool.NewPoolStateService(ctx, rpcClient, poolPrices)
+		poolLiquidity = uniswapv3_pool.NewLiquidityService(liquidityDBClient, poolMetadata)
+		poolQuotes    = uniswapv3_pool.NewQuoteService(poolState, poolLiquidity, poolMetadata)
+		poolAnalytics = uniswapv3_pool.NewAnalyticsService(rollupDBClient, snapshotDBClient, poolMetadata)
+		poolTWAP      = uniswapv3_pool.NewTWAPService(ctx, rpcClient, poolMetadata, poolEventsDBClient, checkpointDBClient)
+		positions     = uniswapv3_pool.NewPositionService(ctx, rpcClient, positionDBClient, poolState, poolPrices)
+		positionPnL   = uniswapv3_pool.NewPnLService(positionDBClient, poolEventsDBClient, poolMetadata)
+		uniswapV3Pool = uniswapv3_pool.NewUniswapV3Pool(ctx, rpcClient, poolRegistry, poolMetadata, poolPrices, poolState, poolDBClient, poolEventsDBClient, checkpointDBClient, registryDBClient, snapshotDBClient, liquidityDBClient, candleDBClient, rollupDBClient)
+		tracker       = uniswapv3_pool.NewPositionTracker(ctx, rpcClient, positionDBClient, checkpointDBClient)
 	)
 
 	// Start Uniswap V3 Pool to store Logs
 	go uniswapV3Pool.RunUniswapV3Pool(ctx)
 
+	// Start indexing the configured LP positions
+	go tracker.RunPositionTracker(ctx)
+
 	// Controller
 	var (
-		poolController        = poolController.NewPoolController(poolDBClient)
-		healthCheckController = healthcheck.NewHealthCheckController()
+		poolController        = poolController.NewPoolController(poolDBClient, poolMetadata, poolPrices)
+		healthCheckController = healthcheck.NewHealthCheckController(uniswapV3Pool, rpcClient)
+		registryController    = registryController.NewRegistryController(poolRegistry)
+		stateController       = stateController.NewStateController(poolState)
+		liquidityController   = liquidityController.NewLiquidityController(poolLiquidity)
+		quoteController       = quoteController.NewQuoteController(poolQuotes)
+		candleController      = candleController.NewCandleController(candleDBClient)
+		analyticsController   = analyticsController.NewAnalyticsController(poolAnalytics)
+		twapController        = twapController.NewTWAPController(poolTWAP)
+		positionController    = positionController.NewPositionController(positions, positionPnL)
 	)
 
 	v1 := router.Group("/v1/api/pool")
 	{
 		v1.GET(HEALTH_CHECK, healthCheckController.HealthCheck)
 
+		v1.GET(POOLS, registryController.GetPools)
+		v1.POST(POOLS, registryController.AddPool)
+		v1.DELETE(POOL_BY_ID, registryController.RemovePool)
+		v1.PATCH(POOL_BY_ID, registryController.UpdatePool)
+
 		v1.GET(POOL_LOG_BY_ID, poolController.GetPoolLogsById)
 		v1.GET(POOL_HISTORY_LOG, poolController.GetPoolLogsHistory)
+		v1.GET(POOL_STATE, stateController.GetPoolState)
+		v1.GET(POOL_LIQUIDITY, liquidityController.GetPoolLiquidity)
+		v1.GET(POOL_QUOTE, quoteController.GetQuote)
+		v1.GET(POOL_CANDLES, candleController.GetCandles)
+		v1.GET(POOL_VOLUME, analyticsController.GetVolume)
+		v1.GET(POOL_FEES, analyticsController.GetFees)
+		v1.GET(POOL_TVL, analyticsController.GetTVL)
+		v1.GET(POOL_TWAP, twapController.GetTWAP)
+		v1.GET(POOL_PNL, positionController.GetRangePnL)
+	}
+
+	position := router.Group("/v1/api/position")
+	{
+		position.GET(POSITIONS, positionController.GetPositions)
+		position.GET(POSITION_BY_ID, positionController.GetPosition)
+		position.GET(POSITION_FEES, positionController.GetFees)
+		position.GET(POSITION_PNL, positionController.GetPositionPnL)
 	}
 
 	return router
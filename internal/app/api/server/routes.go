@@ -3,6 +3,23 @@ package server
 const (
 	HEALTH_CHECK = "/health-check"
 
+	POOLS      = ""
+	POOL_BY_ID = "/:pool_id"
+
 	POOL_LOG_BY_ID   = "/:pool_id"
 	POOL_HISTORY_LOG = "/:pool_id/historic"
+	POOL_STATE       = "/:pool_id/state"
+	POOL_LIQUIDITY   = "/:pool_id/liquidity"
+	POOL_QUOTE       = "/:pool_id/quote"
+	POOL_CANDLES     = "/:pool_id/candles"
+	POOL_VOLUME      = "/:pool_id/volume"
+	POOL_FEES        = "/:pool_id/fees"
+	POOL_TVL         = "/:pool_id/tvl"
+	POOL_TWAP        = "/:pool_id/twap"
+	POOL_PNL         = "/:pool_id/pnl"
+
+	POSITIONS      = ""
+	POSITION_BY_ID = "/:id"
+	POSITION_FEES  = "/:id/fees"
+	POSITION_PNL   = "/:id/pnl"
 )
@@ -1,7 +1,11 @@
 package constants
 
 const (
-	BadRequest          = "bad request"
-	NotFound            = "not found"
-	InternalServerError = "internal server error"
+	BadRequest            = "bad request"
+	NotFound              = "not found"
+	InternalServerError   = "internal server error"
+	Conflict              = "conflict"
+	InvalidPool           = "address is not a uniswap v3 pool"
+	InsufficientLiquidity = "insufficient pool liquidity for the amount"
+	ServiceUnavailable    = "service unavailable"
 )
@@ -0,0 +1,187 @@
+package analytics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/controller"
+	"uniswapper/internal/app/service/correlation"
+	"uniswapper/internal/app/service/logger"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultWindow is the window served when neither window nor from is given
+const defaultWindow = 24 * time.Hour
+
+var errInvalidWindow = errors.New("invalid window")
+
+// IAnalyticsController represents the interface for AnalyticsController
+type IAnalyticsController interface {
+	GetVolume(c *gin.Context)
+	GetFees(c *gin.Context)
+	GetTVL(c *gin.Context)
+}
+
+// AnalyticsController serves the volume, fees and value locked of pools
+type AnalyticsController struct {
+	Analytics uniswapv3_pool.IAnalyticsService
+}
+
+// NewAnalyticsController creates a new instance of AnalyticsController
+func NewAnalyticsController(analytics uniswapv3_pool.IAnalyticsService) IAnalyticsController {
+	return &AnalyticsController{
+		Analytics: analytics,
+	}
+}
+
+// GetVolume returns the volume swapped through a pool over a window
+func (u AnalyticsController) GetVolume(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID, from, to, ok := parseRequest(c)
+	if !ok {
+		return
+	}
+
+	volume, err := u.Analytics.GetVolume(ctx, poolID, from, to)
+	if err != nil {
+		log.Errorf("Error getting the volume of pool %s: %v", poolID, err)
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool Volume", volume)
+}
+
+// GetFees returns the swap fees earned in a pool over a window
+func (u AnalyticsController) GetFees(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID, from, to, ok := parseRequest(c)
+	if !ok {
+		return
+	}
+
+	fees, err := u.Analytics.GetFees(ctx, poolID, from, to)
+	if err != nil {
+		log.Errorf("Error getting the fees of pool %s: %v", poolID, err)
+		if errors.Is(err, uniswapv3_pool.ErrNotAV3Pool) {
+			controller.RespondWithError(c, http.StatusBadRequest, constants.InvalidPool)
+			return
+		}
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool Fees", fees)
+}
+
+// GetTVL returns the value locked in a pool at the start and end of a window
+func (u AnalyticsController) GetTVL(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID, from, to, ok := parseRequest(c)
+	if !ok {
+		return
+	}
+
+	tvl, err := u.Analytics.GetTVL(ctx, poolID, from, to)
+	if err != nil {
+		log.Errorf("Error getting the TVL of pool %s: %v", poolID, err)
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool TVL", tvl)
+}
+
+// parseRequest validates the pool and the window of a request, responding
+// with an error if either is invalid
+func parseRequest(c *gin.Context) (string, time.Time, time.Time, bool) {
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	if !common.IsHexAddress(poolID) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return "", time.Time{}, time.Time{}, false
+	}
+
+	from, to, err := parseWindow(c, time.Now().UTC())
+	if err != nil {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return "", time.Time{}, time.Time{}, false
+	}
+	return poolID, from, to, true
+}
+
+// parseWindow reads the window of a request, either as a duration ending at
+// to, like 24h or 7d, or as from and to in unix seconds. to defaults to now
+// and the window to 24h.
+func parseWindow(c *gin.Context, now time.Time) (time.Time, time.Time, error) {
+	to := now
+	if raw, ok := c.GetQuery("to"); ok {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidWindow
+		}
+		to = time.Unix(seconds, 0).UTC()
+	}
+
+	rawWindow, hasWindow := c.GetQuery("window")
+	rawFrom, hasFrom := c.GetQuery("from")
+	if hasWindow && hasFrom {
+		return time.Time{}, time.Time{}, errInvalidWindow
+	}
+
+	from := to.Add(-defaultWindow)
+	switch {
+	case hasWindow:
+		window, err := parseDuration(rawWindow)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = to.Add(-window)
+	case hasFrom:
+		seconds, err := strconv.ParseInt(rawFrom, 10, 64)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidWindow
+		}
+		from = time.Unix(seconds, 0).UTC()
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errInvalidWindow
+	}
+	return from, to, nil
+}
+
+// parseDuration parses a positive number of hours or days, like 24h or 7d
+func parseDuration(raw string) (time.Duration, error) {
+	if len(raw) < 2 {
+		return 0, errInvalidWindow
+	}
+
+	var unit time.Duration
+	switch raw[len(raw)-1] {
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	default:
+		return 0, errInvalidWindow
+	}
+
+	n, err := strconv.ParseUint(raw[:len(raw)-1], 10, 16)
+	if err != nil || n == 0 {
+		return 0, errInvalidWindow
+	}
+	return time.Duration(n) * unit, nil
+}
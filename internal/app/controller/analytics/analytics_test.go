@@ -0,0 +1,170 @@
+package analytics
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+	mockService "uniswapper/internal/app/service/util/testutils/mocks/service/pool"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const poolID = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+func TestAnalytics(t *testing.T) {
+	setupTest(t)
+
+	from, to := time.Unix(1700000000, 0).UTC(), time.Unix(1700086400, 0).UTC()
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(service *mockService.MockIAnalyticsService)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "volume status ok 200",
+			url:  fmt.Sprintf("/pool/%s/volume?from=%d&to=%d", poolID, from.Unix(), to.Unix()),
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+				service.
+					EXPECT().
+					GetVolume(gomock.Any(), poolID, from, to).
+					Return(&uniswapv3_pool.Volume{Address: poolID, From: from, To: to}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "fees status ok 200 window",
+			url:  fmt.Sprintf("/pool/%s/fees?window=7d&to=%d", poolID, to.Unix()),
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+				service.
+					EXPECT().
+					GetFees(gomock.Any(), poolID, to.Add(-7*24*time.Hour), to).
+					Return(&uniswapv3_pool.Fees{Address: poolID, FeeTier: 500}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "tvl status ok 200 default window",
+			url:  fmt.Sprintf("/pool/%s/tvl?to=%d", poolID, to.Unix()),
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+				service.
+					EXPECT().
+					GetTVL(gomock.Any(), poolID, to.Add(-24*time.Hour), to).
+					Return(&uniswapv3_pool.TVL{Address: poolID}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "fees invalid pool 400",
+			url:  fmt.Sprintf("/pool/%s/fees?window=24h", poolID),
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+				service.
+					EXPECT().
+					GetFees(gomock.Any(), poolID, gomock.Any(), gomock.Any()).
+					Return(nil, uniswapv3_pool.ErrNotAV3Pool).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "volume status 500",
+			url:  fmt.Sprintf("/pool/%s/volume", poolID),
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+				service.
+					EXPECT().
+					GetVolume(gomock.Any(), poolID, gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("error while querying the database")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "bad window 400",
+			url:  fmt.Sprintf("/pool/%s/volume?window=3w", poolID),
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "window and from 400",
+			url:  fmt.Sprintf("/pool/%s/tvl?window=1d&from=%d", poolID, from.Unix()),
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad range 400",
+			url:  fmt.Sprintf("/pool/%s/fees?from=%d&to=%d", poolID, to.Unix(), from.Unix()),
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			url:  "/pool/123/volume",
+			buildStubs: func(service *mockService.MockIAnalyticsService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAnalytics := mockService.NewMockIAnalyticsService(ctrl)
+			tc.buildStubs(mockAnalytics)
+
+			controller := NewAnalyticsController(mockAnalytics)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/pool/:pool_id/volume", controller.GetVolume)
+			router.GET("/pool/:pool_id/fees", controller.GetFees)
+			router.GET("/pool/:pool_id/tvl", controller.GetTVL)
+
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
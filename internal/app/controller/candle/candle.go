@@ -0,0 +1,93 @@
+package candle
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/controller"
+	poolDTO "uniswapper/internal/app/db/dto/pool"
+	poolDB "uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/correlation"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// maxCandles bounds the number of candles served per request
+const maxCandles = 1000
+
+// ICandleController represents the interface for CandleController
+type ICandleController interface {
+	GetCandles(c *gin.Context)
+}
+
+// CandleController serves the OHLCV candles of pools
+type CandleController struct {
+	CandleDBClient poolDB.ICandleRepository
+}
+
+// NewCandleController creates a new instance of CandleController
+func NewCandleController(candleDBClient poolDB.ICandleRepository) ICandleController {
+	return &CandleController{
+		CandleDBClient: candleDBClient,
+	}
+}
+
+// GetCandles returns the candles of an interval starting between from and
+// to, given in unix seconds. to defaults to now and from to the maximum
+// number of candles before it.
+func (u CandleController) GetCandles(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	if !common.IsHexAddress(poolID) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	resolution := c.Query("interval")
+	length, ok := poolDTO.CANDLE_RESOLUTIONS[resolution]
+	if !ok {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	to := time.Now().UTC()
+	if raw, ok := c.GetQuery("to"); ok {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+			return
+		}
+		to = time.Unix(seconds, 0).UTC()
+	}
+
+	from := to.Add(-maxCandles * length)
+	if raw, ok := c.GetQuery("from"); ok {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+			return
+		}
+		from = time.Unix(seconds, 0).UTC()
+	}
+
+	if from.After(to) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	candles, err := u.CandleDBClient.GetCandles(ctx, common.HexToAddress(poolID).String(), resolution, from, to, maxCandles)
+	if err != nil {
+		log.Errorf("Error getting candles of pool %s: %v", poolID, err)
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool Candles", candles)
+}
@@ -0,0 +1,140 @@
+package candle
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	poolDTO "uniswapper/internal/app/db/dto/pool"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+	mockDB "uniswapper/internal/app/service/util/testutils/mocks/repository/pool"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const poolID = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+func TestGetCandles(t *testing.T) {
+	setupTest(t)
+
+	from, to := time.Unix(1700000000, 0).UTC(), time.Unix(1700003600, 0).UTC()
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(store *mockDB.MockICandleRepository)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status ok 200",
+			url:  fmt.Sprintf("/pool/%s/candles?interval=5m&from=%d&to=%d", poolID, from.Unix(), to.Unix()),
+			buildStubs: func(store *mockDB.MockICandleRepository) {
+				store.
+					EXPECT().
+					GetCandles(gomock.Any(), poolID, "5m", from, to, maxCandles).
+					Return([]poolDTO.Candle{{PoolAddress: poolID, Resolution: "5m", StartTime: from}}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "status ok 200 default range",
+			url:  fmt.Sprintf("/pool/%s/candles?interval=1h&to=%d", poolID, to.Unix()),
+			buildStubs: func(store *mockDB.MockICandleRepository) {
+				store.
+					EXPECT().
+					GetCandles(gomock.Any(), poolID, "1h", to.Add(-maxCandles*time.Hour), to, maxCandles).
+					Return([]poolDTO.Candle{}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "status 500",
+			url:  fmt.Sprintf("/pool/%s/candles?interval=1d&from=%d&to=%d", poolID, from.Unix(), to.Unix()),
+			buildStubs: func(store *mockDB.MockICandleRepository) {
+				store.
+					EXPECT().
+					GetCandles(gomock.Any(), poolID, "1d", from, to, maxCandles).
+					Return(nil, fmt.Errorf("error while querying the database")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "bad interval 400",
+			url:  fmt.Sprintf("/pool/%s/candles?interval=2m", poolID),
+			buildStubs: func(store *mockDB.MockICandleRepository) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad range 400",
+			url:  fmt.Sprintf("/pool/%s/candles?interval=1m&from=%d&to=%d", poolID, to.Unix(), from.Unix()),
+			buildStubs: func(store *mockDB.MockICandleRepository) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad timestamp 400",
+			url:  fmt.Sprintf("/pool/%s/candles?interval=1m&from=yesterday", poolID),
+			buildStubs: func(store *mockDB.MockICandleRepository) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			url:  "/pool/123/candles?interval=1m",
+			buildStubs: func(store *mockDB.MockICandleRepository) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCandleDBClient := mockDB.NewMockICandleRepository(ctrl)
+			tc.buildStubs(mockCandleDBClient)
+
+			controller := NewCandleController(mockCandleDBClient)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/pool/:pool_id/candles", controller.GetCandles)
+
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
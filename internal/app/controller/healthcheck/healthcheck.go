@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"uniswapper/internal/app/constants"
 	"uniswapper/internal/app/controller"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	"uniswapper/internal/app/service/rpc"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,12 +15,21 @@ type IHealthCheckController interface {
 }
 
 type HealthCheckController struct {
+	UniswapV3Pool uniswapv3_pool.IUniswapV3Pool
+	RPCClient     *rpc.Client
 }
 
-func NewHealthCheckController() IHealthCheckController {
-	return &HealthCheckController{}
+func NewHealthCheckController(uniswapV3Pool uniswapv3_pool.IUniswapV3Pool, rpcClient *rpc.Client) IHealthCheckController {
+	return &HealthCheckController{
+		UniswapV3Pool: uniswapV3Pool,
+		RPCClient:     rpcClient,
+	}
 }
 
 func (h *HealthCheckController) HealthCheck(c *gin.Context) {
-	controller.RespondWithSuccess(c, http.StatusOK, "version", gin.H{"version": constants.Config.ProjectVersion})
+	controller.RespondWithSuccess(c, http.StatusOK, "version", gin.H{
+		"version":              constants.Config.ProjectVersion,
+		"ingestion_reconnects": h.UniswapV3Pool.ReconnectCount(),
+		"rpc_endpoints":        h.RPCClient.Stats(),
+	})
 }
@@ -0,0 +1,71 @@
+package liquidity
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/controller"
+	"uniswapper/internal/app/service/correlation"
+	"uniswapper/internal/app/service/logger"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// ILiquidityController represents the interface for LiquidityController
+type ILiquidityController interface {
+	GetPoolLiquidity(c *gin.Context)
+}
+
+// LiquidityController serves the tick liquidity of pools
+type LiquidityController struct {
+	Liquidity uniswapv3_pool.ILiquidityService
+}
+
+// NewLiquidityController creates a new instance of LiquidityController
+func NewLiquidityController(liquidity uniswapv3_pool.ILiquidityService) ILiquidityController {
+	return &LiquidityController{
+		Liquidity: liquidity,
+	}
+}
+
+func (u LiquidityController) GetPoolLiquidity(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	if !common.IsHexAddress(poolID) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	var blockNumber *uint64
+	if block, ok := c.GetQuery("block"); ok {
+		n, err := strconv.ParseUint(block, 10, 64)
+		if err != nil {
+			controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+			return
+		}
+		blockNumber = &n
+	}
+
+	liquidity, err := u.Liquidity.GetLiquidity(ctx, poolID, blockNumber)
+	if err != nil {
+		log.Errorf("Error reading liquidity of pool %s: %v", poolID, err)
+		switch {
+		case errors.Is(err, uniswapv3_pool.ErrNoLiquidityHistory):
+			controller.RespondWithError(c, http.StatusNotFound, constants.NotFound)
+		case errors.Is(err, uniswapv3_pool.ErrBlockBeforeHistory):
+			controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		default:
+			controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		}
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool Liquidity", liquidity)
+}
@@ -0,0 +1,149 @@
+package liquidity
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+	mockService "uniswapper/internal/app/service/util/testutils/mocks/service/pool"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const poolID = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+func TestGetPoolLiquidity(t *testing.T) {
+	setupTest(t)
+
+	block := uint64(17000000)
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(liquidity *mockService.MockILiquidityService)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status ok 200",
+			url:  fmt.Sprintf("/pool/%s/liquidity", poolID),
+			buildStubs: func(liquidity *mockService.MockILiquidityService) {
+				liquidity.
+					EXPECT().
+					GetLiquidity(gomock.Any(), poolID, nil).
+					Return(&uniswapv3_pool.LiquidityDistribution{Address: poolID}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "status ok 200 at block",
+			url:  fmt.Sprintf("/pool/%s/liquidity?block=%d", poolID, block),
+			buildStubs: func(liquidity *mockService.MockILiquidityService) {
+				liquidity.
+					EXPECT().
+					GetLiquidity(gomock.Any(), poolID, &block).
+					Return(&uniswapv3_pool.LiquidityDistribution{Address: poolID, BlockNumber: &block}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "block before history 400",
+			url:  fmt.Sprintf("/pool/%s/liquidity?block=%d", poolID, block),
+			buildStubs: func(liquidity *mockService.MockILiquidityService) {
+				liquidity.
+					EXPECT().
+					GetLiquidity(gomock.Any(), poolID, &block).
+					Return(nil, uniswapv3_pool.ErrBlockBeforeHistory).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "not seeded 404",
+			url:  fmt.Sprintf("/pool/%s/liquidity", poolID),
+			buildStubs: func(liquidity *mockService.MockILiquidityService) {
+				liquidity.
+					EXPECT().
+					GetLiquidity(gomock.Any(), poolID, nil).
+					Return(nil, uniswapv3_pool.ErrNoLiquidityHistory).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "status 500",
+			url:  fmt.Sprintf("/pool/%s/liquidity", poolID),
+			buildStubs: func(liquidity *mockService.MockILiquidityService) {
+				liquidity.
+					EXPECT().
+					GetLiquidity(gomock.Any(), poolID, nil).
+					Return(nil, fmt.Errorf("error while querying the database")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "bad block 400",
+			url:  fmt.Sprintf("/pool/%s/liquidity?block=latest", poolID),
+			buildStubs: func(liquidity *mockService.MockILiquidityService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			url:  "/pool/123/liquidity",
+			buildStubs: func(liquidity *mockService.MockILiquidityService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLiquidity := mockService.NewMockILiquidityService(ctrl)
+			tc.buildStubs(mockLiquidity)
+
+			controller := NewLiquidityController(mockLiquidity)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/pool/:pool_id/liquidity", controller.GetPoolLiquidity)
+
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
@@ -8,11 +8,14 @@ import (
 
 	"uniswapper/internal/app/constants"
 	"uniswapper/internal/app/controller"
+	poolDTO "uniswapper/internal/app/db/dto/pool"
 	poolDB "uniswapper/internal/app/db/repository/pool"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
 
 	"uniswapper/internal/app/service/correlation"
 	"uniswapper/internal/app/service/logger"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 )
 
@@ -25,14 +28,20 @@ type IPoolController interface {
 // PoolController is the implementation of the IPoolController interface
 type PoolController struct {
 	PoolDBClient poolDB.IPoolLogsRepository
+	PoolMetadata uniswapv3_pool.IPoolMetadataService
+	Prices       uniswapv3_pool.IPriceService
 }
 
 // NewPoolController creates a new instance of PoolController
 func NewPoolController(
 	poolDBClient poolDB.IPoolLogsRepository,
+	poolMetadata uniswapv3_pool.IPoolMetadataService,
+	prices uniswapv3_pool.IPriceService,
 ) IPoolController {
 	return &PoolController{
 		PoolDBClient: poolDBClient,
+		PoolMetadata: poolMetadata,
+		Prices:       prices,
 	}
 }
 
@@ -55,8 +64,13 @@ func (u PoolController) GetPoolLogsById(c *gin.Context) {
 		return
 	}
 
+	u.fillPrices(c, &logs)
+
 	// Respond with success and the list of users
-	controller.RespondWithSuccess(c, http.StatusAccepted, "Requested Log Info", logs)
+	controller.RespondWithSuccess(c, http.StatusAccepted, "Requested Log Info", gin.H{
+		"pool": u.poolMetadata(c, poolID),
+		"log":  logs,
+	})
 }
 
 func (u PoolController) GetPoolLogsHistory(c *gin.Context) {
@@ -76,6 +90,52 @@ func (u PoolController) GetPoolLogsHistory(c *gin.Context) {
 		return
 	}
 
+	for i := range logs {
+		u.fillPrices(c, &logs[i])
+	}
+
 	// Respond with success and the list of users
-	controller.RespondWithSuccess(c, http.StatusAccepted, "Requested Log Info", logs)
+	controller.RespondWithSuccess(c, http.StatusAccepted, "Requested Log Info", gin.H{
+		"pool": u.poolMetadata(c, poolID),
+		"logs": logs,
+	})
+}
+
+// poolMetadata resolves the pool and token metadata included in the
+// responses. The logs are still served if it cannot be resolved.
+func (u PoolController) poolMetadata(c *gin.Context, poolID string) *uniswapv3_pool.PoolMetadata {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	if !common.IsHexAddress(poolID) {
+		return nil
+	}
+
+	metadata, err := u.PoolMetadata.GetPoolMetadata(ctx, poolID)
+	if err != nil {
+		log.Warnf("Error resolving metadata of pool %s: %v", poolID, err)
+		return nil
+	}
+	return metadata
+}
+
+// fillPrices derives the prices of logs stored without them, e.g. before
+// prices were recorded, from their tick
+func (u PoolController) fillPrices(c *gin.Context, logs *poolDTO.Logs) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	if logs.Token1PerToken0 != nil || !common.IsHexAddress(logs.PoolAddress) {
+		return
+	}
+
+	price, err := u.Prices.GetPriceAtTick(ctx, logs.PoolAddress, int(logs.Tick))
+	if err != nil {
+		log.Warnf("Error pricing log %s of pool %s: %v", logs.TxnId, logs.PoolAddress, err)
+		return
+	}
+	if price != nil {
+		logs.Token1PerToken0 = &price.Token1PerToken0
+		logs.Token0PerToken1 = &price.Token0PerToken1
+	}
 }
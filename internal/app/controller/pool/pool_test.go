@@ -6,8 +6,10 @@ import (
 	"net/http/httptest"
 	"testing"
 	poolDTO "uniswapper/internal/app/db/dto/pool"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
 	testutils "uniswapper/internal/app/service/util/testutils/mocks"
 	mockDB "uniswapper/internal/app/service/util/testutils/mocks/repository/pool"
+	mockService "uniswapper/internal/app/service/util/testutils/mocks/service/pool"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -82,7 +84,10 @@ func TestGetPoolLogsById(t *testing.T) {
 			mockPoolDBClient := mockDB.NewMockIPoolLogsRepository(ctrl)
 			tc.buildStubs(mockPoolDBClient)
 
-			controller := NewPoolController(mockPoolDBClient)
+			mockPoolMetadata := mockService.NewMockIPoolMetadataService(ctrl)
+			mockPrices := mockService.NewMockIPriceService(ctrl)
+
+			controller := NewPoolController(mockPoolDBClient, mockPoolMetadata, mockPrices)
 
 			gin.SetMode(gin.TestMode)
 			router := gin.Default()
@@ -160,7 +165,10 @@ func TestGetHistoryPoolLogs(t *testing.T) {
 			mockPoolDBClient := mockDB.NewMockIPoolLogsRepository(ctrl)
 			tc.buildStubs(mockPoolDBClient)
 
-			controller := NewPoolController(mockPoolDBClient)
+			mockPoolMetadata := mockService.NewMockIPoolMetadataService(ctrl)
+			mockPrices := mockService.NewMockIPriceService(ctrl)
+
+			controller := NewPoolController(mockPoolDBClient, mockPoolMetadata, mockPrices)
 
 			gin.SetMode(gin.TestMode)
 			router := gin.Default()
@@ -175,3 +183,123 @@ func TestGetHistoryPoolLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPoolLogsByAddress(t *testing.T) {
+	setupTest(t)
+
+	poolID := "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+	blockID := "17000000"
+	token1PerToken0, token0PerToken1 := "0.0005", "2000"
+
+	metadata := &uniswapv3_pool.PoolMetadata{
+		Address:     poolID,
+		Token0:      poolDTO.Token{Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Symbol: "USDC", Decimals: 6},
+		Token1:      poolDTO.Token{Address: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", Symbol: "WETH", Decimals: 18},
+		Fee:         500,
+		TickSpacing: 10,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockDB.MockIPoolLogsRepository, poolMetadata *mockService.MockIPoolMetadataService, prices *mockService.MockIPriceService)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "prices derived from the tick",
+			buildStubs: func(store *mockDB.MockIPoolLogsRepository, poolMetadata *mockService.MockIPoolMetadataService, prices *mockService.MockIPriceService) {
+				store.
+					EXPECT().
+					GetPoolLogs(gomock.Any(), poolID, blockID).
+					Return(poolDTO.Logs{PoolAddress: poolID, Tick: 201234}, nil).
+					Times(1)
+				prices.
+					EXPECT().
+					GetPriceAtTick(gomock.Any(), poolID, 201234).
+					Return(&uniswapv3_pool.Price{Token1PerToken0: token1PerToken0, Token0PerToken1: token0PerToken1}, nil).
+					Times(1)
+				poolMetadata.
+					EXPECT().
+					GetPoolMetadata(gomock.Any(), poolID).
+					Return(metadata, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusAccepted, resp.Code)
+				assert.Contains(t, resp.Body.String(), `"token1_per_token0":"0.0005"`)
+				assert.Contains(t, resp.Body.String(), `"symbol":"WETH"`)
+			},
+		},
+		{
+			name: "stored prices",
+			buildStubs: func(store *mockDB.MockIPoolLogsRepository, poolMetadata *mockService.MockIPoolMetadataService, prices *mockService.MockIPriceService) {
+				store.
+					EXPECT().
+					GetPoolLogs(gomock.Any(), poolID, blockID).
+					Return(poolDTO.Logs{PoolAddress: poolID, Tick: 201234, Token1PerToken0: &token1PerToken0, Token0PerToken1: &token0PerToken1}, nil).
+					Times(1)
+				poolMetadata.
+					EXPECT().
+					GetPoolMetadata(gomock.Any(), poolID).
+					Return(metadata, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusAccepted, resp.Code)
+				assert.Contains(t, resp.Body.String(), `"token0_per_token1":"2000"`)
+			},
+		},
+		{
+			name: "unresolved metadata and prices",
+			buildStubs: func(store *mockDB.MockIPoolLogsRepository, poolMetadata *mockService.MockIPoolMetadataService, prices *mockService.MockIPriceService) {
+				store.
+					EXPECT().
+					GetPoolLogs(gomock.Any(), poolID, blockID).
+					Return(poolDTO.Logs{PoolAddress: poolID, Tick: 201234}, nil).
+					Times(1)
+				prices.
+					EXPECT().
+					GetPriceAtTick(gomock.Any(), poolID, 201234).
+					Return(nil, fmt.Errorf("error while getting decimals")).
+					Times(1)
+				poolMetadata.
+					EXPECT().
+					GetPoolMetadata(gomock.Any(), poolID).
+					Return(nil, fmt.Errorf("error while getting metadata")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				// The logs are still served
+				assert.Equal(t, http.StatusAccepted, resp.Code)
+				assert.Contains(t, resp.Body.String(), `"pool":null`)
+				assert.Contains(t, resp.Body.String(), `"token1_per_token0":null`)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPoolDBClient := mockDB.NewMockIPoolLogsRepository(ctrl)
+			mockPoolMetadata := mockService.NewMockIPoolMetadataService(ctrl)
+			mockPrices := mockService.NewMockIPriceService(ctrl)
+			tc.buildStubs(mockPoolDBClient, mockPoolMetadata, mockPrices)
+
+			controller := NewPoolController(mockPoolDBClient, mockPoolMetadata, mockPrices)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/pool/:pool_id", controller.GetPoolLogsById)
+
+			req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/pool/%s?block=%s", poolID, blockID), nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package position
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/controller"
+	"uniswapper/internal/app/service/correlation"
+	"uniswapper/internal/app/service/logger"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	"uniswapper/internal/app/service/v3math/tickmath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// IPositionController represents the interface for PositionController
+type IPositionController interface {
+	GetPositions(c *gin.Context)
+	GetPosition(c *gin.Context)
+	GetFees(c *gin.Context)
+	GetPositionPnL(c *gin.Context)
+	GetRangePnL(c *gin.Context)
+}
+
+const (
+	// defaultPnLPeriod is the period reported when from is not given
+	defaultPnLPeriod = 30 * 24 * time.Hour
+	// maxPnLPeriod bounds the swaps replayed by a request
+	maxPnLPeriod = 366 * 24 * time.Hour
+)
+
+// PositionController serves the tracked LP positions and the performance
+// of positions over time
+type PositionController struct {
+	Positions uniswapv3_pool.IPositionService
+	PnL       uniswapv3_pool.IPnLService
+}
+
+// NewPositionController creates a new instance of PositionController
+func NewPositionController(positions uniswapv3_pool.IPositionService, pnl uniswapv3_pool.IPnLService) IPositionController {
+	return &PositionController{
+		Positions: positions,
+		PnL:       pnl,
+	}
+}
+
+// GetPositions returns the tracked positions, filtered by the optional owner
+func (u PositionController) GetPositions(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	owner := strings.TrimSpace(c.Query("owner"))
+	if owner != "" && !common.IsHexAddress(owner) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	positions, err := u.Positions.GetPositions(ctx, owner)
+	if err != nil {
+		log.Errorf("Error getting positions: %v", err)
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Positions", positions)
+}
+
+// GetPosition returns a tracked position by token ID
+func (u PositionController) GetPosition(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	tokenID, ok := parseTokenID(c)
+	if !ok {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	position, err := u.Positions.GetPosition(ctx, tokenID)
+	if err != nil {
+		log.Errorf("Error getting position %s: %v", tokenID.String(), err)
+		if errors.Is(err, uniswapv3_pool.ErrPositionNotFound) {
+			controller.RespondWithError(c, http.StatusNotFound, constants.NotFound)
+			return
+		}
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Position", position)
+}
+
+// GetFees returns the uncollected fees of a tracked position
+func (u PositionController) GetFees(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	tokenID, ok := parseTokenID(c)
+	if !ok {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	fees, err := u.Positions.GetFees(ctx, tokenID)
+	if err != nil {
+		log.Errorf("Error getting the fees of position %s: %v", tokenID.String(), err)
+		if errors.Is(err, uniswapv3_pool.ErrPositionNotFound) {
+			controller.RespondWithError(c, http.StatusNotFound, constants.NotFound)
+			return
+		}
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Position Fees", fees)
+}
+
+// GetPositionPnL returns the daily impermanent loss, fees and PnL of a
+// tracked position over a period
+func (u PositionController) GetPositionPnL(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	tokenID, ok := parseTokenID(c)
+	if !ok {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	from, to, ok := parsePeriod(c, time.Now().UTC())
+	if !ok {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	pnl, err := u.PnL.GetPositionPnL(ctx, tokenID, from, to)
+	if err != nil {
+		log.Errorf("Error getting the PnL of position %s: %v", tokenID.String(), err)
+		respondWithPnLError(c, err)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Position PnL", pnl)
+}
+
+// GetRangePnL returns the daily impermanent loss, fees and PnL of a
+// hypothetical deposit of amount0 and amount1 into a range of a pool
+func (u PositionController) GetRangePnL(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	if !common.IsHexAddress(poolID) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	tickLower, okLower := parseTick(c.Query("tick_lower"))
+	tickUpper, okUpper := parseTick(c.Query("tick_upper"))
+	if !okLower || !okUpper || tickLower >= tickUpper {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	amount0, ok0 := parseAmount(c.Query("amount0"))
+	amount1, ok1 := parseAmount(c.Query("amount1"))
+	if !ok0 || !ok1 || amount0.Sign()+amount1.Sign() == 0 {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	from, to, ok := parsePeriod(c, time.Now().UTC())
+	if !ok {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	pnl, err := u.PnL.GetRangePnL(ctx, poolID, tickLower, tickUpper, amount0, amount1, from, to)
+	if err != nil {
+		log.Errorf("Error getting the PnL of range [%d, %d) of pool %s: %v", tickLower, tickUpper, poolID, err)
+		respondWithPnLError(c, err)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Range PnL", pnl)
+}
+
+// respondWithPnLError maps the errors of the PnL service to responses
+func respondWithPnLError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, uniswapv3_pool.ErrNotAV3Pool):
+		controller.RespondWithError(c, http.StatusBadRequest, constants.InvalidPool)
+	case errors.Is(err, uniswapv3_pool.ErrInvalidDeposit):
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+	case errors.Is(err, uniswapv3_pool.ErrPositionNotFound), errors.Is(err, uniswapv3_pool.ErrNoTickHistory):
+		controller.RespondWithError(c, http.StatusNotFound, constants.NotFound)
+	default:
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+}
+
+// parsePeriod reads from and to in unix seconds. to defaults to now and from
+// to defaultPnLPeriod before it; longer periods than maxPnLPeriod are invalid.
+func parsePeriod(c *gin.Context, now time.Time) (time.Time, time.Time, bool) {
+	to := now
+	if raw, ok := c.GetQuery("to"); ok {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		to = time.Unix(seconds, 0).UTC()
+	}
+
+	from := to.Add(-defaultPnLPeriod)
+	if raw, ok := c.GetQuery("from"); ok {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		from = time.Unix(seconds, 0).UTC()
+	}
+
+	if from.After(to) || to.Sub(from) > maxPnLPeriod {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// parseTick reads a tick accepted by tickmath
+func parseTick(raw string) (int32, bool) {
+	tick, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 32)
+	if err != nil || tick < tickmath.MIN_TICK || tick > tickmath.MAX_TICK {
+		return 0, false
+	}
+	return int32(tick), true
+}
+
+// parseAmount reads a non-negative amount in base units, zero if empty
+func parseAmount(raw string) (*big.Int, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return new(big.Int), true
+	}
+	amount, ok := new(big.Int).SetString(raw, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, false
+	}
+	return amount, true
+}
+
+// parseTokenID reads the token ID of the position in the path
+func parseTokenID(c *gin.Context) (*big.Int, bool) {
+	tokenID, ok := new(big.Int).SetString(strings.TrimSpace(c.Param("id")), 10)
+	if !ok || tokenID.Sign() < 0 {
+		return nil, false
+	}
+	return tokenID, true
+}
@@ -0,0 +1,439 @@
+package position
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+	poolDTO "uniswapper/internal/app/db/dto/pool"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+	mockService "uniswapper/internal/app/service/util/testutils/mocks/service/pool"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const owner = "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+func TestGetPositions(t *testing.T) {
+	setupTest(t)
+
+	position := uniswapv3_pool.PositionDetails{
+		Position: poolDTO.Position{TokenId: numeric.NewBigInt(big.NewInt(42)), Owner: owner},
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(service *mockService.MockIPositionService)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status ok 200",
+			url:  "/position",
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetPositions(gomock.Any(), "").
+					Return([]uniswapv3_pool.PositionDetails{position}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "status ok 200 by owner",
+			url:  fmt.Sprintf("/position?owner=%s", owner),
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetPositions(gomock.Any(), owner).
+					Return([]uniswapv3_pool.PositionDetails{}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "status 500",
+			url:  "/position",
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetPositions(gomock.Any(), "").
+					Return(nil, fmt.Errorf("error while querying the database")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "bad owner 400",
+			url:  "/position?owner=123",
+			buildStubs: func(service *mockService.MockIPositionService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "position status ok 200",
+			url:  "/position/42",
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetPosition(gomock.Any(), big.NewInt(42)).
+					Return(&position, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "position not found 404",
+			url:  "/position/7",
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetPosition(gomock.Any(), big.NewInt(7)).
+					Return(nil, uniswapv3_pool.ErrPositionNotFound).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "position status 500",
+			url:  "/position/42",
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetPosition(gomock.Any(), big.NewInt(42)).
+					Return(nil, fmt.Errorf("error while querying the database")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "fees status ok 200",
+			url:  "/position/42/fees",
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetFees(gomock.Any(), big.NewInt(42)).
+					Return(&uniswapv3_pool.PositionFees{TokenId: numeric.NewBigInt(big.NewInt(42))}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "fees not found 404",
+			url:  "/position/7/fees",
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetFees(gomock.Any(), big.NewInt(7)).
+					Return(nil, uniswapv3_pool.ErrPositionNotFound).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "fees status 500",
+			url:  "/position/42/fees",
+			buildStubs: func(service *mockService.MockIPositionService) {
+				service.
+					EXPECT().
+					GetFees(gomock.Any(), big.NewInt(42)).
+					Return(nil, fmt.Errorf("error while calling the node")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "fees bad token ID 400",
+			url:  "/position/-1/fees",
+			buildStubs: func(service *mockService.MockIPositionService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad token ID 400",
+			url:  "/position/abc",
+			buildStubs: func(service *mockService.MockIPositionService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPositions := mockService.NewMockIPositionService(ctrl)
+			tc.buildStubs(mockPositions)
+
+			controller := NewPositionController(mockPositions, mockService.NewMockIPnLService(ctrl))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/position", controller.GetPositions)
+			router.GET("/position/:id", controller.GetPosition)
+			router.GET("/position/:id/fees", controller.GetFees)
+
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
+
+func TestGetPnL(t *testing.T) {
+	setupTest(t)
+
+	const poolID = "0x8ad599c3A0ff1De082011EFDDc58f1908eb6e6D8"
+	from, to := time.Unix(1790000000, 0).UTC(), time.Unix(1790864000, 0).UTC()
+	pnl := uniswapv3_pool.PnL{PoolAddress: poolID}
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(service *mockService.MockIPnLService)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "position status ok 200",
+			url:  fmt.Sprintf("/position/42/pnl?from=%d&to=%d", from.Unix(), to.Unix()),
+			buildStubs: func(service *mockService.MockIPnLService) {
+				service.
+					EXPECT().
+					GetPositionPnL(gomock.Any(), big.NewInt(42), from, to).
+					Return(&pnl, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "position default period 200",
+			url:  "/position/42/pnl",
+			buildStubs: func(service *mockService.MockIPnLService) {
+				service.
+					EXPECT().
+					GetPositionPnL(gomock.Any(), big.NewInt(42), gomock.Any(), gomock.Any()).
+					Return(&pnl, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "position not found 404",
+			url:  "/position/7/pnl",
+			buildStubs: func(service *mockService.MockIPnLService) {
+				service.
+					EXPECT().
+					GetPositionPnL(gomock.Any(), big.NewInt(7), gomock.Any(), gomock.Any()).
+					Return(nil, uniswapv3_pool.ErrPositionNotFound).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "position no history 404",
+			url:  "/position/42/pnl",
+			buildStubs: func(service *mockService.MockIPnLService) {
+				service.
+					EXPECT().
+					GetPositionPnL(gomock.Any(), big.NewInt(42), gomock.Any(), gomock.Any()).
+					Return(nil, uniswapv3_pool.ErrNoTickHistory).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "position status 500",
+			url:  "/position/42/pnl",
+			buildStubs: func(service *mockService.MockIPnLService) {
+				service.
+					EXPECT().
+					GetPositionPnL(gomock.Any(), big.NewInt(42), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("error while querying the database")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "position period too long 400",
+			url:  fmt.Sprintf("/position/42/pnl?from=%d&to=%d", from.Unix(), from.AddDate(2, 0, 0).Unix()),
+			buildStubs: func(service *mockService.MockIPnLService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "position from after to 400",
+			url:  fmt.Sprintf("/position/42/pnl?from=%d&to=%d", to.Unix(), from.Unix()),
+			buildStubs: func(service *mockService.MockIPnLService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "range status ok 200",
+			url:  fmt.Sprintf("/pool/%s/pnl?tick_lower=-600&tick_upper=600&amount0=1000&from=%d&to=%d", poolID, from.Unix(), to.Unix()),
+			buildStubs: func(service *mockService.MockIPnLService) {
+				service.
+					EXPECT().
+					GetRangePnL(gomock.Any(), poolID, int32(-600), int32(600), big.NewInt(1000), big.NewInt(0), from, to).
+					Return(&pnl, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "range not a pool 400",
+			url:  fmt.Sprintf("/pool/%s/pnl?tick_lower=-600&tick_upper=600&amount1=1000", owner),
+			buildStubs: func(service *mockService.MockIPnLService) {
+				service.
+					EXPECT().
+					GetRangePnL(gomock.Any(), owner, int32(-600), int32(600), big.NewInt(0), big.NewInt(1000), gomock.Any(), gomock.Any()).
+					Return(nil, uniswapv3_pool.ErrNotAV3Pool).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "range invalid deposit 400",
+			url:  fmt.Sprintf("/pool/%s/pnl?tick_lower=-600&tick_upper=600&amount1=1", poolID),
+			buildStubs: func(service *mockService.MockIPnLService) {
+				service.
+					EXPECT().
+					GetRangePnL(gomock.Any(), poolID, int32(-600), int32(600), big.NewInt(0), big.NewInt(1), gomock.Any(), gomock.Any()).
+					Return(nil, uniswapv3_pool.ErrInvalidDeposit).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "range inverted ticks 400",
+			url:  fmt.Sprintf("/pool/%s/pnl?tick_lower=600&tick_upper=-600&amount0=1000", poolID),
+			buildStubs: func(service *mockService.MockIPnLService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "range tick out of bounds 400",
+			url:  fmt.Sprintf("/pool/%s/pnl?tick_lower=-900000&tick_upper=600&amount0=1000", poolID),
+			buildStubs: func(service *mockService.MockIPnLService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "range no deposit 400",
+			url:  fmt.Sprintf("/pool/%s/pnl?tick_lower=-600&tick_upper=600", poolID),
+			buildStubs: func(service *mockService.MockIPnLService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "range negative amount 400",
+			url:  fmt.Sprintf("/pool/%s/pnl?tick_lower=-600&tick_upper=600&amount0=-1", poolID),
+			buildStubs: func(service *mockService.MockIPnLService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "range bad pool 400",
+			url:  "/pool/123/pnl?tick_lower=-600&tick_upper=600&amount0=1000",
+			buildStubs: func(service *mockService.MockIPnLService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPnL := mockService.NewMockIPnLService(ctrl)
+			tc.buildStubs(mockPnL)
+
+			controller := NewPositionController(mockService.NewMockIPositionService(ctrl), mockPnL)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/position/:id/pnl", controller.GetPositionPnL)
+			router.GET("/pool/:pool_id/pnl", controller.GetRangePnL)
+
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package quote
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/controller"
+	"uniswapper/internal/app/service/correlation"
+	"uniswapper/internal/app/service/logger"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// IQuoteController represents the interface for QuoteController
+type IQuoteController interface {
+	GetQuote(c *gin.Context)
+}
+
+// QuoteController serves simulated swap quotes
+type QuoteController struct {
+	Quotes uniswapv3_pool.IQuoteService
+}
+
+// NewQuoteController creates a new instance of QuoteController
+func NewQuoteController(quotes uniswapv3_pool.IQuoteService) IQuoteController {
+	return &QuoteController{
+		Quotes: quotes,
+	}
+}
+
+// GetQuote quotes swapping tokenIn for the other token of the pool, given
+// either amountIn or amountOut in base units
+func (u QuoteController) GetQuote(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	tokenIn := strings.TrimSpace(c.Query("tokenIn"))
+	if !common.IsHexAddress(poolID) || !common.IsHexAddress(tokenIn) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	amountIn, hasAmountIn := c.GetQuery("amountIn")
+	amountOut, hasAmountOut := c.GetQuery("amountOut")
+	if hasAmountIn == hasAmountOut {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	raw := amountIn
+	if hasAmountOut {
+		raw = amountOut
+	}
+	amount, ok := new(big.Int).SetString(raw, 10)
+	if !ok || amount.Sign() <= 0 {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	quote, err := u.Quotes.GetQuote(ctx, poolID, tokenIn, amount, hasAmountIn)
+	if err != nil {
+		log.Errorf("Error quoting pool %s: %v", poolID, err)
+		switch {
+		case errors.Is(err, uniswapv3_pool.ErrNotAV3Pool):
+			controller.RespondWithError(c, http.StatusBadRequest, constants.InvalidPool)
+		case errors.Is(err, uniswapv3_pool.ErrTokenNotInPool):
+			controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		case errors.Is(err, uniswapv3_pool.ErrInsufficientPoolLiquidity):
+			controller.RespondWithError(c, http.StatusBadRequest, constants.InsufficientLiquidity)
+		case errors.Is(err, uniswapv3_pool.ErrNoLiquidityHistory):
+			controller.RespondWithError(c, http.StatusNotFound, constants.NotFound)
+		case errors.Is(err, uniswapv3_pool.ErrTickMapBehind), errors.Is(err, uniswapv3_pool.ErrBlockBeforeHistory):
+			controller.RespondWithError(c, http.StatusServiceUnavailable, constants.ServiceUnavailable)
+		default:
+			controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		}
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool Quote", quote)
+}
@@ -0,0 +1,208 @@
+package quote
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+	mockService "uniswapper/internal/app/service/util/testutils/mocks/service/pool"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	poolID = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+	usdc   = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+)
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+func TestGetQuote(t *testing.T) {
+	setupTest(t)
+
+	amount := big.NewInt(1000000000)
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(quotes *mockService.MockIQuoteService)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status ok 200 exact input",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountIn=%s", poolID, usdc, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+				quotes.
+					EXPECT().
+					GetQuote(gomock.Any(), poolID, usdc, amount, true).
+					Return(&uniswapv3_pool.Quote{Address: poolID, ExactInput: true}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "status ok 200 exact output",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountOut=%s", poolID, usdc, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+				quotes.
+					EXPECT().
+					GetQuote(gomock.Any(), poolID, usdc, amount, false).
+					Return(&uniswapv3_pool.Quote{Address: poolID}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "token not in pool 400",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountIn=%s", poolID, usdc, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+				quotes.
+					EXPECT().
+					GetQuote(gomock.Any(), poolID, usdc, amount, true).
+					Return(nil, uniswapv3_pool.ErrTokenNotInPool).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "insufficient liquidity 400",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountOut=%s", poolID, usdc, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+				quotes.
+					EXPECT().
+					GetQuote(gomock.Any(), poolID, usdc, amount, false).
+					Return(nil, uniswapv3_pool.ErrInsufficientPoolLiquidity).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "not seeded 404",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountIn=%s", poolID, usdc, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+				quotes.
+					EXPECT().
+					GetQuote(gomock.Any(), poolID, usdc, amount, true).
+					Return(nil, uniswapv3_pool.ErrNoLiquidityHistory).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "tick map behind 503",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountIn=%s", poolID, usdc, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+				quotes.
+					EXPECT().
+					GetQuote(gomock.Any(), poolID, usdc, amount, true).
+					Return(nil, uniswapv3_pool.ErrTickMapBehind).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+			},
+		},
+		{
+			name: "status 500",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountIn=%s", poolID, usdc, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+				quotes.
+					EXPECT().
+					GetQuote(gomock.Any(), poolID, usdc, amount, true).
+					Return(nil, fmt.Errorf("error while calling the node")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "both amounts 400",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountIn=%s&amountOut=%s", poolID, usdc, amount, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "no amount 400",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s", poolID, usdc),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad amount 400",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=%s&amountIn=-5", poolID, usdc),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad token 400",
+			url:  fmt.Sprintf("/pool/%s/quote?tokenIn=usdc&amountIn=%s", poolID, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			url:  fmt.Sprintf("/pool/123/quote?tokenIn=%s&amountIn=%s", usdc, amount),
+			buildStubs: func(quotes *mockService.MockIQuoteService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuotes := mockService.NewMockIQuoteService(ctrl)
+			tc.buildStubs(mockQuotes)
+
+			controller := NewQuoteController(mockQuotes)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/pool/:pool_id/quote", controller.GetQuote)
+
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/controller"
+	"uniswapper/internal/app/service/correlation"
+	"uniswapper/internal/app/service/dto/request"
+	"uniswapper/internal/app/service/logger"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// IRegistryController represents the interface for RegistryController
+type IRegistryController interface {
+	GetPools(c *gin.Context)
+	AddPool(c *gin.Context)
+	RemovePool(c *gin.Context)
+	UpdatePool(c *gin.Context)
+}
+
+// RegistryController manages the tracked pools at runtime
+type RegistryController struct {
+	Registry uniswapv3_pool.IPoolRegistry
+}
+
+// NewRegistryController creates a new instance of RegistryController
+func NewRegistryController(registry uniswapv3_pool.IPoolRegistry) IRegistryController {
+	return &RegistryController{
+		Registry: registry,
+	}
+}
+
+func (u RegistryController) GetPools(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	pools, err := u.Registry.GetPools(ctx)
+	if err != nil {
+		log.Errorf("Error getting pools: %v", err)
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Tracked Pools", pools)
+}
+
+func (u RegistryController) AddPool(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	var req request.AddPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil || !common.IsHexAddress(req.Address) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	pool, err := u.Registry.AddPool(ctx, req.Address)
+	if err != nil {
+		log.Errorf("Error adding pool %s: %v", req.Address, err)
+		respondWithRegistryError(c, err)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusCreated, "Pool Added", pool)
+}
+
+func (u RegistryController) RemovePool(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	if !common.IsHexAddress(poolID) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	if err := u.Registry.RemovePool(ctx, poolID); err != nil {
+		log.Errorf("Error removing pool %s: %v", poolID, err)
+		respondWithRegistryError(c, err)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool Removed", nil)
+}
+
+func (u RegistryController) UpdatePool(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	if !common.IsHexAddress(poolID) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	var req request.UpdatePoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	pool, err := u.Registry.SetPaused(ctx, poolID, *req.Paused)
+	if err != nil {
+		log.Errorf("Error updating pool %s: %v", poolID, err)
+		respondWithRegistryError(c, err)
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool Updated", pool)
+}
+
+// respondWithRegistryError maps the registry errors to their HTTP status
+func respondWithRegistryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, uniswapv3_pool.ErrPoolNotFound):
+		controller.RespondWithError(c, http.StatusNotFound, constants.NotFound)
+	case errors.Is(err, uniswapv3_pool.ErrPoolAlreadyTracked):
+		controller.RespondWithError(c, http.StatusConflict, constants.Conflict)
+	case errors.Is(err, uniswapv3_pool.ErrNotAV3Pool):
+		controller.RespondWithError(c, http.StatusBadRequest, constants.InvalidPool)
+	default:
+		controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+}
@@ -0,0 +1,274 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	poolDTO "uniswapper/internal/app/db/dto/pool"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+	mockService "uniswapper/internal/app/service/util/testutils/mocks/service/pool"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const poolID = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+func TestAddPool(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(registry *mockService.MockIPoolRegistry)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status created 201",
+			body: fmt.Sprintf(`{"address":"%s"}`, poolID),
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+				registry.
+					EXPECT().
+					AddPool(gomock.Any(), poolID).
+					Return(&poolDTO.Pool{Address: poolID, Tracked: true}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, resp.Code)
+			},
+		},
+		{
+			name: "conflict 409",
+			body: fmt.Sprintf(`{"address":"%s"}`, poolID),
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+				registry.
+					EXPECT().
+					AddPool(gomock.Any(), poolID).
+					Return(nil, uniswapv3_pool.ErrPoolAlreadyTracked).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusConflict, resp.Code)
+			},
+		},
+		{
+			name: "not a pool 400",
+			body: fmt.Sprintf(`{"address":"%s"}`, poolID),
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+				registry.
+					EXPECT().
+					AddPool(gomock.Any(), poolID).
+					Return(nil, fmt.Errorf("%w: factory() reverted", uniswapv3_pool.ErrNotAV3Pool)).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "status 500",
+			body: fmt.Sprintf(`{"address":"%s"}`, poolID),
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+				registry.
+					EXPECT().
+					AddPool(gomock.Any(), poolID).
+					Return(nil, fmt.Errorf("error while storing pool")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			body: `{"address":"not-an-address"}`,
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRegistry := mockService.NewMockIPoolRegistry(ctrl)
+			tc.buildStubs(mockRegistry)
+
+			controller := NewRegistryController(mockRegistry)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.POST("/pool", controller.AddPool)
+
+			req, _ := http.NewRequest(http.MethodPost, "/pool", strings.NewReader(tc.body))
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
+
+func TestRemovePool(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(registry *mockService.MockIPoolRegistry)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status ok 200",
+			url:  fmt.Sprintf("/pool/%s", poolID),
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+				registry.
+					EXPECT().
+					RemovePool(gomock.Any(), poolID).
+					Return(nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "not found 404",
+			url:  fmt.Sprintf("/pool/%s", poolID),
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+				registry.
+					EXPECT().
+					RemovePool(gomock.Any(), poolID).
+					Return(uniswapv3_pool.ErrPoolNotFound).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			url:  "/pool/123",
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRegistry := mockService.NewMockIPoolRegistry(ctrl)
+			tc.buildStubs(mockRegistry)
+
+			controller := NewRegistryController(mockRegistry)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.DELETE("/pool/:pool_id", controller.RemovePool)
+
+			req, _ := http.NewRequest(http.MethodDelete, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
+
+func TestUpdatePool(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(registry *mockService.MockIPoolRegistry)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status ok 200",
+			body: `{"paused":true}`,
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+				registry.
+					EXPECT().
+					SetPaused(gomock.Any(), poolID, true).
+					Return(&poolDTO.Pool{Address: poolID, Tracked: true, Paused: true}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "not found 404",
+			body: `{"paused":false}`,
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+				registry.
+					EXPECT().
+					SetPaused(gomock.Any(), poolID, false).
+					Return(nil, uniswapv3_pool.ErrPoolNotFound).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			body: `{}`,
+			buildStubs: func(registry *mockService.MockIPoolRegistry) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRegistry := mockService.NewMockIPoolRegistry(ctrl)
+			tc.buildStubs(mockRegistry)
+
+			controller := NewRegistryController(mockRegistry)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.PATCH("/pool/:pool_id", controller.UpdatePool)
+
+			req, _ := http.NewRequest(http.MethodPatch, fmt.Sprintf("/pool/%s", poolID), strings.NewReader(tc.body))
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/controller"
+	"uniswapper/internal/app/service/correlation"
+	"uniswapper/internal/app/service/logger"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// IStateController represents the interface for StateController
+type IStateController interface {
+	GetPoolState(c *gin.Context)
+}
+
+// StateController serves the on-chain state of pools
+type StateController struct {
+	PoolState uniswapv3_pool.IPoolStateService
+}
+
+// NewStateController creates a new instance of StateController
+func NewStateController(poolState uniswapv3_pool.IPoolStateService) IStateController {
+	return &StateController{
+		PoolState: poolState,
+	}
+}
+
+func (u StateController) GetPoolState(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	if !common.IsHexAddress(poolID) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	var blockNumber *uint64
+	if block, ok := c.GetQuery("block"); ok {
+		n, err := strconv.ParseUint(block, 10, 64)
+		if err != nil {
+			controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+			return
+		}
+		blockNumber = &n
+	}
+
+	state, err := u.PoolState.GetPoolState(ctx, poolID, blockNumber)
+	if err != nil {
+		log.Errorf("Error reading state of pool %s: %v", poolID, err)
+		switch {
+		case errors.Is(err, uniswapv3_pool.ErrNotAV3Pool):
+			controller.RespondWithError(c, http.StatusBadRequest, constants.InvalidPool)
+		case errors.Is(err, uniswapv3_pool.ErrBlockNotMined):
+			controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		default:
+			controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		}
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool State", state)
+}
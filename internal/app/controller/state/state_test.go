@@ -0,0 +1,149 @@
+package state
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+	mockService "uniswapper/internal/app/service/util/testutils/mocks/service/pool"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const poolID = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+func TestGetPoolState(t *testing.T) {
+	setupTest(t)
+
+	block := uint64(17000000)
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(poolState *mockService.MockIPoolStateService)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status ok 200",
+			url:  fmt.Sprintf("/pool/%s/state", poolID),
+			buildStubs: func(poolState *mockService.MockIPoolStateService) {
+				poolState.
+					EXPECT().
+					GetPoolState(gomock.Any(), poolID, nil).
+					Return(&uniswapv3_pool.PoolState{Address: poolID}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "status ok 200 at block",
+			url:  fmt.Sprintf("/pool/%s/state?block=%d", poolID, block),
+			buildStubs: func(poolState *mockService.MockIPoolStateService) {
+				poolState.
+					EXPECT().
+					GetPoolState(gomock.Any(), poolID, &block).
+					Return(&uniswapv3_pool.PoolState{Address: poolID, BlockNumber: block}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "future block 400",
+			url:  fmt.Sprintf("/pool/%s/state?block=%d", poolID, block),
+			buildStubs: func(poolState *mockService.MockIPoolStateService) {
+				poolState.
+					EXPECT().
+					GetPoolState(gomock.Any(), poolID, &block).
+					Return(nil, uniswapv3_pool.ErrBlockNotMined).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "not a pool 400",
+			url:  fmt.Sprintf("/pool/%s/state", poolID),
+			buildStubs: func(poolState *mockService.MockIPoolStateService) {
+				poolState.
+					EXPECT().
+					GetPoolState(gomock.Any(), poolID, nil).
+					Return(nil, fmt.Errorf("%w: slot0() reverted", uniswapv3_pool.ErrNotAV3Pool)).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "status 500",
+			url:  fmt.Sprintf("/pool/%s/state", poolID),
+			buildStubs: func(poolState *mockService.MockIPoolStateService) {
+				poolState.
+					EXPECT().
+					GetPoolState(gomock.Any(), poolID, nil).
+					Return(nil, fmt.Errorf("error while calling the node")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "bad block 400",
+			url:  fmt.Sprintf("/pool/%s/state?block=latest", poolID),
+			buildStubs: func(poolState *mockService.MockIPoolStateService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			url:  "/pool/123/state",
+			buildStubs: func(poolState *mockService.MockIPoolStateService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPoolState := mockService.NewMockIPoolStateService(ctrl)
+			tc.buildStubs(mockPoolState)
+
+			controller := NewStateController(mockPoolState)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/pool/:pool_id/state", controller.GetPoolState)
+
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
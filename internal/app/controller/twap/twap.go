@@ -0,0 +1,76 @@
+package twap
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/controller"
+	"uniswapper/internal/app/service/correlation"
+	"uniswapper/internal/app/service/logger"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultWindow is the TWAP window in seconds when none is given
+const defaultWindow = 1800
+
+// ITWAPController represents the interface for TWAPController
+type ITWAPController interface {
+	GetTWAP(c *gin.Context)
+}
+
+// TWAPController serves the time-weighted average prices of pools
+type TWAPController struct {
+	TWAP uniswapv3_pool.ITWAPService
+}
+
+// NewTWAPController creates a new instance of TWAPController
+func NewTWAPController(twap uniswapv3_pool.ITWAPService) ITWAPController {
+	return &TWAPController{
+		TWAP: twap,
+	}
+}
+
+// GetTWAP returns the average tick, liquidity and price of a pool over the
+// last window seconds
+func (u TWAPController) GetTWAP(c *gin.Context) {
+	ctx := correlation.WithReqContext(c)
+	log := logger.Logger(ctx)
+
+	poolID := strings.TrimSpace(c.Param("pool_id"))
+	if !common.IsHexAddress(poolID) {
+		controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+		return
+	}
+
+	window := uint64(defaultWindow)
+	if raw, ok := c.GetQuery("window"); ok {
+		var err error
+		window, err = strconv.ParseUint(raw, 10, 32)
+		if err != nil || window == 0 {
+			controller.RespondWithError(c, http.StatusBadRequest, constants.BadRequest)
+			return
+		}
+	}
+
+	twap, err := u.TWAP.GetTWAP(ctx, poolID, uint32(window))
+	if err != nil {
+		log.Errorf("Error getting the TWAP of pool %s: %v", poolID, err)
+		switch {
+		case errors.Is(err, uniswapv3_pool.ErrNotAV3Pool):
+			controller.RespondWithError(c, http.StatusBadRequest, constants.InvalidPool)
+		case errors.Is(err, uniswapv3_pool.ErrNoTickHistory):
+			controller.RespondWithError(c, http.StatusNotFound, constants.NotFound)
+		default:
+			controller.RespondWithError(c, http.StatusInternalServerError, constants.InternalServerError)
+		}
+		return
+	}
+
+	controller.RespondWithSuccess(c, http.StatusOK, "Pool TWAP", twap)
+}
@@ -0,0 +1,156 @@
+package twap
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	uniswapv3_pool "uniswapper/internal/app/service/pool"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+	mockService "uniswapper/internal/app/service/util/testutils/mocks/service/pool"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const poolID = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+func TestGetTWAP(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(service *mockService.MockITWAPService)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "status ok 200",
+			url:  fmt.Sprintf("/pool/%s/twap?window=600", poolID),
+			buildStubs: func(service *mockService.MockITWAPService) {
+				service.
+					EXPECT().
+					GetTWAP(gomock.Any(), poolID, uint32(600)).
+					Return(&uniswapv3_pool.TWAP{Address: poolID, Source: uniswapv3_pool.TWAP_SOURCE_ORACLE, Window: 600}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "status ok 200 default window",
+			url:  fmt.Sprintf("/pool/%s/twap", poolID),
+			buildStubs: func(service *mockService.MockITWAPService) {
+				service.
+					EXPECT().
+					GetTWAP(gomock.Any(), poolID, uint32(defaultWindow)).
+					Return(&uniswapv3_pool.TWAP{Address: poolID, Source: uniswapv3_pool.TWAP_SOURCE_EVENTS, Window: defaultWindow}, nil).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, resp.Code)
+			},
+		},
+		{
+			name: "no tick history 404",
+			url:  fmt.Sprintf("/pool/%s/twap?window=86400", poolID),
+			buildStubs: func(service *mockService.MockITWAPService) {
+				service.
+					EXPECT().
+					GetTWAP(gomock.Any(), poolID, uint32(86400)).
+					Return(nil, uniswapv3_pool.ErrNoTickHistory).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+			},
+		},
+		{
+			name: "invalid pool 400",
+			url:  fmt.Sprintf("/pool/%s/twap", poolID),
+			buildStubs: func(service *mockService.MockITWAPService) {
+				service.
+					EXPECT().
+					GetTWAP(gomock.Any(), poolID, gomock.Any()).
+					Return(nil, uniswapv3_pool.ErrNotAV3Pool).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "status 500",
+			url:  fmt.Sprintf("/pool/%s/twap", poolID),
+			buildStubs: func(service *mockService.MockITWAPService) {
+				service.
+					EXPECT().
+					GetTWAP(gomock.Any(), poolID, gomock.Any()).
+					Return(nil, fmt.Errorf("error while calling the node")).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name: "zero window 400",
+			url:  fmt.Sprintf("/pool/%s/twap?window=0", poolID),
+			buildStubs: func(service *mockService.MockITWAPService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad window 400",
+			url:  fmt.Sprintf("/pool/%s/twap?window=30m", poolID),
+			buildStubs: func(service *mockService.MockITWAPService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+		{
+			name: "bad request 400",
+			url:  "/pool/123/twap",
+			buildStubs: func(service *mockService.MockITWAPService) {
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTWAP := mockService.NewMockITWAPService(ctrl)
+			tc.buildStubs(mockTWAP)
+
+			controller := NewTWAPController(mockTWAP)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/pool/:pool_id/twap", controller.GetTWAP)
+
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			tc.checkResponse(t, resp)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package numeric
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// BigInt carries an exact on-chain integer (up to 256 bits) through the
+// NUMERIC(78,0) columns and the JSON API. It is serialized as a decimal
+// string so that no client parses it into a lossy float64.
+// A nil Int maps to SQL NULL and JSON null.
+type BigInt struct {
+	*big.Int
+}
+
+// NewBigInt returns a BigInt holding a copy of x
+func NewBigInt(x *big.Int) BigInt {
+	if x == nil {
+		return BigInt{}
+	}
+	return BigInt{Int: new(big.Int).Set(x)}
+}
+
+// ParseBigInt parses a base 10 integer string
+func ParseBigInt(s string) (BigInt, error) {
+	x, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return BigInt{}, fmt.Errorf("numeric: invalid integer %q", s)
+	}
+	return BigInt{Int: x}, nil
+}
+
+// Big returns a copy of the value, treating NULL as zero
+func (b BigInt) Big() *big.Int {
+	if b.Int == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(b.Int)
+}
+
+// Value implements driver.Valuer
+func (b BigInt) Value() (driver.Value, error) {
+	if b.Int == nil {
+		return nil, nil
+	}
+	return b.Int.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (b *BigInt) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		b.Int = nil
+		return nil
+	case int64:
+		b.Int = big.NewInt(v)
+		return nil
+	case []byte:
+		return b.setString(string(v))
+	case string:
+		return b.setString(v)
+	default:
+		return fmt.Errorf("numeric: cannot scan %T into BigInt", src)
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	if b.Int == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(b.Int.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting quoted and bare integers
+func (b *BigInt) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		b.Int = nil
+		return nil
+	}
+	return b.setString(string(bytes.Trim(data, `"`)))
+}
+
+func (b *BigInt) setString(s string) error {
+	v, err := ParseBigInt(s)
+	if err != nil {
+		return err
+	}
+	b.Int = v.Int
+	return nil
+}
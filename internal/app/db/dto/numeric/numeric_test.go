@@ -0,0 +1,65 @@
+package numeric
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigIntRoundTrip(t *testing.T) {
+	maxUint256, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	minInt256 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+
+	testCases := []struct {
+		name  string
+		value *big.Int
+	}{
+		{name: "max uint256", value: maxUint256},
+		{name: "min int256", value: minInt256},
+		{name: "above int64", value: new(big.Int).Mul(big.NewInt(25), big.NewInt(1e18))},
+		{name: "zero", value: big.NewInt(0)},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := NewBigInt(tc.value).Value()
+			assert.NoError(t, err)
+
+			var scanned BigInt
+			assert.NoError(t, scanned.Scan([]byte(value.(string))))
+			assert.Equal(t, 0, tc.value.Cmp(scanned.Int))
+
+			data, err := json.Marshal(scanned)
+			assert.NoError(t, err)
+			assert.Equal(t, `"`+tc.value.String()+`"`, string(data))
+
+			var decoded BigInt
+			assert.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, 0, tc.value.Cmp(decoded.Int))
+		})
+	}
+}
+
+func TestBigIntNull(t *testing.T) {
+	var b BigInt
+	assert.NoError(t, b.Scan(nil))
+
+	value, err := b.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	data, err := json.Marshal(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+	assert.Equal(t, 0, b.Big().Sign())
+}
+
+func TestBigIntScanInvalid(t *testing.T) {
+	var b BigInt
+	assert.Error(t, b.Scan("1.5"))
+	assert.Error(t, b.Scan(1.5))
+}
@@ -0,0 +1,43 @@
+package posts
+
+import (
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+)
+
+const (
+	CANDLES_TABLE_NAME    = "pool_candles"
+	COLUMN_RESOLUTION     = "resolution"
+	COLUMN_START_TIME     = "start_time"
+	COLUMN_LAST_BLOCK     = "last_block"
+	COLUMN_LAST_LOG_INDEX = "last_log_index"
+)
+
+// CANDLE_RESOLUTIONS are the supported candle intervals and their length
+var CANDLE_RESOLUTIONS = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": 5 * time.Minute,
+	"1h": time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// Candle aggregates the swaps of a pool over one interval. Prices are in
+// token1 per token0 and volumes are in the base units of each token.
+// LastBlock and LastLogIndex identify the last swap included.
+type Candle struct {
+	Id           int            `json:"-"`
+	PoolAddress  string         `json:"pool_address"`
+	Resolution   string         `json:"interval"`
+	StartTime    time.Time      `json:"start_time"`
+	Open         string         `json:"open"`
+	High         string         `json:"high"`
+	Low          string         `json:"low"`
+	Close        string         `json:"close"`
+	Volume0      numeric.BigInt `json:"volume0"`
+	Volume1      numeric.BigInt `json:"volume1"`
+	SwapCount    uint64         `json:"swap_count"`
+	LastBlock    uint64         `json:"-"`
+	LastLogIndex uint           `json:"-"`
+	CreatedAt    time.Time      `json:"-"`
+	UpdatedAt    time.Time      `json:"-"`
+}
@@ -0,0 +1,17 @@
+package posts
+
+import (
+	"time"
+)
+
+const (
+	CHECKPOINT_TABLE_NAME = "ingestion_checkpoints"
+	COLUMN_UPDATED_AT     = "updated_at"
+)
+
+// Checkpoint is the last block whose logs have been fully processed for a pool
+type Checkpoint struct {
+	PoolAddress string    `json:"pool_address"`
+	BlockNumber uint64    `json:"block_number"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,126 @@
+package posts
+
+import (
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+)
+
+const (
+	SWAP_TABLE_NAME    = "pool_swaps"
+	MINT_TABLE_NAME    = "pool_mints"
+	BURN_TABLE_NAME    = "pool_burns"
+	COLLECT_TABLE_NAME = "pool_collects"
+	FLASH_TABLE_NAME   = "pool_flashes"
+
+	COLUMN_BLOCK_TIMESTAMP = "block_timestamp"
+	COLUMN_SENDER          = "sender"
+	COLUMN_RECIPIENT       = "recipient"
+	COLUMN_OWNER           = "owner"
+	COLUMN_TICK_LOWER      = "tick_lower"
+	COLUMN_TICK_UPPER      = "tick_upper"
+	COLUMN_AMOUNT          = "amount"
+	COLUMN_AMOUNT0         = "amount0"
+	COLUMN_AMOUNT1         = "amount1"
+	COLUMN_SQRT_PRICE_X96  = "sqrt_price_x96"
+	COLUMN_LIQUIDITY       = "liquidity"
+	COLUMN_PAID0           = "paid0"
+	COLUMN_PAID1           = "paid1"
+
+	// Decimal-adjusted prices after the event, NULL when the token
+	// decimals could not be resolved
+	COLUMN_TOKEN1_PER_TOKEN0 = "token1_per_token0"
+	COLUMN_TOKEN0_PER_TOKEN1 = "token0_per_token1"
+)
+
+type Swap struct {
+	Id              int            `json:"id"`
+	PoolAddress     string         `json:"pool_address"`
+	TxnId           string         `json:"txn_id"`
+	BlockNumber     uint64         `json:"block_number"`
+	BlockHash       string         `json:"block_hash"`
+	LogIndex        uint           `json:"log_index"`
+	BlockTimestamp  time.Time      `json:"block_timestamp"`
+	Sender          string         `json:"sender"`
+	Recipient       string         `json:"recipient"`
+	Amount0         numeric.BigInt `json:"amount0"`
+	Amount1         numeric.BigInt `json:"amount1"`
+	SqrtPriceX96    numeric.BigInt `json:"sqrt_price_x96"`
+	Liquidity       numeric.BigInt `json:"liquidity"`
+	Tick            int64          `json:"tick"`
+	Token1PerToken0 *string        `json:"token1_per_token0"`
+	Token0PerToken1 *string        `json:"token0_per_token1"`
+	// The fee charged on the input token and the protocol's share of it,
+	// NULL when the fee tier of the pool could not be resolved
+	FeeAmount         numeric.BigInt `json:"fee_amount"`
+	ProtocolFeeAmount numeric.BigInt `json:"protocol_fee_amount"`
+	CreatedAt         time.Time      `json:"created_at"`
+}
+
+type Mint struct {
+	Id             int            `json:"id"`
+	PoolAddress    string         `json:"pool_address"`
+	TxnId          string         `json:"txn_id"`
+	BlockNumber    uint64         `json:"block_number"`
+	BlockHash      string         `json:"block_hash"`
+	LogIndex       uint           `json:"log_index"`
+	BlockTimestamp time.Time      `json:"block_timestamp"`
+	Sender         string         `json:"sender"`
+	Owner          string         `json:"owner"`
+	TickLower      int64          `json:"tick_lower"`
+	TickUpper      int64          `json:"tick_upper"`
+	Amount         numeric.BigInt `json:"amount"`
+	Amount0        numeric.BigInt `json:"amount0"`
+	Amount1        numeric.BigInt `json:"amount1"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type Burn struct {
+	Id             int            `json:"id"`
+	PoolAddress    string         `json:"pool_address"`
+	TxnId          string         `json:"txn_id"`
+	BlockNumber    uint64         `json:"block_number"`
+	BlockHash      string         `json:"block_hash"`
+	LogIndex       uint           `json:"log_index"`
+	BlockTimestamp time.Time      `json:"block_timestamp"`
+	Owner          string         `json:"owner"`
+	TickLower      int64          `json:"tick_lower"`
+	TickUpper      int64          `json:"tick_upper"`
+	Amount         numeric.BigInt `json:"amount"`
+	Amount0        numeric.BigInt `json:"amount0"`
+	Amount1        numeric.BigInt `json:"amount1"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type Collect struct {
+	Id             int            `json:"id"`
+	PoolAddress    string         `json:"pool_address"`
+	TxnId          string         `json:"txn_id"`
+	BlockNumber    uint64         `json:"block_number"`
+	BlockHash      string         `json:"block_hash"`
+	LogIndex       uint           `json:"log_index"`
+	BlockTimestamp time.Time      `json:"block_timestamp"`
+	Owner          string         `json:"owner"`
+	Recipient      string         `json:"recipient"`
+	TickLower      int64          `json:"tick_lower"`
+	TickUpper      int64          `json:"tick_upper"`
+	Amount0        numeric.BigInt `json:"amount0"`
+	Amount1        numeric.BigInt `json:"amount1"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type Flash struct {
+	Id             int            `json:"id"`
+	PoolAddress    string         `json:"pool_address"`
+	TxnId          string         `json:"txn_id"`
+	BlockNumber    uint64         `json:"block_number"`
+	BlockHash      string         `json:"block_hash"`
+	LogIndex       uint           `json:"log_index"`
+	BlockTimestamp time.Time      `json:"block_timestamp"`
+	Sender         string         `json:"sender"`
+	Recipient      string         `json:"recipient"`
+	Amount0        numeric.BigInt `json:"amount0"`
+	Amount1        numeric.BigInt `json:"amount1"`
+	Paid0          numeric.BigInt `json:"paid0"`
+	Paid1          numeric.BigInt `json:"paid1"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
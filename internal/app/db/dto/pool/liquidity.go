@@ -0,0 +1,33 @@
+package posts
+
+import (
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+)
+
+const (
+	LIQUIDITY_SEEDS_TABLE_NAME = "pool_liquidity_seeds"
+	SEED_TICKS_TABLE_NAME      = "pool_seed_ticks"
+	COLUMN_LIQUIDITY_GROSS     = "liquidity_gross"
+	COLUMN_LIQUIDITY_NET       = "liquidity_net"
+)
+
+// LiquiditySeed is the block at which the tick liquidity of a pool was read
+// on-chain. The distribution at later blocks is the seed plus the liquidity
+// added and removed by the stored Mint and Burn events.
+type LiquiditySeed struct {
+	Id          int       `json:"id"`
+	PoolAddress string    `json:"pool_address"`
+	BlockNumber uint64    `json:"block_number"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Tick is the liquidity referencing an initialized tick, or a change of it
+type Tick struct {
+	Id             int            `json:"-"`
+	PoolAddress    string         `json:"-"`
+	Tick           int64          `json:"tick"`
+	LiquidityGross numeric.BigInt `json:"liquidity_gross"`
+	LiquidityNet   numeric.BigInt `json:"liquidity_net"`
+	CreatedAt      time.Time      `json:"-"`
+}
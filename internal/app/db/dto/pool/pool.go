@@ -2,6 +2,7 @@ package posts
 
 import (
 	"time"
+	"uniswapper/internal/app/db/dto/numeric"
 )
 
 const (
@@ -10,6 +11,8 @@ const (
 	COLUMN_POOL_ADDRESS   = "pool_address"
 	COLUMN_TXN_ID         = "txn_id"
 	COLUMN_BLOCK_NUMBER   = "block_number"
+	COLUMN_BLOCK_HASH     = "block_hash"
+	COLUMN_LOG_INDEX      = "log_index"
 	COLUMN_TOKEN0_BALANCE = "token0_balance"
 	COLUMN_TOKEN1_BALANCE = "token1_balance"
 	COLUMN_TOKEN0_DELTA   = "token0_delta"
@@ -19,14 +22,18 @@ const (
 )
 
 type Logs struct {
-	Id            int       `json:"id"`
-	PoolAddress   string    `json:"pool_address"`
-	TxnId         string    `json:"txn_id"`
-	BlockNumber   uint64    `json:"block_number"`
-	Token0Balance int64     `json:"token0_balance"`
-	Token1Balance int64     `json:"token1_balance"`
-	Token0Delta   int64     `json:"token0_delta"`
-	Token1Delta   int64     `json:"token1_delta"`
-	Tick          int64     `json:"tick"`
-	CreatedAt     time.Time `json:"created_at"`
+	Id              int            `json:"id"`
+	PoolAddress     string         `json:"pool_address"`
+	TxnId           string         `json:"txn_id"`
+	BlockNumber     uint64         `json:"block_number"`
+	BlockHash       string         `json:"block_hash"`
+	LogIndex        uint           `json:"log_index"`
+	Token0Balance   numeric.BigInt `json:"token0_balance"`
+	Token1Balance   numeric.BigInt `json:"token1_balance"`
+	Token0Delta     numeric.BigInt `json:"token0_delta"`
+	Token1Delta     numeric.BigInt `json:"token1_delta"`
+	Tick            int64          `json:"tick"`
+	Token1PerToken0 *string        `json:"token1_per_token0"`
+	Token0PerToken1 *string        `json:"token0_per_token1"`
+	CreatedAt       time.Time      `json:"created_at"`
 }
@@ -0,0 +1,61 @@
+package posts
+
+import (
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+)
+
+const (
+	POSITIONS_TABLE_NAME       = "positions"
+	POSITION_EVENTS_TABLE_NAME = "position_events"
+	COLUMN_TOKEN_ID            = "token_id"
+	COLUMN_EVENT               = "event"
+
+	// NonfungiblePositionManager events stored for a position
+	POSITION_EVENT_INCREASE_LIQUIDITY = "IncreaseLiquidity"
+	POSITION_EVENT_DECREASE_LIQUIDITY = "DecreaseLiquidity"
+	POSITION_EVENT_COLLECT            = "Collect"
+	POSITION_EVENT_TRANSFER           = "Transfer"
+)
+
+// Position is the state of an LP position NFT as of BlockNumber. The owner
+// of a burned position is the zero address.
+type Position struct {
+	Id                       int            `json:"-"`
+	TokenId                  numeric.BigInt `json:"token_id"`
+	Owner                    string         `json:"owner"`
+	PoolAddress              string         `json:"pool_address"`
+	Token0                   string         `json:"token0"`
+	Token1                   string         `json:"token1"`
+	Fee                      uint32         `json:"fee"`
+	TickLower                int32          `json:"tick_lower"`
+	TickUpper                int32          `json:"tick_upper"`
+	Liquidity                numeric.BigInt `json:"liquidity"`
+	FeeGrowthInside0LastX128 numeric.BigInt `json:"fee_growth_inside0_last_x128"`
+	FeeGrowthInside1LastX128 numeric.BigInt `json:"fee_growth_inside1_last_x128"`
+	TokensOwed0              numeric.BigInt `json:"tokens_owed0"`
+	TokensOwed1              numeric.BigInt `json:"tokens_owed1"`
+	BlockNumber              uint64         `json:"block_number"`
+	CreatedAt                time.Time      `json:"-"`
+	UpdatedAt                time.Time      `json:"updated_at"`
+}
+
+// PositionEvent is a NonfungiblePositionManager event of a position. Sender
+// and Recipient are the from and to of a Transfer and the recipient of a
+// Collect; Liquidity is only set for liquidity changes.
+type PositionEvent struct {
+	Id             int            `json:"-"`
+	TokenId        numeric.BigInt `json:"token_id"`
+	Event          string         `json:"event"`
+	TxnId          string         `json:"txn_id"`
+	BlockNumber    uint64         `json:"block_number"`
+	BlockHash      string         `json:"block_hash"`
+	LogIndex       uint           `json:"log_index"`
+	BlockTimestamp time.Time      `json:"block_timestamp"`
+	Sender         *string        `json:"sender"`
+	Recipient      *string        `json:"recipient"`
+	Liquidity      numeric.BigInt `json:"liquidity"`
+	Amount0        numeric.BigInt `json:"amount0"`
+	Amount1        numeric.BigInt `json:"amount1"`
+	CreatedAt      time.Time      `json:"-"`
+}
@@ -0,0 +1,42 @@
+package posts
+
+import (
+	"time"
+)
+
+const (
+	POOLS_TABLE_NAME    = "pools"
+	COLUMN_ADDRESS      = "address"
+	COLUMN_TOKEN0       = "token0"
+	COLUMN_TOKEN1       = "token1"
+	COLUMN_FEE          = "fee"
+	COLUMN_TICK_SPACING = "tick_spacing"
+	COLUMN_TRACKED      = "tracked"
+	COLUMN_PAUSED       = "paused"
+	COLUMN_SOURCE       = "source"
+
+	// How a pool entered the registry
+	POOL_SOURCE_FACTORY = "factory"
+	POOL_SOURCE_CONFIG  = "config"
+	POOL_SOURCE_API     = "api"
+	POOL_SOURCE_LOOKUP  = "lookup"
+)
+
+// Pool is a Uniswap V3 pool known to the registry. Tracked pools are ingested
+// unless paused; pools added manually carry no creation log.
+type Pool struct {
+	Id          int       `json:"id"`
+	Address     string    `json:"address"`
+	Token0      string    `json:"token0"`
+	Token1      string    `json:"token1"`
+	Fee         uint32    `json:"fee"`
+	TickSpacing int32     `json:"tick_spacing"`
+	TxnId       string    `json:"txn_id"`
+	BlockNumber uint64    `json:"block_number"`
+	BlockHash   string    `json:"block_hash"`
+	LogIndex    uint      `json:"log_index"`
+	Tracked     bool      `json:"tracked"`
+	Paused      bool      `json:"paused"`
+	Source      string    `json:"source"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package posts
+
+import (
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+)
+
+const (
+	ROLLUPS_TABLE_NAME = "pool_rollups"
+
+	// ROLLUP_PERIOD is the length of the interval each rollup aggregates
+	ROLLUP_PERIOD = time.Hour
+)
+
+// Rollup aggregates the swaps of a pool over one ROLLUP_PERIOD. Volumes and
+// fees are in the base units of each token; fees are those earned by the
+// liquidity providers, net of the protocol fees.
+type Rollup struct {
+	Id            int            `json:"-"`
+	PoolAddress   string         `json:"pool_address"`
+	StartTime     time.Time      `json:"start_time"`
+	Volume0       numeric.BigInt `json:"volume0"`
+	Volume1       numeric.BigInt `json:"volume1"`
+	Fees0         numeric.BigInt `json:"fees0"`
+	Fees1         numeric.BigInt `json:"fees1"`
+	ProtocolFees0 numeric.BigInt `json:"protocol_fees0"`
+	ProtocolFees1 numeric.BigInt `json:"protocol_fees1"`
+	SwapCount     uint64         `json:"swap_count"`
+	LastBlock     uint64         `json:"-"`
+	LastLogIndex  uint           `json:"-"`
+	CreatedAt     time.Time      `json:"-"`
+	UpdatedAt     time.Time      `json:"-"`
+}
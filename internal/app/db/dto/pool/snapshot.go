@@ -0,0 +1,36 @@
+package posts
+
+import (
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+)
+
+const (
+	SNAPSHOTS_TABLE_NAME = "pool_snapshots"
+)
+
+// Snapshot is the full on-chain state of a pool at a confirmed block,
+// including the token balances actually held by the pool contract
+type Snapshot struct {
+	Id                         int            `json:"id"`
+	PoolAddress                string         `json:"pool_address"`
+	BlockNumber                uint64         `json:"block_number"`
+	BlockTimestamp             time.Time      `json:"block_timestamp"`
+	SqrtPriceX96               numeric.BigInt `json:"sqrt_price_x96"`
+	Tick                       int64          `json:"tick"`
+	ObservationIndex           uint16         `json:"observation_index"`
+	ObservationCardinality     uint16         `json:"observation_cardinality"`
+	ObservationCardinalityNext uint16         `json:"observation_cardinality_next"`
+	FeeProtocol                uint8          `json:"fee_protocol"`
+	Unlocked                   bool           `json:"unlocked"`
+	Liquidity                  numeric.BigInt `json:"liquidity"`
+	FeeGrowthGlobal0X128       numeric.BigInt `json:"fee_growth_global0_x128"`
+	FeeGrowthGlobal1X128       numeric.BigInt `json:"fee_growth_global1_x128"`
+	ProtocolFeesToken0         numeric.BigInt `json:"protocol_fees_token0"`
+	ProtocolFeesToken1         numeric.BigInt `json:"protocol_fees_token1"`
+	Token0Balance              numeric.BigInt `json:"token0_balance"`
+	Token1Balance              numeric.BigInt `json:"token1_balance"`
+	Token1PerToken0            *string        `json:"token1_per_token0"`
+	Token0PerToken1            *string        `json:"token0_per_token1"`
+	CreatedAt                  time.Time      `json:"created_at"`
+}
@@ -0,0 +1,22 @@
+package posts
+
+import (
+	"time"
+)
+
+const (
+	TOKENS_TABLE_NAME = "tokens"
+	COLUMN_SYMBOL     = "symbol"
+	COLUMN_NAME       = "name"
+	COLUMN_DECIMALS   = "decimals"
+)
+
+// Token is the ERC-20 metadata of a pool token
+type Token struct {
+	Id        int       `json:"-"`
+	Address   string    `json:"address"`
+	Symbol    string    `json:"symbol"`
+	Name      string    `json:"name"`
+	Decimals  uint8     `json:"decimals"`
+	CreatedAt time.Time `json:"-"`
+}
@@ -0,0 +1,108 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.pool_swaps
+(
+    id bigserial NOT NULL,
+    pool_address text,
+    txn_id text,
+    block_number bigint,
+    log_index bigint,
+    block_timestamp timestamp without time zone,
+    sender text,
+    recipient text,
+    amount0 numeric(78,0),
+    amount1 numeric(78,0),
+    sqrt_price_x96 numeric(78,0),
+    liquidity numeric(78,0),
+    tick bigint,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id)
+);
+CREATE INDEX pool_swaps_pool_address_block_number_idx ON public.pool_swaps (pool_address, block_number);
+
+CREATE TABLE public.pool_mints
+(
+    id bigserial NOT NULL,
+    pool_address text,
+    txn_id text,
+    block_number bigint,
+    log_index bigint,
+    block_timestamp timestamp without time zone,
+    sender text,
+    owner text,
+    tick_lower bigint,
+    tick_upper bigint,
+    amount numeric(78,0),
+    amount0 numeric(78,0),
+    amount1 numeric(78,0),
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id)
+);
+CREATE INDEX pool_mints_pool_address_block_number_idx ON public.pool_mints (pool_address, block_number);
+
+CREATE TABLE public.pool_burns
+(
+    id bigserial NOT NULL,
+    pool_address text,
+    txn_id text,
+    block_number bigint,
+    log_index bigint,
+    block_timestamp timestamp without time zone,
+    owner text,
+    tick_lower bigint,
+    tick_upper bigint,
+    amount numeric(78,0),
+    amount0 numeric(78,0),
+    amount1 numeric(78,0),
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id)
+);
+CREATE INDEX pool_burns_pool_address_block_number_idx ON public.pool_burns (pool_address, block_number);
+
+CREATE TABLE public.pool_collects
+(
+    id bigserial NOT NULL,
+    pool_address text,
+    txn_id text,
+    block_number bigint,
+    log_index bigint,
+    block_timestamp timestamp without time zone,
+    owner text,
+    recipient text,
+    tick_lower bigint,
+    tick_upper bigint,
+    amount0 numeric(78,0),
+    amount1 numeric(78,0),
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id)
+);
+CREATE INDEX pool_collects_pool_address_block_number_idx ON public.pool_collects (pool_address, block_number);
+
+CREATE TABLE public.pool_flashes
+(
+    id bigserial NOT NULL,
+    pool_address text,
+    txn_id text,
+    block_number bigint,
+    log_index bigint,
+    block_timestamp timestamp without time zone,
+    sender text,
+    recipient text,
+    amount0 numeric(78,0),
+    amount1 numeric(78,0),
+    paid0 numeric(78,0),
+    paid1 numeric(78,0),
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id)
+);
+CREATE INDEX pool_flashes_pool_address_block_number_idx ON public.pool_flashes (pool_address, block_number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.pool_flashes;
+DROP TABLE IF EXISTS public.pool_collects;
+DROP TABLE IF EXISTS public.pool_burns;
+DROP TABLE IF EXISTS public.pool_mints;
+DROP TABLE IF EXISTS public.pool_swaps;
+-- +goose StatementEnd
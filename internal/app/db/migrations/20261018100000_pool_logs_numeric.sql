@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.pool_logs
+    ALTER COLUMN token0_balance TYPE numeric(78,0),
+    ALTER COLUMN token1_balance TYPE numeric(78,0),
+    ALTER COLUMN token0_delta TYPE numeric(78,0),
+    ALTER COLUMN token1_delta TYPE numeric(78,0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.pool_logs
+    ALTER COLUMN token0_balance TYPE bigint,
+    ALTER COLUMN token1_balance TYPE bigint,
+    ALTER COLUMN token0_delta TYPE bigint,
+    ALTER COLUMN token1_delta TYPE bigint;
+-- +goose StatementEnd
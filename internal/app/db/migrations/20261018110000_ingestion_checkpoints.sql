@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.ingestion_checkpoints
+(
+    pool_address text NOT NULL,
+    block_number bigint NOT NULL,
+    updated_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (pool_address)
+);
+
+ALTER TABLE public.pool_logs ADD COLUMN log_index bigint;
+
+CREATE UNIQUE INDEX pool_logs_txn_id_log_index_key ON public.pool_logs (txn_id, log_index);
+CREATE UNIQUE INDEX pool_swaps_txn_id_log_index_key ON public.pool_swaps (txn_id, log_index);
+CREATE UNIQUE INDEX pool_mints_txn_id_log_index_key ON public.pool_mints (txn_id, log_index);
+CREATE UNIQUE INDEX pool_burns_txn_id_log_index_key ON public.pool_burns (txn_id, log_index);
+CREATE UNIQUE INDEX pool_collects_txn_id_log_index_key ON public.pool_collects (txn_id, log_index);
+CREATE UNIQUE INDEX pool_flashes_txn_id_log_index_key ON public.pool_flashes (txn_id, log_index);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS public.pool_flashes_txn_id_log_index_key;
+DROP INDEX IF EXISTS public.pool_collects_txn_id_log_index_key;
+DROP INDEX IF EXISTS public.pool_burns_txn_id_log_index_key;
+DROP INDEX IF EXISTS public.pool_mints_txn_id_log_index_key;
+DROP INDEX IF EXISTS public.pool_swaps_txn_id_log_index_key;
+DROP INDEX IF EXISTS public.pool_logs_txn_id_log_index_key;
+ALTER TABLE public.pool_logs DROP COLUMN IF EXISTS log_index;
+DROP TABLE IF EXISTS public.ingestion_checkpoints;
+-- +goose StatementEnd
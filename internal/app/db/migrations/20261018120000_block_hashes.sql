@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.pool_logs ADD COLUMN block_hash text;
+ALTER TABLE public.pool_swaps ADD COLUMN block_hash text;
+ALTER TABLE public.pool_mints ADD COLUMN block_hash text;
+ALTER TABLE public.pool_burns ADD COLUMN block_hash text;
+ALTER TABLE public.pool_collects ADD COLUMN block_hash text;
+ALTER TABLE public.pool_flashes ADD COLUMN block_hash text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.pool_flashes DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE public.pool_collects DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE public.pool_burns DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE public.pool_mints DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE public.pool_swaps DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE public.pool_logs DROP COLUMN IF EXISTS block_hash;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.pools
+(
+    id bigserial NOT NULL,
+    address text NOT NULL,
+    token0 text NOT NULL,
+    token1 text NOT NULL,
+    fee integer NOT NULL,
+    tick_spacing integer NOT NULL,
+    txn_id text NOT NULL,
+    block_number bigint NOT NULL,
+    block_hash text NOT NULL,
+    log_index bigint NOT NULL,
+    tracked boolean NOT NULL DEFAULT false,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (address)
+);
+
+CREATE INDEX pools_tokens_idx ON public.pools (token0, token1);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.pools;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.pools ADD COLUMN paused boolean NOT NULL DEFAULT false;
+ALTER TABLE public.pools ADD COLUMN source text NOT NULL DEFAULT 'factory';
+
+CREATE INDEX pools_tracked_idx ON public.pools (tracked);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS public.pools_tracked_idx;
+ALTER TABLE public.pools DROP COLUMN IF EXISTS source;
+ALTER TABLE public.pools DROP COLUMN IF EXISTS paused;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.tokens
+(
+    id bigserial NOT NULL,
+    address text NOT NULL,
+    symbol text NOT NULL,
+    name text NOT NULL,
+    decimals smallint NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (address)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.tokens;
+-- +goose StatementEnd
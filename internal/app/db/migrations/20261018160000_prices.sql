@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.pool_swaps
+    ADD COLUMN token1_per_token0 numeric,
+    ADD COLUMN token0_per_token1 numeric;
+
+ALTER TABLE public.pool_logs
+    ADD COLUMN token1_per_token0 numeric,
+    ADD COLUMN token0_per_token1 numeric;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.pool_logs
+    DROP COLUMN token1_per_token0,
+    DROP COLUMN token0_per_token1;
+
+ALTER TABLE public.pool_swaps
+    DROP COLUMN token1_per_token0,
+    DROP COLUMN token0_per_token1;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.pool_snapshots
+(
+    id bigserial NOT NULL,
+    pool_address text NOT NULL,
+    block_number bigint NOT NULL,
+    block_timestamp timestamp without time zone,
+    sqrt_price_x96 numeric(78,0),
+    tick bigint,
+    observation_index integer,
+    observation_cardinality integer,
+    observation_cardinality_next integer,
+    fee_protocol smallint,
+    unlocked boolean,
+    liquidity numeric(78,0),
+    fee_growth_global0_x128 numeric(78,0),
+    fee_growth_global1_x128 numeric(78,0),
+    protocol_fees_token0 numeric(78,0),
+    protocol_fees_token1 numeric(78,0),
+    token0_balance numeric(78,0),
+    token1_balance numeric(78,0),
+    token1_per_token0 numeric,
+    token0_per_token1 numeric,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (pool_address, block_number)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.pool_snapshots;
+-- +goose StatementEnd
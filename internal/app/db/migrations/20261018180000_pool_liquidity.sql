@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.pool_liquidity_seeds
+(
+    id bigserial NOT NULL,
+    pool_address text NOT NULL,
+    block_number bigint NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (pool_address)
+);
+
+CREATE TABLE public.pool_seed_ticks
+(
+    id bigserial NOT NULL,
+    pool_address text NOT NULL,
+    tick bigint NOT NULL,
+    liquidity_gross numeric(78,0) NOT NULL,
+    liquidity_net numeric(78,0) NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (pool_address, tick)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.pool_seed_ticks;
+DROP TABLE IF EXISTS public.pool_liquidity_seeds;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.pool_candles
+(
+    id bigserial NOT NULL,
+    pool_address text NOT NULL,
+    resolution text NOT NULL,
+    start_time timestamp without time zone NOT NULL,
+    open numeric NOT NULL,
+    high numeric NOT NULL,
+    low numeric NOT NULL,
+    close numeric NOT NULL,
+    volume0 numeric(78,0) NOT NULL,
+    volume1 numeric(78,0) NOT NULL,
+    swap_count bigint NOT NULL,
+    last_block bigint NOT NULL,
+    last_log_index bigint NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    updated_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (pool_address, resolution, start_time)
+);
+
+CREATE INDEX pool_candles_pool_address_last_block_idx ON public.pool_candles (pool_address, last_block);
+CREATE INDEX pool_swaps_pool_address_block_timestamp_idx ON public.pool_swaps (pool_address, block_timestamp);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS public.pool_swaps_pool_address_block_timestamp_idx;
+DROP TABLE IF EXISTS public.pool_candles;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.pool_swaps
+    ADD COLUMN fee_amount numeric(78,0),
+    ADD COLUMN protocol_fee_amount numeric(78,0);
+
+CREATE TABLE public.pool_rollups
+(
+    id bigserial NOT NULL,
+    pool_address text NOT NULL,
+    start_time timestamp without time zone NOT NULL,
+    volume0 numeric(78,0) NOT NULL,
+    volume1 numeric(78,0) NOT NULL,
+    fees0 numeric(78,0) NOT NULL,
+    fees1 numeric(78,0) NOT NULL,
+    protocol_fees0 numeric(78,0) NOT NULL,
+    protocol_fees1 numeric(78,0) NOT NULL,
+    swap_count bigint NOT NULL,
+    last_block bigint NOT NULL,
+    last_log_index bigint NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    updated_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (pool_address, start_time)
+);
+
+CREATE INDEX pool_rollups_pool_address_last_block_idx ON public.pool_rollups (pool_address, last_block);
+CREATE INDEX pool_snapshots_pool_address_block_timestamp_idx ON public.pool_snapshots (pool_address, block_timestamp);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS public.pool_snapshots_pool_address_block_timestamp_idx;
+DROP TABLE IF EXISTS public.pool_rollups;
+
+ALTER TABLE public.pool_swaps
+    DROP COLUMN fee_amount,
+    DROP COLUMN protocol_fee_amount;
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.positions
+(
+    id bigserial NOT NULL,
+    token_id numeric(78,0) NOT NULL,
+    owner text NOT NULL,
+    pool_address text NOT NULL,
+    token0 text NOT NULL,
+    token1 text NOT NULL,
+    fee integer NOT NULL,
+    tick_lower integer NOT NULL,
+    tick_upper integer NOT NULL,
+    liquidity numeric(78,0) NOT NULL,
+    fee_growth_inside0_last_x128 numeric(78,0) NOT NULL,
+    fee_growth_inside1_last_x128 numeric(78,0) NOT NULL,
+    tokens_owed0 numeric(78,0) NOT NULL,
+    tokens_owed1 numeric(78,0) NOT NULL,
+    block_number bigint NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    updated_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (token_id)
+);
+
+CREATE INDEX positions_owner_idx ON public.positions (owner);
+
+CREATE TABLE public.position_events
+(
+    id bigserial NOT NULL,
+    token_id numeric(78,0) NOT NULL,
+    event text NOT NULL,
+    txn_id text NOT NULL,
+    block_number bigint NOT NULL,
+    block_hash text NOT NULL,
+    log_index bigint NOT NULL,
+    block_timestamp timestamp without time zone NOT NULL,
+    sender text,
+    recipient text,
+    liquidity numeric(78,0),
+    amount0 numeric(78,0),
+    amount1 numeric(78,0),
+    created_at timestamp without time zone NOT NULL DEFAULT 'NOW()',
+    PRIMARY KEY (id),
+    UNIQUE (txn_id, log_index)
+);
+
+CREATE INDEX position_events_token_id_block_number_idx ON public.position_events (token_id, block_number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.position_events;
+DROP TABLE IF EXISTS public.positions;
+-- +goose StatementEnd
@@ -0,0 +1,137 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/candle_mock.go uniswapper/internal/app/db/repository/pool ICandleRepository
+package pool
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+// applySwapToCandle opens the candle of a swap or folds the swap into it.
+// Swaps that are not after the last one included are already counted, so
+// replaying them leaves the candle unchanged.
+const applySwapToCandle = `INSERT INTO pool_candles
+    (pool_address, resolution, start_time, open, high, low, close, volume0, volume1, swap_count, last_block, last_log_index)
+VALUES (?, ?, ?, ?, ?, ?, ?, ABS(?::numeric), ABS(?::numeric), 1, ?, ?)
+ON CONFLICT (pool_address, resolution, start_time) DO UPDATE SET
+    high = GREATEST(pool_candles.high, EXCLUDED.high),
+    low = LEAST(pool_candles.low, EXCLUDED.low),
+    close = EXCLUDED.close,
+    volume0 = pool_candles.volume0 + EXCLUDED.volume0,
+    volume1 = pool_candles.volume1 + EXCLUDED.volume1,
+    swap_count = pool_candles.swap_count + 1,
+    last_block = EXCLUDED.last_block,
+    last_log_index = EXCLUDED.last_log_index,
+    updated_at = NOW()
+WHERE (pool_candles.last_block, pool_candles.last_log_index) < (EXCLUDED.last_block, EXCLUDED.last_log_index)`
+
+// rebuildCandle aggregates the stored swaps of a pool within one interval
+const rebuildCandle = `INSERT INTO pool_candles
+    (pool_address, resolution, start_time, open, high, low, close, volume0, volume1, swap_count, last_block, last_log_index)
+SELECT pool_address, ?, ?,
+    (array_agg(token1_per_token0 ORDER BY block_number ASC, log_index ASC))[1],
+    MAX(token1_per_token0),
+    MIN(token1_per_token0),
+    (array_agg(token1_per_token0 ORDER BY block_number DESC, log_index DESC))[1],
+    SUM(ABS(amount0)),
+    SUM(ABS(amount1)),
+    COUNT(*),
+    MAX(block_number),
+    (array_agg(log_index ORDER BY block_number DESC, log_index DESC))[1]
+FROM pool_swaps
+WHERE pool_address = ? AND block_timestamp >= ? AND block_timestamp < ? AND token1_per_token0 IS NOT NULL
+GROUP BY pool_address`
+
+type ICandleRepository interface {
+	ApplySwap(ctx context.Context, swap pool_DBModels.Swap) error
+	GetCandles(ctx context.Context, poolID, resolution string, from, to time.Time, limit int) ([]pool_DBModels.Candle, error)
+	RebuildCandlesAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error
+}
+
+type CandleRepository struct {
+	DBService *db.DBService
+}
+
+func NewCandleRepository(dbService *db.DBService) ICandleRepository {
+	return &CandleRepository{
+		DBService: dbService,
+	}
+}
+
+// ApplySwap adds a swap to its candle of every resolution. Swaps stored
+// without a price are left out.
+func (u *CandleRepository) ApplySwap(ctx context.Context, swap pool_DBModels.Swap) error {
+	if swap.Token1PerToken0 == nil {
+		return nil
+	}
+
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	price := *swap.Token1PerToken0
+	for resolution, length := range pool_DBModels.CANDLE_RESOLUTIONS {
+		err := tx.Exec(applySwapToCandle,
+			swap.PoolAddress, resolution, swap.BlockTimestamp.UTC().Truncate(length),
+			price, price, price, price, swap.Amount0, swap.Amount1,
+			swap.BlockNumber, swap.LogIndex,
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// GetCandles returns up to limit candles of a pool starting within [from, to], oldest first
+func (u *CandleRepository) GetCandles(ctx context.Context, poolID, resolution string, from, to time.Time, limit int) ([]pool_DBModels.Candle, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var candles []pool_DBModels.Candle
+	whr := fmt.Sprintf("%s = ? AND %s = ? AND %s BETWEEN ? AND ?",
+		pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_RESOLUTION, pool_DBModels.COLUMN_START_TIME)
+
+	if err := tx.Table(pool_DBModels.CANDLES_TABLE_NAME).
+		Where(whr, poolID, resolution, from.UTC(), to.UTC()).
+		Order(fmt.Sprintf("%s ASC", pool_DBModels.COLUMN_START_TIME)).
+		Limit(limit).Scan(&candles).Error; err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
+
+// RebuildCandlesAfterBlock aggregates again, from the stored swaps, every
+// candle of a pool that includes a swap newer than blockNumber. It is called
+// once those swaps were deleted, so that replaying them counts them once.
+func (u *CandleRepository) RebuildCandlesAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var stale []pool_DBModels.Candle
+	whr := fmt.Sprintf("%s = ? AND %s > ?", pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_LAST_BLOCK)
+
+	if err := tx.Table(pool_DBModels.CANDLES_TABLE_NAME).Where(whr, poolID, blockNumber).Scan(&stale).Error; err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	if err := tx.Table(pool_DBModels.CANDLES_TABLE_NAME).Where(whr, poolID, blockNumber).Delete(pool_DBModels.Candle{}).Error; err != nil {
+		return err
+	}
+	for _, candle := range stale {
+		start := candle.StartTime.UTC()
+		end := start.Add(pool_DBModels.CANDLE_RESOLUTIONS[candle.Resolution])
+		if err := tx.Exec(rebuildCandle, candle.Resolution, start, poolID, start, end).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,59 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/checkpoint_mock.go uniswapper/internal/app/db/repository/pool ICheckpointRepository
+package pool
+
+import (
+	"context"
+	"fmt"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+type ICheckpointRepository interface {
+	GetCheckpoint(ctx context.Context, poolAddress string) (*pool_DBModels.Checkpoint, error)
+	StoreCheckpoint(ctx context.Context, poolAddress string, blockNumber uint64) error
+}
+
+type CheckpointRepository struct {
+	DBService *db.DBService
+}
+
+func NewCheckpointRepository(dbService *db.DBService) ICheckpointRepository {
+	return &CheckpointRepository{
+		DBService: dbService,
+	}
+}
+
+// GetCheckpoint returns the checkpoint of the pool, or nil if it has never been processed
+func (u *CheckpointRepository) GetCheckpoint(ctx context.Context, poolAddress string) (*pool_DBModels.Checkpoint, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var checkpoints []pool_DBModels.Checkpoint
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_POOL_ADDRESS)
+
+	if err := tx.Table(pool_DBModels.CHECKPOINT_TABLE_NAME).Where(whr, poolAddress).Limit(1).Scan(&checkpoints).Error; err != nil {
+		return nil, err
+	}
+	if len(checkpoints) == 0 {
+		return nil, nil
+	}
+	return &checkpoints[0], nil
+}
+
+// StoreCheckpoint upserts the last fully processed block of the pool
+func (u *CheckpointRepository) StoreCheckpoint(ctx context.Context, poolAddress string, blockNumber uint64) error {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s, %s, %s) VALUES (?, ?, NOW()) ON CONFLICT (%s) DO UPDATE SET %s = EXCLUDED.%s, %s = EXCLUDED.%s",
+		pool_DBModels.CHECKPOINT_TABLE_NAME,
+		pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_NUMBER, pool_DBModels.COLUMN_UPDATED_AT,
+		pool_DBModels.COLUMN_POOL_ADDRESS,
+		pool_DBModels.COLUMN_BLOCK_NUMBER, pool_DBModels.COLUMN_BLOCK_NUMBER,
+		pool_DBModels.COLUMN_UPDATED_AT, pool_DBModels.COLUMN_UPDATED_AT,
+	)
+	return tx.Exec(query, poolAddress, blockNumber).Error
+}
@@ -0,0 +1,170 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/events_mock.go uniswapper/internal/app/db/repository/pool IPoolEventsRepository
+package pool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+type IPoolEventsRepository interface {
+	StoreSwap(ctx context.Context, swap pool_DBModels.Swap) error
+	StoreMint(ctx context.Context, mint pool_DBModels.Mint) error
+	StoreBurn(ctx context.Context, burn pool_DBModels.Burn) error
+	StoreCollect(ctx context.Context, collect pool_DBModels.Collect) error
+	StoreFlash(ctx context.Context, flash pool_DBModels.Flash) error
+	GetSwaps(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Swap, error)
+	GetSwapsSince(ctx context.Context, poolID string, from, to time.Time) ([]pool_DBModels.Swap, error)
+	GetMints(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Mint, error)
+	GetBurns(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Burn, error)
+	GetCollects(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Collect, error)
+	GetFlashes(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Flash, error)
+	DeleteEvent(ctx context.Context, blockHash, txnID string, logIndex uint) error
+	DeleteEventsAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error
+}
+
+// eventTables lists every per-event table
+var eventTables = []string{
+	pool_DBModels.SWAP_TABLE_NAME,
+	pool_DBModels.MINT_TABLE_NAME,
+	pool_DBModels.BURN_TABLE_NAME,
+	pool_DBModels.COLLECT_TABLE_NAME,
+	pool_DBModels.FLASH_TABLE_NAME,
+}
+
+type PoolEventsRepository struct {
+	DBService *db.DBService
+}
+
+func NewPoolEventsRepository(dbService *db.DBService) IPoolEventsRepository {
+	return &PoolEventsRepository{
+		DBService: dbService,
+	}
+}
+
+func (u *PoolEventsRepository) StoreSwap(ctx context.Context, swap pool_DBModels.Swap) error {
+	return u.store(pool_DBModels.SWAP_TABLE_NAME, &swap)
+}
+
+func (u *PoolEventsRepository) StoreMint(ctx context.Context, mint pool_DBModels.Mint) error {
+	return u.store(pool_DBModels.MINT_TABLE_NAME, &mint)
+}
+
+func (u *PoolEventsRepository) StoreBurn(ctx context.Context, burn pool_DBModels.Burn) error {
+	return u.store(pool_DBModels.BURN_TABLE_NAME, &burn)
+}
+
+func (u *PoolEventsRepository) StoreCollect(ctx context.Context, collect pool_DBModels.Collect) error {
+	return u.store(pool_DBModels.COLLECT_TABLE_NAME, &collect)
+}
+
+func (u *PoolEventsRepository) StoreFlash(ctx context.Context, flash pool_DBModels.Flash) error {
+	return u.store(pool_DBModels.FLASH_TABLE_NAME, &flash)
+}
+
+func (u *PoolEventsRepository) GetSwaps(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Swap, error) {
+	var swaps []pool_DBModels.Swap
+	err := u.find(pool_DBModels.SWAP_TABLE_NAME, poolID, fromBlock, toBlock, &swaps)
+	return swaps, err
+}
+
+// GetSwapsSince returns the swaps of a pool timestamped within (from, to] in
+// chain order, preceded by the last swap at or before from, which set the
+// state of the pool at from
+func (u *PoolEventsRepository) GetSwapsSince(ctx context.Context, poolID string, from, to time.Time) ([]pool_DBModels.Swap, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	order := fmt.Sprintf("%s, %s", pool_DBModels.COLUMN_BLOCK_NUMBER, pool_DBModels.COLUMN_LOG_INDEX)
+	reverse := fmt.Sprintf("%s DESC, %s DESC", pool_DBModels.COLUMN_BLOCK_NUMBER, pool_DBModels.COLUMN_LOG_INDEX)
+	query := fmt.Sprintf(
+		"(SELECT * FROM %[1]s WHERE %[2]s = ? AND %[3]s <= ? ORDER BY %[4]s LIMIT 1) UNION ALL (SELECT * FROM %[1]s WHERE %[2]s = ? AND %[3]s > ? AND %[3]s <= ?) ORDER BY %[5]s",
+		pool_DBModels.SWAP_TABLE_NAME, pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_TIMESTAMP, reverse, order,
+	)
+
+	var swaps []pool_DBModels.Swap
+	if err := tx.Raw(query, poolID, from, poolID, from, to).Scan(&swaps).Error; err != nil {
+		return nil, err
+	}
+	return swaps, nil
+}
+
+func (u *PoolEventsRepository) GetMints(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Mint, error) {
+	var mints []pool_DBModels.Mint
+	err := u.find(pool_DBModels.MINT_TABLE_NAME, poolID, fromBlock, toBlock, &mints)
+	return mints, err
+}
+
+func (u *PoolEventsRepository) GetBurns(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Burn, error) {
+	var burns []pool_DBModels.Burn
+	err := u.find(pool_DBModels.BURN_TABLE_NAME, poolID, fromBlock, toBlock, &burns)
+	return burns, err
+}
+
+func (u *PoolEventsRepository) GetCollects(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Collect, error) {
+	var collects []pool_DBModels.Collect
+	err := u.find(pool_DBModels.COLLECT_TABLE_NAME, poolID, fromBlock, toBlock, &collects)
+	return collects, err
+}
+
+func (u *PoolEventsRepository) GetFlashes(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Flash, error) {
+	var flashes []pool_DBModels.Flash
+	err := u.find(pool_DBModels.FLASH_TABLE_NAME, poolID, fromBlock, toBlock, &flashes)
+	return flashes, err
+}
+
+// DeleteEvent removes the event decoded from a log of an orphaned block
+func (u *PoolEventsRepository) DeleteEvent(ctx context.Context, blockHash, txnID string, logIndex uint) error {
+	whr := fmt.Sprintf("%s = ? AND %s = ? AND %s = ?", pool_DBModels.COLUMN_BLOCK_HASH, pool_DBModels.COLUMN_TXN_ID, pool_DBModels.COLUMN_LOG_INDEX)
+	return u.delete(whr, blockHash, txnID, logIndex)
+}
+
+// DeleteEventsAfterBlock removes the events of a pool newer than blockNumber
+func (u *PoolEventsRepository) DeleteEventsAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error {
+	whr := fmt.Sprintf("%s = ? AND %s > ?", pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_NUMBER)
+	return u.delete(whr, poolID, blockNumber)
+}
+
+// store inserts a single event row into the given table
+func (u *PoolEventsRepository) store(table string, event interface{}) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	err := tx.Table(table).Set(gormInsertOption, skipDuplicateLogs).Create(event).Error
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// find loads the events of a pool within [fromBlock, toBlock] in chain order
+func (u *PoolEventsRepository) find(table, poolID string, fromBlock, toBlock uint64, out interface{}) error {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ? AND %s BETWEEN ? AND ?", pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_NUMBER)
+	order := fmt.Sprintf("%s ASC, %s ASC", pool_DBModels.COLUMN_BLOCK_NUMBER, pool_DBModels.COLUMN_LOG_INDEX)
+
+	return tx.Table(table).Where(whr, poolID, fromBlock, toBlock).Order(order).Scan(out).Error
+}
+
+// delete removes the rows matching the condition from every event table in one transaction
+func (u *PoolEventsRepository) delete(whr string, args ...interface{}) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	for _, table := range eventTables {
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", table, whr), args...).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,147 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/liquidity_mock.go uniswapper/internal/app/db/repository/pool ILiquidityRepository
+package pool
+
+import (
+	"context"
+	"fmt"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+// seedTables are deleted from in this order, so that the seed goes last
+var seedTables = []string{
+	pool_DBModels.SEED_TICKS_TABLE_NAME,
+	pool_DBModels.LIQUIDITY_SEEDS_TABLE_NAME,
+}
+
+type ILiquidityRepository interface {
+	GetSeed(ctx context.Context, poolID string) (*pool_DBModels.LiquiditySeed, error)
+	GetSeedTicks(ctx context.Context, poolID string) ([]pool_DBModels.Tick, error)
+	StoreSeed(ctx context.Context, seed pool_DBModels.LiquiditySeed, ticks []pool_DBModels.Tick) error
+	DeleteSeedAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error
+	GetTickDeltas(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Tick, error)
+}
+
+type LiquidityRepository struct {
+	DBService *db.DBService
+}
+
+func NewLiquidityRepository(dbService *db.DBService) ILiquidityRepository {
+	return &LiquidityRepository{
+		DBService: dbService,
+	}
+}
+
+// GetSeed returns the liquidity seed of the pool, or nil if it was never seeded
+func (u *LiquidityRepository) GetSeed(ctx context.Context, poolID string) (*pool_DBModels.LiquiditySeed, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var seeds []pool_DBModels.LiquiditySeed
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_POOL_ADDRESS)
+
+	if err := tx.Table(pool_DBModels.LIQUIDITY_SEEDS_TABLE_NAME).Where(whr, poolID).Limit(1).Scan(&seeds).Error; err != nil {
+		return nil, err
+	}
+	if len(seeds) == 0 {
+		return nil, nil
+	}
+	return &seeds[0], nil
+}
+
+func (u *LiquidityRepository) GetSeedTicks(ctx context.Context, poolID string) ([]pool_DBModels.Tick, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var ticks []pool_DBModels.Tick
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_POOL_ADDRESS)
+
+	if err := tx.Table(pool_DBModels.SEED_TICKS_TABLE_NAME).
+		Where(whr, poolID).Order(fmt.Sprintf("%s ASC", pool_DBModels.COLUMN_TICK)).Scan(&ticks).Error; err != nil {
+		return nil, err
+	}
+	return ticks, nil
+}
+
+// StoreSeed replaces the liquidity seed of a pool and its ticks in one transaction
+func (u *LiquidityRepository) StoreSeed(ctx context.Context, seed pool_DBModels.LiquiditySeed, ticks []pool_DBModels.Tick) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_POOL_ADDRESS)
+	for _, table := range seedTables {
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", table, whr), seed.PoolAddress).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Table(pool_DBModels.LIQUIDITY_SEEDS_TABLE_NAME).Create(&seed).Error; err != nil {
+		return err
+	}
+	for _, tick := range ticks {
+		tick.PoolAddress = seed.PoolAddress
+		if err := tx.Table(pool_DBModels.SEED_TICKS_TABLE_NAME).Create(&tick).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// DeleteSeedAfterBlock removes the seed of a pool and its ticks if they were
+// read after blockNumber, as they may include orphaned liquidity
+func (u *LiquidityRepository) DeleteSeedAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ? AND EXISTS (SELECT 1 FROM %s s WHERE s.%s = ? AND s.%s > ?)",
+		pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.LIQUIDITY_SEEDS_TABLE_NAME,
+		pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_NUMBER)
+	for _, table := range seedTables {
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", table, whr), poolID, poolID, blockNumber).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// GetTickDeltas sums the liquidity changes of the Mint and Burn events of a
+// pool in (fromBlock, toBlock] per tick. A position adds its liquidity to
+// the net liquidity of its lower tick and subtracts it from its upper tick.
+func (u *LiquidityRepository) GetTickDeltas(ctx context.Context, poolID string, fromBlock, toBlock uint64) ([]pool_DBModels.Tick, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ? AND %s > ? AND %s <= ?", pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_NUMBER, pool_DBModels.COLUMN_BLOCK_NUMBER)
+	deltas := func(table, tick, net, gross string) string {
+		return fmt.Sprintf("SELECT %s AS %s, %s AS %s, %s AS %s FROM %s WHERE %s",
+			tick, pool_DBModels.COLUMN_TICK, net, pool_DBModels.COLUMN_LIQUIDITY_NET, gross, pool_DBModels.COLUMN_LIQUIDITY_GROSS, table, whr)
+	}
+
+	amount := pool_DBModels.COLUMN_AMOUNT
+	query := fmt.Sprintf(
+		"SELECT %s, SUM(%s) AS %s, SUM(%s) AS %s FROM (%s UNION ALL %s UNION ALL %s UNION ALL %s) deltas GROUP BY %s ORDER BY %s",
+		pool_DBModels.COLUMN_TICK,
+		pool_DBModels.COLUMN_LIQUIDITY_NET, pool_DBModels.COLUMN_LIQUIDITY_NET,
+		pool_DBModels.COLUMN_LIQUIDITY_GROSS, pool_DBModels.COLUMN_LIQUIDITY_GROSS,
+		deltas(pool_DBModels.MINT_TABLE_NAME, pool_DBModels.COLUMN_TICK_LOWER, amount, amount),
+		deltas(pool_DBModels.MINT_TABLE_NAME, pool_DBModels.COLUMN_TICK_UPPER, "-"+amount, amount),
+		deltas(pool_DBModels.BURN_TABLE_NAME, pool_DBModels.COLUMN_TICK_LOWER, "-"+amount, "-"+amount),
+		deltas(pool_DBModels.BURN_TABLE_NAME, pool_DBModels.COLUMN_TICK_UPPER, amount, "-"+amount),
+		pool_DBModels.COLUMN_TICK, pool_DBModels.COLUMN_TICK,
+	)
+
+	args := []interface{}{}
+	for i := 0; i < 4; i++ {
+		args = append(args, poolID, fromBlock, toBlock)
+	}
+
+	var ticks []pool_DBModels.Tick
+	if err := tx.Raw(query, args...).Scan(&ticks).Error; err != nil {
+		return nil, err
+	}
+	return ticks, nil
+}
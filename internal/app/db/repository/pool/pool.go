@@ -3,6 +3,7 @@ package pool
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"uniswapper/internal/app/constants"
 	"uniswapper/internal/app/db"
@@ -10,10 +11,21 @@ import (
 	pool_DBModels "uniswapper/internal/app/db/dto/pool"
 )
 
+const (
+	gormInsertOption = "gorm:insert_option"
+
+	// skipDuplicateLogs turns the insert of an already stored log into a
+	// no-op so that block ranges can be replayed safely. Gorm then reports
+	// sql.ErrNoRows since no id is returned.
+	skipDuplicateLogs = "ON CONFLICT (txn_id, log_index) DO NOTHING"
+)
+
 type IPoolLogsRepository interface {
 	StorePoolLogs(ctx context.Context, logs pool_DBModels.Logs) error
 	GetPoolLogs(ctx context.Context, poolID, block string) (pool_DBModels.Logs, error)
 	GetPoolLogsHistory(ctx context.Context, poolID string) ([]pool_DBModels.Logs, error)
+	DeletePoolLog(ctx context.Context, blockHash, txnID string, logIndex uint) error
+	DeletePoolLogsAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error
 }
 
 type PoolLogsRepository struct {
@@ -31,8 +43,8 @@ func (u *PoolLogsRepository) StorePoolLogs(ctx context.Context, logs pool_DBMode
 	defer tx.Rollback()
 	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
 
-	err := tx.Table(pool_DBModels.TABLE_NAME).Create(&logs).Error
-	if err != nil {
+	err := tx.Table(pool_DBModels.TABLE_NAME).Set(gormInsertOption, skipDuplicateLogs).Create(&logs).Error
+	if err != nil && err != sql.ErrNoRows {
 		return err
 	}
 	tx.Commit()
@@ -78,3 +90,21 @@ func (u *PoolLogsRepository) GetPoolLogsHistory(ctx context.Context, poolID stri
 
 	return poolLogs, nil
 }
+
+// DeletePoolLog removes the snapshot taken from a log of an orphaned block
+func (u *PoolLogsRepository) DeletePoolLog(ctx context.Context, blockHash, txnID string, logIndex uint) error {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ? AND %s = ? AND %s = ?", pool_DBModels.COLUMN_BLOCK_HASH, pool_DBModels.COLUMN_TXN_ID, pool_DBModels.COLUMN_LOG_INDEX)
+	return tx.Table(pool_DBModels.TABLE_NAME).Where(whr, blockHash, txnID, logIndex).Delete(pool_DBModels.Logs{}).Error
+}
+
+// DeletePoolLogsAfterBlock removes the snapshots of a pool newer than blockNumber
+func (u *PoolLogsRepository) DeletePoolLogsAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ? AND %s > ?", pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_NUMBER)
+	return tx.Table(pool_DBModels.TABLE_NAME).Where(whr, poolID, blockNumber).Delete(pool_DBModels.Logs{}).Error
+}
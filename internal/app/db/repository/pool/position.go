@@ -0,0 +1,122 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/position_mock.go uniswapper/internal/app/db/repository/pool IPositionRepository
+package pool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+// updatePosition refreshes the mutable state of a position unless the
+// stored one was read at a later block
+const updatePosition = `ON CONFLICT (token_id) DO UPDATE SET
+    owner = EXCLUDED.owner,
+    liquidity = EXCLUDED.liquidity,
+    fee_growth_inside0_last_x128 = EXCLUDED.fee_growth_inside0_last_x128,
+    fee_growth_inside1_last_x128 = EXCLUDED.fee_growth_inside1_last_x128,
+    tokens_owed0 = EXCLUDED.tokens_owed0,
+    tokens_owed1 = EXCLUDED.tokens_owed1,
+    block_number = EXCLUDED.block_number,
+    updated_at = NOW()
+WHERE positions.block_number <= EXCLUDED.block_number`
+
+type IPositionRepository interface {
+	StorePosition(ctx context.Context, position pool_DBModels.Position) error
+	StorePositionEvent(ctx context.Context, event pool_DBModels.PositionEvent) error
+	GetPosition(ctx context.Context, tokenID string) (*pool_DBModels.Position, error)
+	GetPositions(ctx context.Context, owner string) ([]pool_DBModels.Position, error)
+	GetLiquidityEvents(ctx context.Context, tokenID string, to time.Time) ([]pool_DBModels.PositionEvent, error)
+}
+
+type PositionRepository struct {
+	DBService *db.DBService
+}
+
+func NewPositionRepository(dbService *db.DBService) IPositionRepository {
+	return &PositionRepository{
+		DBService: dbService,
+	}
+}
+
+// StorePosition inserts a position or updates its state
+func (u *PositionRepository) StorePosition(ctx context.Context, position pool_DBModels.Position) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	err := tx.Table(pool_DBModels.POSITIONS_TABLE_NAME).Set(gormInsertOption, updatePosition).Create(&position).Error
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (u *PositionRepository) StorePositionEvent(ctx context.Context, event pool_DBModels.PositionEvent) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	err := tx.Table(pool_DBModels.POSITION_EVENTS_TABLE_NAME).Set(gormInsertOption, skipDuplicateLogs).Create(&event).Error
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// GetPosition returns a position by token ID, or nil if it is not tracked
+func (u *PositionRepository) GetPosition(ctx context.Context, tokenID string) (*pool_DBModels.Position, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var positions []pool_DBModels.Position
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_TOKEN_ID)
+
+	if err := tx.Table(pool_DBModels.POSITIONS_TABLE_NAME).Where(whr, tokenID).Limit(1).Scan(&positions).Error; err != nil {
+		return nil, err
+	}
+	if len(positions) == 0 {
+		return nil, nil
+	}
+	return &positions[0], nil
+}
+
+// GetPositions returns the tracked positions of an owner, or all of them if
+// owner is empty, by token ID
+func (u *PositionRepository) GetPositions(ctx context.Context, owner string) ([]pool_DBModels.Position, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	query := tx.Table(pool_DBModels.POSITIONS_TABLE_NAME)
+	if owner != "" {
+		query = query.Where(fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_OWNER), owner)
+	}
+
+	var positions []pool_DBModels.Position
+	if err := query.Order(pool_DBModels.COLUMN_TOKEN_ID).Scan(&positions).Error; err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// GetLiquidityEvents returns the IncreaseLiquidity and DecreaseLiquidity
+// events of a position timestamped at or before to, in chain order
+func (u *PositionRepository) GetLiquidityEvents(ctx context.Context, tokenID string, to time.Time) ([]pool_DBModels.PositionEvent, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ? AND %s IN (?) AND %s <= ?",
+		pool_DBModels.COLUMN_TOKEN_ID, pool_DBModels.COLUMN_EVENT, pool_DBModels.COLUMN_BLOCK_TIMESTAMP)
+	events := []string{pool_DBModels.POSITION_EVENT_INCREASE_LIQUIDITY, pool_DBModels.POSITION_EVENT_DECREASE_LIQUIDITY}
+	order := fmt.Sprintf("%s, %s", pool_DBModels.COLUMN_BLOCK_NUMBER, pool_DBModels.COLUMN_LOG_INDEX)
+
+	var positionEvents []pool_DBModels.PositionEvent
+	if err := tx.Table(pool_DBModels.POSITION_EVENTS_TABLE_NAME).Where(whr, tokenID, events, to).Order(order).Scan(&positionEvents).Error; err != nil {
+		return nil, err
+	}
+	return positionEvents, nil
+}
@@ -0,0 +1,160 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/registry_mock.go uniswapper/internal/app/db/repository/pool IPoolRegistryRepository
+package pool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+// skipDuplicatePools keeps the first row stored for a pool address
+const skipDuplicatePools = "ON CONFLICT (address) DO NOTHING"
+
+type IPoolRegistryRepository interface {
+	StorePool(ctx context.Context, pool pool_DBModels.Pool) (bool, error)
+	StoreFactoryPool(ctx context.Context, pool pool_DBModels.Pool) (bool, error)
+	GetPool(ctx context.Context, address string) (*pool_DBModels.Pool, error)
+	GetTrackedPools(ctx context.Context) ([]pool_DBModels.Pool, error)
+	SetTracked(ctx context.Context, address string, tracked bool) error
+	SetPaused(ctx context.Context, address string, paused bool) error
+	DeletePool(ctx context.Context, address string) error
+	GetFactoryPoolsAfterBlock(ctx context.Context, blockNumber uint64) ([]pool_DBModels.Pool, error)
+}
+
+type PoolRegistryRepository struct {
+	DBService *db.DBService
+}
+
+func NewPoolRegistryRepository(dbService *db.DBService) IPoolRegistryRepository {
+	return &PoolRegistryRepository{
+		DBService: dbService,
+	}
+}
+
+// StorePool inserts a pool and reports whether it was new to the registry
+func (u *PoolRegistryRepository) StorePool(ctx context.Context, pool pool_DBModels.Pool) (bool, error) {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	err := tx.Table(pool_DBModels.POOLS_TABLE_NAME).Set(gormInsertOption, skipDuplicatePools).Create(&pool).Error
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit().Error
+}
+
+// StoreFactoryPool inserts a pool discovered by the factory and reports
+// whether it was new to the registry. A pool created again after a reorg
+// gets its new creation log; its tracked and paused state is left as is.
+func (u *PoolRegistryRepository) StoreFactoryPool(ctx context.Context, pool pool_DBModels.Pool) (bool, error) {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var existing []pool_DBModels.Pool
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_ADDRESS)
+	if err := tx.Table(pool_DBModels.POOLS_TABLE_NAME).Where(whr, pool.Address).Limit(1).Scan(&existing).Error; err != nil {
+		return false, err
+	}
+
+	if len(existing) == 0 {
+		if err := tx.Table(pool_DBModels.POOLS_TABLE_NAME).Create(&pool).Error; err != nil {
+			return false, err
+		}
+		return true, tx.Commit().Error
+	}
+
+	// Pools added by configuration or by an operator carry no creation log
+	if existing[0].Source != pool_DBModels.POOL_SOURCE_FACTORY {
+		return false, nil
+	}
+
+	err := tx.Table(pool_DBModels.POOLS_TABLE_NAME).Where(whr, pool.Address).Updates(map[string]interface{}{
+		pool_DBModels.COLUMN_TXN_ID:       pool.TxnId,
+		pool_DBModels.COLUMN_BLOCK_NUMBER: pool.BlockNumber,
+		pool_DBModels.COLUMN_BLOCK_HASH:   pool.BlockHash,
+		pool_DBModels.COLUMN_LOG_INDEX:    pool.LogIndex,
+	}).Error
+	if err != nil {
+		return false, err
+	}
+	return false, tx.Commit().Error
+}
+
+// GetPool returns the pool with the given address, or nil if it is unknown
+func (u *PoolRegistryRepository) GetPool(ctx context.Context, address string) (*pool_DBModels.Pool, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var pools []pool_DBModels.Pool
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_ADDRESS)
+
+	if err := tx.Table(pool_DBModels.POOLS_TABLE_NAME).Where(whr, address).Limit(1).Scan(&pools).Error; err != nil {
+		return nil, err
+	}
+	if len(pools) == 0 {
+		return nil, nil
+	}
+	return &pools[0], nil
+}
+
+func (u *PoolRegistryRepository) GetTrackedPools(ctx context.Context) ([]pool_DBModels.Pool, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var pools []pool_DBModels.Pool
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_TRACKED)
+	order := fmt.Sprintf("%s ASC", pool_DBModels.COLUMN_ID)
+
+	err := tx.Table(pool_DBModels.POOLS_TABLE_NAME).Where(whr, true).Order(order).Scan(&pools).Error
+	return pools, err
+}
+
+func (u *PoolRegistryRepository) SetTracked(ctx context.Context, address string, tracked bool) error {
+	return u.update(address, pool_DBModels.COLUMN_TRACKED, tracked)
+}
+
+func (u *PoolRegistryRepository) SetPaused(ctx context.Context, address string, paused bool) error {
+	return u.update(address, pool_DBModels.COLUMN_PAUSED, paused)
+}
+
+// DeletePool removes a pool discovered by the factory whose creation log was
+// orphaned. Pools added by configuration or by an operator are kept.
+func (u *PoolRegistryRepository) DeletePool(ctx context.Context, address string) error {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ? AND %s = ?", pool_DBModels.COLUMN_ADDRESS, pool_DBModels.COLUMN_SOURCE)
+	return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", pool_DBModels.POOLS_TABLE_NAME, whr), address, pool_DBModels.POOL_SOURCE_FACTORY).Error
+}
+
+// GetFactoryPoolsAfterBlock returns the pools discovered by the factory that
+// were created after blockNumber
+func (u *PoolRegistryRepository) GetFactoryPoolsAfterBlock(ctx context.Context, blockNumber uint64) ([]pool_DBModels.Pool, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var pools []pool_DBModels.Pool
+	whr := fmt.Sprintf("%s = ? AND %s > ?", pool_DBModels.COLUMN_SOURCE, pool_DBModels.COLUMN_BLOCK_NUMBER)
+	order := fmt.Sprintf("%s ASC", pool_DBModels.COLUMN_ID)
+
+	err := tx.Table(pool_DBModels.POOLS_TABLE_NAME).Where(whr, pool_DBModels.POOL_SOURCE_FACTORY, blockNumber).Order(order).Scan(&pools).Error
+	return pools, err
+}
+
+// update sets a single column of a pool
+func (u *PoolRegistryRepository) update(address, column string, value interface{}) error {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_ADDRESS)
+	return tx.Table(pool_DBModels.POOLS_TABLE_NAME).Where(whr, address).Update(column, value).Error
+}
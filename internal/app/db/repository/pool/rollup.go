@@ -0,0 +1,157 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/rollup_mock.go uniswapper/internal/app/db/repository/pool IRollupRepository
+package pool
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+// swapRollupColumns are the volume and fees of each swap, in the order of
+// the rollup columns. The fee is charged on the input token, which is the
+// one whose amount is positive.
+const swapRollupColumns = `ABS(amount0) AS volume0,
+    ABS(amount1) AS volume1,
+    CASE WHEN amount0 > 0 THEN COALESCE(fee_amount, 0) - COALESCE(protocol_fee_amount, 0) ELSE 0 END AS fees0,
+    CASE WHEN amount1 > 0 THEN COALESCE(fee_amount, 0) - COALESCE(protocol_fee_amount, 0) ELSE 0 END AS fees1,
+    CASE WHEN amount0 > 0 THEN COALESCE(protocol_fee_amount, 0) ELSE 0 END AS protocol_fees0,
+    CASE WHEN amount1 > 0 THEN COALESCE(protocol_fee_amount, 0) ELSE 0 END AS protocol_fees1`
+
+// applySwapToRollup opens the rollup of a swap or adds the swap to it, once
+const applySwapToRollup = `INSERT INTO pool_rollups
+    (pool_address, start_time, volume0, volume1, fees0, fees1, protocol_fees0, protocol_fees1, swap_count, last_block, last_log_index)
+SELECT pool_address, ?, ` + swapRollupColumns + `, 1, block_number, log_index
+FROM pool_swaps
+WHERE txn_id = ? AND log_index = ?
+ON CONFLICT (pool_address, start_time) DO UPDATE SET
+    volume0 = pool_rollups.volume0 + EXCLUDED.volume0,
+    volume1 = pool_rollups.volume1 + EXCLUDED.volume1,
+    fees0 = pool_rollups.fees0 + EXCLUDED.fees0,
+    fees1 = pool_rollups.fees1 + EXCLUDED.fees1,
+    protocol_fees0 = pool_rollups.protocol_fees0 + EXCLUDED.protocol_fees0,
+    protocol_fees1 = pool_rollups.protocol_fees1 + EXCLUDED.protocol_fees1,
+    swap_count = pool_rollups.swap_count + 1,
+    last_block = EXCLUDED.last_block,
+    last_log_index = EXCLUDED.last_log_index,
+    updated_at = NOW()
+WHERE (pool_rollups.last_block, pool_rollups.last_log_index) < (EXCLUDED.last_block, EXCLUDED.last_log_index)`
+
+// rebuildRollup aggregates the stored swaps of a pool within one period
+const rebuildRollup = `INSERT INTO pool_rollups
+    (pool_address, start_time, volume0, volume1, fees0, fees1, protocol_fees0, protocol_fees1, swap_count, last_block, last_log_index)
+SELECT pool_address, ?, SUM(volume0), SUM(volume1), SUM(fees0), SUM(fees1), SUM(protocol_fees0), SUM(protocol_fees1), COUNT(*),
+    MAX(block_number),
+    (array_agg(log_index ORDER BY block_number DESC, log_index DESC))[1]
+FROM (
+    SELECT pool_address, block_number, log_index, ` + swapRollupColumns + `
+    FROM pool_swaps
+    WHERE pool_address = ? AND block_timestamp >= ? AND block_timestamp < ?
+) swaps
+GROUP BY pool_address`
+
+// rollupTotals sums the rollups within [?, ?) and the swaps of the partial
+// periods [?, ?) and [?, ?) around them
+const rollupTotals = `SELECT COALESCE(SUM(volume0), 0) AS volume0,
+    COALESCE(SUM(volume1), 0) AS volume1,
+    COALESCE(SUM(fees0), 0) AS fees0,
+    COALESCE(SUM(fees1), 0) AS fees1,
+    COALESCE(SUM(protocol_fees0), 0) AS protocol_fees0,
+    COALESCE(SUM(protocol_fees1), 0) AS protocol_fees1,
+    COALESCE(SUM(swap_count), 0) AS swap_count
+FROM (
+    SELECT volume0, volume1, fees0, fees1, protocol_fees0, protocol_fees1, swap_count
+    FROM pool_rollups
+    WHERE pool_address = ? AND start_time >= ? AND start_time < ?
+    UNION ALL
+    SELECT ` + swapRollupColumns + `, 1 AS swap_count
+    FROM pool_swaps
+    WHERE pool_address = ? AND ((block_timestamp >= ? AND block_timestamp < ?) OR (block_timestamp >= ? AND block_timestamp < ?))
+) totals`
+
+type IRollupRepository interface {
+	ApplySwap(ctx context.Context, swap pool_DBModels.Swap) error
+	GetTotals(ctx context.Context, poolID string, from, to time.Time) (*pool_DBModels.Rollup, error)
+	RebuildRollupsAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error
+}
+
+type RollupRepository struct {
+	DBService *db.DBService
+}
+
+func NewRollupRepository(dbService *db.DBService) IRollupRepository {
+	return &RollupRepository{
+		DBService: dbService,
+	}
+}
+
+// ApplySwap adds a stored swap to the rollup of its period. Swaps that are
+// not after the last one included are already counted and left out.
+func (u *RollupRepository) ApplySwap(ctx context.Context, swap pool_DBModels.Swap) error {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	start := swap.BlockTimestamp.UTC().Truncate(pool_DBModels.ROLLUP_PERIOD)
+	return tx.Exec(applySwapToRollup, start, swap.TxnId, swap.LogIndex).Error
+}
+
+// GetTotals sums the volume and fees of a pool within [from, to). Whole
+// periods are read from the rollups and the partial ones from the swaps.
+func (u *RollupRepository) GetTotals(ctx context.Context, poolID string, from, to time.Time) (*pool_DBModels.Rollup, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	from, to = from.UTC(), to.UTC()
+	first := from.Truncate(pool_DBModels.ROLLUP_PERIOD)
+	if first.Before(from) {
+		first = first.Add(pool_DBModels.ROLLUP_PERIOD)
+	}
+	last := to.Truncate(pool_DBModels.ROLLUP_PERIOD)
+	// The window is within a single period
+	if first.After(last) {
+		first, last = to, to
+	}
+
+	var totals []pool_DBModels.Rollup
+	if err := tx.Raw(rollupTotals, poolID, first, last, poolID, from, first, last, to).Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	if len(totals) == 0 {
+		return &pool_DBModels.Rollup{PoolAddress: poolID}, nil
+	}
+	totals[0].PoolAddress = poolID
+	return &totals[0], nil
+}
+
+// RebuildRollupsAfterBlock aggregates again, from the stored swaps, every
+// rollup of a pool that includes a swap newer than blockNumber. It is called
+// once those swaps were deleted, so that replaying them counts them once.
+func (u *RollupRepository) RebuildRollupsAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var stale []pool_DBModels.Rollup
+	whr := fmt.Sprintf("%s = ? AND %s > ?", pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_LAST_BLOCK)
+
+	if err := tx.Table(pool_DBModels.ROLLUPS_TABLE_NAME).Where(whr, poolID, blockNumber).Scan(&stale).Error; err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	if err := tx.Table(pool_DBModels.ROLLUPS_TABLE_NAME).Where(whr, poolID, blockNumber).Delete(pool_DBModels.Rollup{}).Error; err != nil {
+		return err
+	}
+	for _, rollup := range stale {
+		start := rollup.StartTime.UTC()
+		if err := tx.Exec(rebuildRollup, start, poolID, start, start.Add(pool_DBModels.ROLLUP_PERIOD)).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,91 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/snapshot_mock.go uniswapper/internal/app/db/repository/pool ISnapshotRepository
+package pool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+// skipDuplicateSnapshots keeps a single snapshot per pool and block
+const skipDuplicateSnapshots = "ON CONFLICT (pool_address, block_number) DO NOTHING"
+
+type ISnapshotRepository interface {
+	StoreSnapshot(ctx context.Context, snapshot pool_DBModels.Snapshot) error
+	GetLatestSnapshot(ctx context.Context, poolID string) (*pool_DBModels.Snapshot, error)
+	GetSnapshotAt(ctx context.Context, poolID string, at time.Time) (*pool_DBModels.Snapshot, error)
+	DeleteSnapshotsAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error
+}
+
+type SnapshotRepository struct {
+	DBService *db.DBService
+}
+
+func NewSnapshotRepository(dbService *db.DBService) ISnapshotRepository {
+	return &SnapshotRepository{
+		DBService: dbService,
+	}
+}
+
+func (u *SnapshotRepository) StoreSnapshot(ctx context.Context, snapshot pool_DBModels.Snapshot) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	err := tx.Table(pool_DBModels.SNAPSHOTS_TABLE_NAME).Set(gormInsertOption, skipDuplicateSnapshots).Create(&snapshot).Error
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// GetLatestSnapshot returns the most recent snapshot of the pool, or nil if it has none
+func (u *SnapshotRepository) GetLatestSnapshot(ctx context.Context, poolID string) (*pool_DBModels.Snapshot, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var snapshots []pool_DBModels.Snapshot
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_POOL_ADDRESS)
+
+	if err := tx.Table(pool_DBModels.SNAPSHOTS_TABLE_NAME).
+		Where(whr, poolID).Order(fmt.Sprintf("%s DESC", pool_DBModels.COLUMN_BLOCK_NUMBER)).Limit(1).Scan(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	return &snapshots[0], nil
+}
+
+// GetSnapshotAt returns the last snapshot of the pool taken at or before at, or nil if it has none
+func (u *SnapshotRepository) GetSnapshotAt(ctx context.Context, poolID string, at time.Time) (*pool_DBModels.Snapshot, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var snapshots []pool_DBModels.Snapshot
+	whr := fmt.Sprintf("%s = ? AND %s <= ?", pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_TIMESTAMP)
+
+	if err := tx.Table(pool_DBModels.SNAPSHOTS_TABLE_NAME).
+		Where(whr, poolID, at.UTC()).Order(fmt.Sprintf("%s DESC", pool_DBModels.COLUMN_BLOCK_TIMESTAMP)).Limit(1).Scan(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	return &snapshots[0], nil
+}
+
+// DeleteSnapshotsAfterBlock removes the snapshots of a pool newer than blockNumber
+func (u *SnapshotRepository) DeleteSnapshotsAfterBlock(ctx context.Context, poolID string, blockNumber uint64) error {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	whr := fmt.Sprintf("%s = ? AND %s > ?", pool_DBModels.COLUMN_POOL_ADDRESS, pool_DBModels.COLUMN_BLOCK_NUMBER)
+	return tx.Table(pool_DBModels.SNAPSHOTS_TABLE_NAME).Where(whr, poolID, blockNumber).Delete(pool_DBModels.Snapshot{}).Error
+}
@@ -0,0 +1,60 @@
+//go:generate mockgen -package=mock -destination=../../../service/util/testutils/mocks/repository/pool/token_mock.go uniswapper/internal/app/db/repository/pool ITokenRepository
+package pool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db"
+
+	pool_DBModels "uniswapper/internal/app/db/dto/pool"
+)
+
+// skipDuplicateTokens keeps the first metadata stored for a token
+const skipDuplicateTokens = "ON CONFLICT (address) DO NOTHING"
+
+type ITokenRepository interface {
+	GetToken(ctx context.Context, address string) (*pool_DBModels.Token, error)
+	StoreToken(ctx context.Context, token pool_DBModels.Token) error
+}
+
+type TokenRepository struct {
+	DBService *db.DBService
+}
+
+func NewTokenRepository(dbService *db.DBService) ITokenRepository {
+	return &TokenRepository{
+		DBService: dbService,
+	}
+}
+
+// GetToken returns the metadata of a token, or nil if it was never resolved
+func (u *TokenRepository) GetToken(ctx context.Context, address string) (*pool_DBModels.Token, error) {
+	tx := u.DBService.GetDB()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	var tokens []pool_DBModels.Token
+	whr := fmt.Sprintf("%s = ?", pool_DBModels.COLUMN_ADDRESS)
+
+	if err := tx.Table(pool_DBModels.TOKENS_TABLE_NAME).Where(whr, address).Limit(1).Scan(&tokens).Error; err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return &tokens[0], nil
+}
+
+func (u *TokenRepository) StoreToken(ctx context.Context, token pool_DBModels.Token) error {
+	tx := u.DBService.GetDB().Begin()
+	defer tx.Rollback()
+	tx.LogMode(constants.Config.DatabaseConfig.DB_LOG_MODE)
+
+	err := tx.Table(pool_DBModels.TOKENS_TABLE_NAME).Set(gormInsertOption, skipDuplicateTokens).Create(&token).Error
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	tx.Commit()
+	return nil
+}
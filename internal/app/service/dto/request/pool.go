@@ -0,0 +1,11 @@
+package request
+
+// AddPoolRequest is the body of a request to start tracking a pool
+type AddPoolRequest struct {
+	Address string `json:"address" binding:"required"`
+}
+
+// UpdatePoolRequest is the body of a request to pause or resume a pool
+type UpdatePoolRequest struct {
+	Paused *bool `json:"paused" binding:"required"`
+}
@@ -0,0 +1,197 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/analytics_mock.go uniswapper/internal/app/service/pool IAnalyticsService
+package pool
+
+import (
+	"context"
+	"math/big"
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Volume is the amount of each token swapped through a pool in [From, To),
+// in base units
+type Volume struct {
+	Address   string         `json:"address"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Volume0   numeric.BigInt `json:"volume0"`
+	Volume1   numeric.BigInt `json:"volume1"`
+	SwapCount uint64         `json:"swap_count"`
+}
+
+// Fees are the swap fees of a pool in [From, To), in base units. Fees0 and
+// Fees1 are earned by the liquidity providers, net of the protocol fees.
+type Fees struct {
+	Address       string         `json:"address"`
+	From          time.Time      `json:"from"`
+	To            time.Time      `json:"to"`
+	FeeTier       uint32         `json:"fee_tier"`
+	Fees0         numeric.BigInt `json:"fees0"`
+	Fees1         numeric.BigInt `json:"fees1"`
+	ProtocolFees0 numeric.BigInt `json:"protocol_fees0"`
+	ProtocolFees1 numeric.BigInt `json:"protocol_fees1"`
+}
+
+// ValueLocked is the balance of each token held by a pool at a snapshot,
+// and their total value in each token when the price is known
+type ValueLocked struct {
+	BlockNumber    uint64         `json:"block_number"`
+	BlockTimestamp time.Time      `json:"block_timestamp"`
+	Token0         numeric.BigInt `json:"token0"`
+	Token1         numeric.BigInt `json:"token1"`
+	ValueInToken0  *string        `json:"value_in_token0"`
+	ValueInToken1  *string        `json:"value_in_token1"`
+}
+
+// TVL is the value locked in a pool at the last snapshots before From and
+// To. Either is nil if no snapshot was taken by then.
+type TVL struct {
+	Address string       `json:"address"`
+	From    time.Time    `json:"from"`
+	To      time.Time    `json:"to"`
+	Start   *ValueLocked `json:"start"`
+	End     *ValueLocked `json:"end"`
+}
+
+// IAnalyticsService aggregates the activity of a pool over a window
+type IAnalyticsService interface {
+	GetVolume(ctx context.Context, address string, from, to time.Time) (*Volume, error)
+	GetFees(ctx context.Context, address string, from, to time.Time) (*Fees, error)
+	GetTVL(ctx context.Context, address string, from, to time.Time) (*TVL, error)
+}
+
+// AnalyticsService reads the hourly rollups maintained by the ingestor and
+// the pool snapshots
+type AnalyticsService struct {
+	RollupDBClient   pool.IRollupRepository
+	SnapshotDBClient pool.ISnapshotRepository
+	Metadata         IPoolMetadataService
+}
+
+func NewAnalyticsService(
+	rollupDBClient pool.IRollupRepository,
+	snapshotDBClient pool.ISnapshotRepository,
+	metadata IPoolMetadataService,
+) IAnalyticsService {
+	return &AnalyticsService{
+		RollupDBClient:   rollupDBClient,
+		SnapshotDBClient: snapshotDBClient,
+		Metadata:         metadata,
+	}
+}
+
+func (s *AnalyticsService) GetVolume(ctx context.Context, address string, from, to time.Time) (*Volume, error) {
+	address = common.HexToAddress(address).String()
+
+	totals, err := s.RollupDBClient.GetTotals(ctx, address, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Volume{
+		Address:   address,
+		From:      from,
+		To:        to,
+		Volume0:   totals.Volume0,
+		Volume1:   totals.Volume1,
+		SwapCount: totals.SwapCount,
+	}, nil
+}
+
+func (s *AnalyticsService) GetFees(ctx context.Context, address string, from, to time.Time) (*Fees, error) {
+	address = common.HexToAddress(address).String()
+
+	metadata, err := s.Metadata.GetPoolMetadata(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.RollupDBClient.GetTotals(ctx, address, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fees{
+		Address:       address,
+		From:          from,
+		To:            to,
+		FeeTier:       metadata.Fee,
+		Fees0:         totals.Fees0,
+		Fees1:         totals.Fees1,
+		ProtocolFees0: totals.ProtocolFees0,
+		ProtocolFees1: totals.ProtocolFees1,
+	}, nil
+}
+
+func (s *AnalyticsService) GetTVL(ctx context.Context, address string, from, to time.Time) (*TVL, error) {
+	log := logger.Logger(ctx)
+	address = common.HexToAddress(address).String()
+
+	start, err := s.SnapshotDBClient.GetSnapshotAt(ctx, address, from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := s.SnapshotDBClient.GetSnapshotAt(ctx, address, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var decimals0, decimals1 *uint8
+	metadata, err := s.Metadata.GetPoolMetadata(ctx, address)
+	if err != nil {
+		log.Warnf("Error valuing the tokens of pool %s: %v", address, err)
+	} else {
+		decimals0, decimals1 = &metadata.Token0.Decimals, &metadata.Token1.Decimals
+	}
+
+	return &TVL{
+		Address: address,
+		From:    from,
+		To:      to,
+		Start:   valueLocked(start, decimals0, decimals1),
+		End:     valueLocked(end, decimals0, decimals1),
+	}, nil
+}
+
+// valueLocked values the balances of a snapshot at its price. The values
+// are left out when the price or the token decimals are unknown.
+func valueLocked(snapshot *posts.Snapshot, decimals0, decimals1 *uint8) *ValueLocked {
+	if snapshot == nil {
+		return nil
+	}
+
+	locked := &ValueLocked{
+		BlockNumber:    snapshot.BlockNumber,
+		BlockTimestamp: snapshot.BlockTimestamp,
+		Token0:         snapshot.Token0Balance,
+		Token1:         snapshot.Token1Balance,
+	}
+	if decimals0 == nil || decimals1 == nil || snapshot.Token1PerToken0 == nil {
+		return locked
+	}
+
+	price, ok := new(big.Rat).SetString(*snapshot.Token1PerToken0)
+	if !ok || price.Sign() <= 0 {
+		return locked
+	}
+
+	amount0 := new(big.Rat).SetFrac(snapshot.Token0Balance.Big(), pow10(*decimals0))
+	amount1 := new(big.Rat).SetFrac(snapshot.Token1Balance.Big(), pow10(*decimals1))
+
+	inToken1 := new(big.Rat).Add(new(big.Rat).Mul(amount0, price), amount1)
+	inToken0 := new(big.Rat).Quo(inToken1, price)
+	if inToken1.Sign() == 0 {
+		zero := "0"
+		locked.ValueInToken0, locked.ValueInToken1 = &zero, &zero
+		return locked
+	}
+
+	value0, value1 := formatPrice(inToken0), formatPrice(inToken1)
+	locked.ValueInToken0, locked.ValueInToken1 = &value0, &value1
+	return locked
+}
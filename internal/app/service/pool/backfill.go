@@ -0,0 +1,72 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const defaultBackfillChunkSize uint64 = 2000
+
+// backfill replays the logs of the given addresses in [from, to] through the
+// same handler as the live stream. The block range per eth_getLogs call
+// adapts to the provider: it is halved whenever a query fails (e.g. the
+// provider's result-size limit is hit) and doubled again after each
+// successful query, up to the configured chunk size.
+func (u *UniswapV3Pool) backfill(ctx context.Context, addresses []common.Address, from, to uint64) error {
+	log := logger.Logger(ctx)
+
+	maxChunk := u.backfillChunkSize
+	if maxChunk == 0 {
+		maxChunk = defaultBackfillChunkSize
+	}
+	chunk := maxChunk
+
+	log.Infof("Backfilling %d pools from block %d to %d", len(addresses), from, to)
+
+	for from <= to {
+		end := from + chunk - 1
+		if end > to || end < from {
+			end = to
+		}
+
+		logs, err := u.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: addresses,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if chunk == 1 {
+				return fmt.Errorf("fetching logs of block %d: %w", from, err)
+			}
+			chunk /= 2
+			log.Warnf("Splitting backfill range %d-%d to %d blocks: %v", from, end, chunk, err)
+			continue
+		}
+
+		for _, vLog := range logs {
+			if err := u.handleLog(ctx, vLog); err != nil {
+				return err
+			}
+		}
+
+		log.Infof("Backfilled blocks %d-%d (%d logs)", from, end, len(logs))
+
+		from = end + 1
+		if chunk < maxChunk {
+			chunk *= 2
+			if chunk > maxChunk {
+				chunk = maxChunk
+			}
+		}
+	}
+
+	return nil
+}
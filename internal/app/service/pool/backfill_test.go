@@ -0,0 +1,158 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"uniswapper/internal/app/service/rpc"
+	"uniswapper/internal/app/service/util/testutils/ethnode"
+	testutils "uniswapper/internal/app/service/util/testutils/mocks"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTest(t *testing.T) {
+	envPath := "../../../../.env"
+	testutils.SetupTest(t, envPath)
+}
+
+// newTestNode starts a fake node with head blocks and a client on it
+func newTestNode(t *testing.T, head uint64) (*ethnode.Node, *rpc.Client) {
+	node := ethnode.New()
+	node.Mine(head)
+	t.Cleanup(node.Close)
+
+	client, err := rpc.NewClientWithEndpoints(nil, []string{node.URL()}, 0)
+	assert.NoError(t, err)
+	t.Cleanup(client.Close)
+	return node, client
+}
+
+func TestBackfill(t *testing.T) {
+	setupTest(t)
+
+	errTooManyResults := errors.New("query returned more than 10000 results")
+	addresses := []common.Address{common.HexToAddress(usdcWethPool)}
+
+	testCases := []struct {
+		name     string
+		maxChunk uint64
+		from     uint64
+		to       uint64
+		// logsError fails the requested range, like a provider limit
+		logsError func(from, to uint64) error
+		expected  []ethnode.Range
+		err       error
+	}{
+		{
+			name:     "fixed chunks",
+			maxChunk: 8,
+			from:     1,
+			to:       20,
+			expected: []ethnode.Range{{From: 1, To: 8}, {From: 9, To: 16}, {From: 17, To: 20}},
+		},
+		{
+			name:     "halving and growing back",
+			maxChunk: 8,
+			from:     1,
+			to:       20,
+			// Blocks 1-4 are dense, only two of them fit in a response
+			logsError: func(from, to uint64) error {
+				if from < 5 && to-from+1 > 2 {
+					return errTooManyResults
+				}
+				return nil
+			},
+			expected: []ethnode.Range{
+				{From: 1, To: 8},
+				{From: 1, To: 4},
+				{From: 1, To: 2},
+				{From: 3, To: 6},
+				{From: 3, To: 4},
+				{From: 5, To: 8},
+				{From: 9, To: 16},
+				{From: 17, To: 20},
+			},
+		},
+		{
+			name:     "failing single block",
+			maxChunk: 4,
+			from:     1,
+			to:       8,
+			logsError: func(from, to uint64) error {
+				if from <= 3 && to >= 3 {
+					return errTooManyResults
+				}
+				return nil
+			},
+			expected: []ethnode.Range{
+				{From: 1, To: 4},
+				{From: 1, To: 2},
+				{From: 3, To: 6},
+				{From: 3, To: 4},
+				{From: 3, To: 3},
+			},
+			err: errTooManyResults,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestNode(t, 20)
+			node.LogsError = tc.logsError
+
+			u := &UniswapV3Pool{client: client, backfillChunkSize: tc.maxChunk}
+			err := u.backfill(context.Background(), addresses, tc.from, tc.to)
+			if tc.err != nil {
+				assert.ErrorContains(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, node.Queries())
+		})
+	}
+}
+
+func TestBackfillCancelled(t *testing.T) {
+	setupTest(t)
+
+	node, client := newTestNode(t, 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The backfill is stopped while a query fails, instead of splitting it
+	node.LogsError = func(from, to uint64) error {
+		cancel()
+		return errors.New("query timeout exceeded")
+	}
+
+	u := &UniswapV3Pool{client: client, backfillChunkSize: 8}
+	err := u.backfill(ctx, []common.Address{common.HexToAddress(usdcWethPool)}, 1, 20)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []ethnode.Range{{From: 1, To: 8}}, node.Queries())
+}
+
+func TestBackfillFailedLog(t *testing.T) {
+	setupTest(t)
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	assert.NoError(t, err)
+
+	// A swap whose data cannot be decoded, in the second chunk
+	node, client := newTestNode(t, 20)
+	broken := goldenLog([]string{swapTopic, routerTopic, routerTopic}, "0x"+strings.Repeat("0", 64))
+	broken.BlockNumber = 12
+	node.AddLog(broken)
+
+	// The backfill stops at the failed log, so catchUp does not confirm the
+	// blocks and the next session replays them
+	u := &UniswapV3Pool{client: client, poolABI: poolABI, backfillChunkSize: 8}
+	err = u.backfill(context.Background(), []common.Address{common.HexToAddress(usdcWethPool)}, 1, 20)
+	assert.ErrorContains(t, err, "decoding log")
+	assert.Equal(t, []ethnode.Range{{From: 1, To: 8}, {From: 9, To: 16}}, node.Queries())
+}
@@ -0,0 +1,51 @@
+package pool
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// backoff yields exponentially growing delays with jitter between min and max
+type backoff struct {
+	min     time.Duration
+	max     time.Duration
+	attempt int
+}
+
+func newBackoff(min, max time.Duration) *backoff {
+	if min <= 0 {
+		min = defaultMinBackoff
+	}
+	if max < min {
+		max = defaultMaxBackoff
+		if max < min {
+			max = min
+		}
+	}
+	return &backoff{min: min, max: max}
+}
+
+// next returns the delay before the next attempt, picked at random from the
+// upper half of the current exponential step so that instances restarting
+// together do not reconnect in lockstep
+func (b *backoff) next() time.Duration {
+	delay := b.max
+	if b.attempt < 32 {
+		if d := b.min << uint(b.attempt); d > 0 && d < b.max {
+			delay = d
+		}
+	}
+	b.attempt++
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+func (b *backoff) reset() {
+	b.attempt = 0
+}
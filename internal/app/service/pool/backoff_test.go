@@ -0,0 +1,80 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackoff(t *testing.T) {
+	testCases := []struct {
+		name        string
+		min         time.Duration
+		max         time.Duration
+		expectedMin time.Duration
+		expectedMax time.Duration
+	}{
+		{name: "configured", min: 2 * time.Second, max: 30 * time.Second, expectedMin: 2 * time.Second, expectedMax: 30 * time.Second},
+		{name: "unset", expectedMin: defaultMinBackoff, expectedMax: defaultMaxBackoff},
+		{name: "max below min", min: 5 * time.Second, max: time.Second, expectedMin: 5 * time.Second, expectedMax: defaultMaxBackoff},
+		{name: "min above the default max", min: 2 * time.Minute, expectedMin: 2 * time.Minute, expectedMax: 2 * time.Minute},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			b := newBackoff(tc.min, tc.max)
+			assert.Equal(t, tc.expectedMin, b.min)
+			assert.Equal(t, tc.expectedMax, b.max)
+		})
+	}
+}
+
+func TestBackoffNext(t *testing.T) {
+	min, max := 100*time.Millisecond, 2*time.Second
+
+	// The step doubles from min until it is capped at max, far beyond the
+	// shift that would overflow
+	steps := []time.Duration{100, 200, 400, 800, 1600, 2000, 2000}
+	for i := range steps {
+		steps[i] *= time.Millisecond
+	}
+
+	for run := 0; run < 100; run++ {
+		b := newBackoff(min, max)
+		for attempt, step := range steps {
+			delay := b.next()
+			// Jittered within the upper half of the step
+			assert.GreaterOrEqual(t, delay, step/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, delay, step, "attempt %d", attempt)
+		}
+
+		b.attempt = 100
+		delay := b.next()
+		assert.GreaterOrEqual(t, delay, max/2)
+		assert.LessOrEqual(t, delay, max)
+	}
+
+	// Instances restarting together do not all wait the same delay
+	delays := make(map[time.Duration]bool)
+	for run := 0; run < 100; run++ {
+		delays[newBackoff(min, max).next()] = true
+	}
+	assert.Greater(t, len(delays), 1)
+}
+
+func TestBackoffReset(t *testing.T) {
+	min, max := 100*time.Millisecond, 2*time.Second
+
+	b := newBackoff(min, max)
+	for i := 0; i < 10; i++ {
+		b.next()
+	}
+
+	b.reset()
+	delay := b.next()
+	assert.GreaterOrEqual(t, delay, min/2)
+	assert.LessOrEqual(t, delay, min)
+}
@@ -0,0 +1,68 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"uniswapper/internal/app/service/rpc"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// callContract executes a view function of a contract at blockNumber, or at
+// the latest block if nil, and returns the unpacked outputs
+func callContract(
+	ctx context.Context,
+	client *rpc.Client,
+	contractABI abi.ABI,
+	address common.Address,
+	method string,
+	blockNumber *big.Int,
+	args ...interface{},
+) ([]interface{}, error) {
+	input, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: input}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return contractABI.Unpack(method, output)
+}
+
+// callPool executes a view function of a pool at blockNumber, or at the
+// latest block if nil. Reverts and undecodable results mean the address is
+// not a pool; any other failure is returned as is, since the call may
+// succeed when retried.
+func callPool(
+	ctx context.Context,
+	client *rpc.Client,
+	poolABI abi.ABI,
+	address common.Address,
+	method string,
+	blockNumber *big.Int,
+) ([]interface{}, error) {
+	input, err := poolABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: input}, blockNumber)
+	if err != nil {
+		if rpc.IsRevert(err) {
+			return nil, fmt.Errorf("%w: %s(): %v", ErrNotAV3Pool, method, err)
+		}
+		return nil, err
+	}
+
+	out, err := poolABI.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s(): %v", ErrNotAV3Pool, method, err)
+	}
+	return out, nil
+}
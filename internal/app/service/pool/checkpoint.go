@@ -0,0 +1,96 @@
+package pool
+
+import (
+	"context"
+	"uniswapper/internal/app/constants"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// catchUp fills the gap between each pool's checkpoint and head with
+// historical log queries, then marks the last confirmed block as processed
+// for every pool. Rows stored after the checkpoint were not final yet and may
+// come from orphaned blocks, so they are deleted and replayed, and pools
+// discovered in blocks that were orphaned are removed; rows already
+// stored are otherwise skipped by the repositories, so replaying a block
+// never duplicates data. Pools without a liquidity seed are seeded at the
+// block before the replay, or at head if they have no history.
+func (u *UniswapV3Pool) catchUp(ctx context.Context, head uint64) error {
+	for _, address := range u.following {
+		from, ok, err := u.resumeBlock(ctx, address)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			u.seedLiquidity(ctx, address, head)
+			continue
+		}
+		if from > head {
+			continue
+		}
+
+		if from > 0 {
+			if err := u.deleteAfterBlock(ctx, address, from-1); err != nil {
+				return err
+			}
+			u.seedLiquidity(ctx, address, from-1)
+		}
+
+		if err := u.backfill(ctx, []common.Address{address}, from, head); err != nil {
+			return err
+		}
+		if from > 0 && u.isFactory(address) {
+			if err := u.pruneOrphanedPools(ctx, from-1); err != nil {
+				return err
+			}
+		}
+	}
+
+	if head <= u.confirmations {
+		return nil
+	}
+	return u.confirm(ctx, head-u.confirmations)
+}
+
+// resumeBlock returns the first block that still has to be processed for the
+// pool or factory, or false if it has no history to replay. Discovered pools
+// are replayed from their creation block.
+func (u *UniswapV3Pool) resumeBlock(ctx context.Context, address common.Address) (uint64, bool, error) {
+	checkpoint, err := u.CheckpointDBClient.GetCheckpoint(ctx, address.String())
+	if err != nil {
+		return 0, false, err
+	}
+	if checkpoint != nil {
+		return checkpoint.BlockNumber + 1, true, nil
+	}
+	if u.isFactory(address) {
+		return u.factory.startBlock, true, nil
+	}
+	if start, ok := u.pools.startBlock(address); ok {
+		return start, true, nil
+	}
+	if constants.Config.PoolConfig.POOL_BACKFILL_ENABLED {
+		return constants.Config.PoolConfig.POOL_BACKFILL_START_BLOCK, true, nil
+	}
+	return 0, false, nil
+}
+
+// confirm handles block becoming final: the checkpoints advance to it and
+// the pool snapshots due by then are taken
+func (u *UniswapV3Pool) confirm(ctx context.Context, block uint64) error {
+	if err := u.advanceCheckpoints(ctx, block); err != nil {
+		return err
+	}
+	u.takeSnapshots(ctx, block)
+	return nil
+}
+
+// advanceCheckpoints records block as fully processed for every tracked pool
+func (u *UniswapV3Pool) advanceCheckpoints(ctx context.Context, block uint64) error {
+	for _, address := range u.following {
+		if err := u.CheckpointDBClient.StoreCheckpoint(ctx, address.String(), block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// UniswapV3Pool event names as declared in the pool ABI
+const (
+	EVENT_SWAP       = "Swap"
+	EVENT_MINT       = "Mint"
+	EVENT_BURN       = "Burn"
+	EVENT_COLLECT    = "Collect"
+	EVENT_FLASH      = "Flash"
+	EVENT_INITIALIZE = "Initialize"
+
+	EVENT_SET_FEE_PROTOCOL = "SetFeeProtocol"
+)
+
+var (
+	errNoTopics       = errors.New("log has no topics")
+	errUnhandledEvent = errors.New("unhandled pool event")
+)
+
+// SwapEvent is emitted by the pool for any swap between token0 and token1.
+// Amount0 and Amount1 are signed deltas of the pool balances.
+type SwapEvent struct {
+	Sender       common.Address
+	Recipient    common.Address
+	Amount0      *big.Int
+	Amount1      *big.Int
+	SqrtPriceX96 *big.Int
+	Liquidity    *big.Int
+	Tick         *big.Int
+	Raw          types.Log
+}
+
+// MintEvent is emitted when liquidity is minted for a given position
+type MintEvent struct {
+	Sender    common.Address
+	Owner     common.Address
+	TickLower *big.Int
+	TickUpper *big.Int
+	Amount    *big.Int
+	Amount0   *big.Int
+	Amount1   *big.Int
+	Raw       types.Log
+}
+
+// BurnEvent is emitted when a position's liquidity is removed
+type BurnEvent struct {
+	Owner     common.Address
+	TickLower *big.Int
+	TickUpper *big.Int
+	Amount    *big.Int
+	Amount0   *big.Int
+	Amount1   *big.Int
+	Raw       types.Log
+}
+
+// CollectEvent is emitted when fees are collected by the owner of a position
+type CollectEvent struct {
+	Owner     common.Address
+	Recipient common.Address
+	TickLower *big.Int
+	TickUpper *big.Int
+	Amount0   *big.Int
+	Amount1   *big.Int
+	Raw       types.Log
+}
+
+// FlashEvent is emitted by the pool for a flash loan of token0/token1
+type FlashEvent struct {
+	Sender    common.Address
+	Recipient common.Address
+	Amount0   *big.Int
+	Amount1   *big.Int
+	Paid0     *big.Int
+	Paid1     *big.Int
+	Raw       types.Log
+}
+
+// InitializeEvent is emitted exactly once by a pool when its price is first set
+type InitializeEvent struct {
+	SqrtPriceX96 *big.Int
+	Tick         *big.Int
+	Raw          types.Log
+}
+
+// SetFeeProtocolEvent is emitted when the factory owner changes the share of
+// the swap fees taken by the protocol, as the denominator of 1/x per token
+type SetFeeProtocolEvent struct {
+	FeeProtocol0Old uint8
+	FeeProtocol1Old uint8
+	FeeProtocol0New uint8
+	FeeProtocol1New uint8
+	Raw             types.Log
+}
+
+// decodeLog dispatches on the event signature in Topics[0] and unpacks the
+// log into the matching typed event struct
+func decodeLog(contractAbi abi.ABI, vLog types.Log) (interface{}, error) {
+	if len(vLog.Topics) == 0 {
+		return nil, errNoTopics
+	}
+
+	event, err := contractAbi.EventByID(vLog.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var out interface{}
+	switch event.Name {
+	case EVENT_SWAP:
+		out = &SwapEvent{Raw: vLog}
+	case EVENT_MINT:
+		out = &MintEvent{Raw: vLog}
+	case EVENT_BURN:
+		out = &BurnEvent{Raw: vLog}
+	case EVENT_COLLECT:
+		out = &CollectEvent{Raw: vLog}
+	case EVENT_FLASH:
+		out = &FlashEvent{Raw: vLog}
+	case EVENT_INITIALIZE:
+		out = &InitializeEvent{Raw: vLog}
+	case EVENT_SET_FEE_PROTOCOL:
+		out = &SetFeeProtocolEvent{Raw: vLog}
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnhandledEvent, event.Name)
+	}
+
+	if err := unpackLog(contractAbi, out, event.Name, vLog); err != nil {
+		return nil, fmt.Errorf("unpack %s: %w", event.Name, err)
+	}
+	return out, nil
+}
+
+// unpackLog unpacks the non-indexed fields from the log data and the indexed
+// fields from the log topics into out
+func unpackLog(contractAbi abi.ABI, out interface{}, event string, vLog types.Log) error {
+	if len(vLog.Data) > 0 {
+		if err := contractAbi.UnpackIntoInterface(out, event, vLog.Data); err != nil {
+			return err
+		}
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range contractAbi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	return abi.ParseTopics(out, indexed, vLog.Topics[1:])
+}
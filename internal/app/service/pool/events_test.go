@@ -0,0 +1,208 @@
+package pool
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// Golden logs of the USDC/WETH 0.05% pool, with the topics and data laid out
+// word by word as the pool contract emits them, independently of the ABI
+// package. Topic 0 is the keccak256 of the event signature.
+const (
+	usdcWethPool    = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+	universalRouter = "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD"
+	positionManager = "0xC36442b4a4522E871399CD717aBDD847Ab11FE88"
+
+	swapTopic    = "0xc42079f94a6350d7e6235f29174924f928cc2ac818eb64fed8004e115fbcca67"
+	mintTopic    = "0x7a53080ba414158be7ec69b987b5fb7d07dee101fe85488f0853ae16239d0bde"
+	burnTopic    = "0x0c396cd989a39f4459b5fa1aed6a9a8dcdbc45908acfd67e028cd568da98982c"
+	collectTopic = "0x70935338e69775456a85ddef226c395fb668b63fa0115f5f20610b388e6ca9c0"
+	flashTopic   = "0xbdbdb71d7860376ba52b25a5028beea23581364a40522f6bcfb86bb1f2dca633"
+
+	routerTopic  = "0x0000000000000000000000003fc91a3afd70395cd496c647d5a6cc9d4b2b7fad"
+	managerTopic = "0x000000000000000000000000c36442b4a4522e871399cd717abdd847ab11fe88"
+	// int24 ticks are sign-extended to 32 bytes in topics and data
+	minTickTopic = "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffff2764c"
+	maxTickTopic = "0x00000000000000000000000000000000000000000000000000000000000d89b4"
+)
+
+func goldenLog(topics []string, data string) types.Log {
+	vLog := types.Log{
+		Address:     common.HexToAddress(usdcWethPool),
+		BlockNumber: 17000000,
+		TxHash:      common.HexToHash("0x01"),
+		Index:       7,
+		Data:        common.FromHex(data),
+	}
+	for _, topic := range topics {
+		vLog.Topics = append(vLog.Topics, common.HexToHash(topic))
+	}
+	return vLog
+}
+
+func bigString(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+func TestDecodeLog(t *testing.T) {
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		log      types.Log
+		expected interface{}
+	}{
+		{
+			name: "swap",
+			log: goldenLog(
+				[]string{swapTopic, routerTopic, routerTopic},
+				"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffff6afd0700"+
+					"0000000000000000000000000000000000000000000000000de0b6b3a7640000"+
+					"00000000000000000000000000000000000061ffb97edec2183ed8fd6f5fc5eb"+
+					"000000000000000000000000000000000000000000000000ab54a98ceb1f0ad2"+
+					"0000000000000000000000000000000000000000000000000000000000031212",
+			),
+			expected: &SwapEvent{
+				Sender:       common.HexToAddress(universalRouter),
+				Recipient:    common.HexToAddress(universalRouter),
+				Amount0:      big.NewInt(-2500000000),
+				Amount1:      bigString("1000000000000000000"),
+				SqrtPriceX96: bigString("1987654321098765432109876543210987"),
+				Liquidity:    bigString("12345678901234567890"),
+				Tick:         big.NewInt(201234),
+			},
+		},
+		{
+			name: "mint",
+			log: goldenLog(
+				[]string{mintTopic, managerTopic, minTickTopic, maxTickTopic},
+				"0x000000000000000000000000c36442b4a4522e871399cd717abdd847ab11fe88"+
+					"0000000000000000000000000000000000000000000000000000048c27395000"+
+					"000000000000000000000000000000000000000000000000000000003b9aca00"+
+					"000000000000000000000000000000000000000000000000058d15e176280000",
+			),
+			expected: &MintEvent{
+				Sender:    common.HexToAddress(positionManager),
+				Owner:     common.HexToAddress(positionManager),
+				TickLower: big.NewInt(-887220),
+				TickUpper: big.NewInt(887220),
+				Amount:    big.NewInt(5000000000000),
+				Amount0:   big.NewInt(1000000000),
+				Amount1:   bigString("400000000000000000"),
+			},
+		},
+		{
+			name: "burn",
+			log: goldenLog(
+				[]string{burnTopic, managerTopic, minTickTopic, maxTickTopic},
+				"0x0000000000000000000000000000000000000000000000000000048c27395000"+
+					"000000000000000000000000000000000000000000000000000000003b9ac9ff"+
+					"000000000000000000000000000000000000000000000000058d15e17627ffff",
+			),
+			expected: &BurnEvent{
+				Owner:     common.HexToAddress(positionManager),
+				TickLower: big.NewInt(-887220),
+				TickUpper: big.NewInt(887220),
+				Amount:    big.NewInt(5000000000000),
+				Amount0:   big.NewInt(999999999),
+				Amount1:   bigString("399999999999999999"),
+			},
+		},
+		{
+			name: "collect",
+			log: goldenLog(
+				[]string{
+					collectTopic,
+					managerTopic,
+					"0x0000000000000000000000000000000000000000000000000000000000030d40",
+					"0x0000000000000000000000000000000000000000000000000000000000031510",
+				},
+				"0x000000000000000000000000c36442b4a4522e871399cd717abdd847ab11fe88"+
+					"000000000000000000000000000000000000000000000000000000003b9aca7b"+
+					"000000000000000000000000000000000000000000000000058d15e1762801c8",
+			),
+			expected: &CollectEvent{
+				Owner:     common.HexToAddress(positionManager),
+				Recipient: common.HexToAddress(positionManager),
+				TickLower: big.NewInt(200000),
+				TickUpper: big.NewInt(202000),
+				Amount0:   big.NewInt(1000000123),
+				Amount1:   bigString("400000000000000456"),
+			},
+		},
+		{
+			name: "flash",
+			log: goldenLog(
+				[]string{flashTopic, routerTopic, routerTopic},
+				"0x000000000000000000000000000000000000000000000000000000e8d4a51000"+
+					"0000000000000000000000000000000000000000000000000000000000000000"+
+					"000000000000000000000000000000000000000000000000000000001dcd6500"+
+					"0000000000000000000000000000000000000000000000000000000000000000",
+			),
+			expected: &FlashEvent{
+				Sender:    common.HexToAddress(universalRouter),
+				Recipient: common.HexToAddress(universalRouter),
+				Amount0:   big.NewInt(1000000000000),
+				Amount1:   big.NewInt(0),
+				Paid0:     big.NewInt(500000000),
+				Paid1:     big.NewInt(0),
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := decodeLog(poolABI, tc.log)
+			assert.NoError(t, err)
+
+			// The raw log is carried along unchanged
+			switch e := tc.expected.(type) {
+			case *SwapEvent:
+				e.Raw = tc.log
+			case *MintEvent:
+				e.Raw = tc.log
+			case *BurnEvent:
+				e.Raw = tc.log
+			case *CollectEvent:
+				e.Raw = tc.log
+			case *FlashEvent:
+				e.Raw = tc.log
+			}
+			// Compared as text, as zero big.Ints may differ internally
+			assert.Equal(t, fmt.Sprintf("%+v", tc.expected), fmt.Sprintf("%+v", event))
+		})
+	}
+}
+
+func TestDecodeLogErrors(t *testing.T) {
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	assert.NoError(t, err)
+
+	_, err = decodeLog(poolABI, goldenLog(nil, "0x"))
+	assert.ErrorIs(t, err, errNoTopics)
+
+	// Events the pool declares but the ingestion does not store
+	cardinality := poolABI.Events["IncreaseObservationCardinalityNext"].ID.Hex()
+	_, err = decodeLog(poolABI, goldenLog([]string{cardinality}, "0x"+strings.Repeat("0", 128)))
+	assert.ErrorIs(t, err, errUnhandledEvent)
+
+	// Unknown signatures, e.g. an ERC-20 Transfer
+	_, err = decodeLog(poolABI, goldenLog([]string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"}, "0x"))
+	assert.Error(t, err)
+
+	// A truncated data section fails to unpack
+	swap := goldenLog([]string{swapTopic, routerTopic, routerTopic}, "0x"+strings.Repeat("0", 64))
+	_, err = decodeLog(poolABI, swap)
+	assert.Error(t, err)
+}
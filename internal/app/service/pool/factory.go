@@ -0,0 +1,220 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"uniswapper/internal/app/constants"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EVENT_POOL_CREATED is emitted by the factory for every new pool
+const EVENT_POOL_CREATED = "PoolCreated"
+
+// PoolCreatedEvent is emitted by the factory when a pool is created
+type PoolCreatedEvent struct {
+	Token0      common.Address
+	Token1      common.Address
+	Fee         *big.Int
+	TickSpacing *big.Int
+	Pool        common.Address
+	Raw         types.Log
+}
+
+// factoryWatch discovers new pools from the factory's PoolCreated events.
+// A pool is tracked when its fee tier and both of its tokens pass the
+// filters; an empty filter accepts everything.
+type factoryWatch struct {
+	address    common.Address
+	factoryABI abi.ABI
+	startBlock uint64
+	tokens     map[common.Address]bool
+	fees       map[uint32]bool
+}
+
+// newFactoryWatch returns nil when factory discovery is disabled
+func newFactoryWatch() (*factoryWatch, error) {
+	cfg := constants.Config.PoolConfig
+	if !cfg.POOL_FACTORY_WATCH {
+		return nil, nil
+	}
+
+	if !common.IsHexAddress(cfg.POOL_FACTORY_ADDRESS) {
+		return nil, fmt.Errorf("invalid factory address %q", cfg.POOL_FACTORY_ADDRESS)
+	}
+
+	factoryABI, err := abi.JSON(strings.NewReader(uniswapV3FactoryABI))
+	if err != nil {
+		return nil, err
+	}
+
+	w := &factoryWatch{
+		address:    common.HexToAddress(cfg.POOL_FACTORY_ADDRESS),
+		factoryABI: factoryABI,
+		startBlock: cfg.POOL_FACTORY_START_BLOCK,
+		tokens:     make(map[common.Address]bool),
+		fees:       make(map[uint32]bool),
+	}
+
+	if cfg.POOL_FACTORY_TOKENS != "" {
+		var tokens []string
+		if err := json.Unmarshal([]byte(cfg.POOL_FACTORY_TOKENS), &tokens); err != nil {
+			return nil, fmt.Errorf("reading token allowlist: %w", err)
+		}
+		for _, token := range tokens {
+			if !common.IsHexAddress(token) {
+				return nil, fmt.Errorf("invalid token address %q", token)
+			}
+			w.tokens[common.HexToAddress(token)] = true
+		}
+	}
+
+	if cfg.POOL_FACTORY_FEES != "" {
+		var fees []uint32
+		if err := json.Unmarshal([]byte(cfg.POOL_FACTORY_FEES), &fees); err != nil {
+			return nil, fmt.Errorf("reading fee tiers: %w", err)
+		}
+		for _, fee := range fees {
+			w.fees[fee] = true
+		}
+	}
+
+	return w, nil
+}
+
+// matches reports whether a created pool passes the discovery filters
+func (w *factoryWatch) matches(event *PoolCreatedEvent) bool {
+	if len(w.fees) > 0 && !w.fees[uint32(event.Fee.Uint64())] {
+		return false
+	}
+	if len(w.tokens) > 0 && (!w.tokens[event.Token0] || !w.tokens[event.Token1]) {
+		return false
+	}
+	return true
+}
+
+// decode unpacks a PoolCreated log, skipping the factory's other events
+func (w *factoryWatch) decode(vLog types.Log) (*PoolCreatedEvent, error) {
+	if len(vLog.Topics) == 0 {
+		return nil, errNoTopics
+	}
+
+	event := w.factoryABI.Events[EVENT_POOL_CREATED]
+	if vLog.Topics[0] != event.ID {
+		return nil, fmt.Errorf("%w: factory event %s", errUnhandledEvent, vLog.Topics[0].String())
+	}
+
+	out := &PoolCreatedEvent{Raw: vLog}
+	if err := unpackLog(w.factoryABI, out, event.Name, vLog); err != nil {
+		return nil, fmt.Errorf("unpack %s: %w", event.Name, err)
+	}
+	return out, nil
+}
+
+// handleFactoryLog records a created pool in the registry and starts
+// ingesting it when it passes the filters
+func (u *UniswapV3Pool) handleFactoryLog(ctx context.Context, vLog types.Log) error {
+	log := logger.Logger(ctx)
+
+	if vLog.Removed {
+		if err := u.removeFactoryLog(ctx, vLog); err != nil {
+			return fmt.Errorf("removing orphaned pool of txn %s: %w", vLog.TxHash.String(), err)
+		}
+		return nil
+	}
+
+	event, err := u.factory.decode(vLog)
+	if err != nil {
+		if errors.Is(err, errUnhandledEvent) {
+			return nil
+		}
+		return fmt.Errorf("decoding factory log %d of txn %s: %w", vLog.Index, vLog.TxHash.String(), err)
+	}
+
+	tracked := u.factory.matches(event)
+
+	pool := posts.Pool{
+		Address:     event.Pool.String(),
+		Token0:      event.Token0.String(),
+		Token1:      event.Token1.String(),
+		Fee:         uint32(event.Fee.Uint64()),
+		TickSpacing: int32(event.TickSpacing.Int64()),
+		TxnId:       vLog.TxHash.String(),
+		BlockNumber: vLog.BlockNumber,
+		BlockHash:   vLog.BlockHash.String(),
+		LogIndex:    vLog.Index,
+		Tracked:     tracked,
+		Source:      posts.POOL_SOURCE_FACTORY,
+	}
+
+	// A pool already in the registry keeps its tracked and paused state
+	created, err := u.RegistryDBClient.StoreFactoryPool(ctx, pool)
+	if err != nil {
+		return fmt.Errorf("storing pool %s: %w", pool.Address, err)
+	}
+
+	if created && tracked && u.pools.add(event.Pool, vLog.BlockNumber) {
+		log.Infof("Discovered pool %s (%s/%s, fee %d) at block %d", pool.Address, pool.Token0, pool.Token1, pool.Fee, vLog.BlockNumber)
+	}
+	return nil
+}
+
+// removeFactoryLog deletes a pool whose creation block was orphaned. The pool
+// stays in the ingestion set; an orphaned address simply emits no logs.
+func (u *UniswapV3Pool) removeFactoryLog(ctx context.Context, vLog types.Log) error {
+	event, err := u.factory.decode(vLog)
+	if err != nil {
+		if errors.Is(err, errUnhandledEvent) {
+			return nil
+		}
+		return err
+	}
+	return u.RegistryDBClient.DeletePool(ctx, event.Pool.String())
+}
+
+// pruneOrphanedPools deletes the pools discovered after number whose creation
+// block is no longer canonical. It runs after the blocks were replayed, so a
+// pool created again in another block has its new creation log by then and
+// keeps its operator flags.
+func (u *UniswapV3Pool) pruneOrphanedPools(ctx context.Context, number uint64) error {
+	log := logger.Logger(ctx)
+
+	if u.factory == nil {
+		return nil
+	}
+
+	pools, err := u.RegistryDBClient.GetFactoryPoolsAfterBlock(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		header, err := u.client.HeaderByNumber(ctx, new(big.Int).SetUint64(pool.BlockNumber))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return fmt.Errorf("fetching block %d: %w", pool.BlockNumber, err)
+		}
+		if err == nil && header.Hash().String() == pool.BlockHash {
+			continue
+		}
+
+		log.Warnf("Removing pool %s created in orphaned block %d", pool.Address, pool.BlockNumber)
+		if err := u.RegistryDBClient.DeletePool(ctx, pool.Address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isFactory reports whether address is the watched factory
+func (u *UniswapV3Pool) isFactory(address common.Address) bool {
+	return u.factory != nil && address == u.factory.address
+}
@@ -0,0 +1,231 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"uniswapper/internal/app/constants"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// The golden PoolCreated log of the USDC/WETH 0.05% pool, laid out word by
+// word as the factory emits it
+const (
+	uniswapV3Factory = "0x1F98431c8aD98523631AE4a59f267346ea31F984"
+	usdc             = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	weth             = "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"
+	wbtc             = "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599"
+
+	poolCreatedTopic = "0x783cca1c0412dd0d695e784568c96da2e9c22ff989357a2e8b1d9b2b4e6b7118"
+	usdcTopic        = "0x000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	wethTopic        = "0x000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+	fee500Topic      = "0x00000000000000000000000000000000000000000000000000000000000001f4"
+	poolCreatedData  = "0x000000000000000000000000000000000000000000000000000000000000000a" +
+		"00000000000000000000000088e6a0c2ddd26feeb64f039a2c41296fcb3f5640"
+)
+
+func poolCreatedLog(block uint64) types.Log {
+	vLog := goldenLog([]string{poolCreatedTopic, usdcTopic, wethTopic, fee500Topic}, poolCreatedData)
+	vLog.Address = common.HexToAddress(uniswapV3Factory)
+	vLog.BlockNumber = block
+	return vLog
+}
+
+func newTestFactoryWatch(t *testing.T, tokens []string, fees []uint32) *factoryWatch {
+	factoryABI, err := abi.JSON(strings.NewReader(uniswapV3FactoryABI))
+	assert.NoError(t, err)
+
+	w := &factoryWatch{
+		address:    common.HexToAddress(uniswapV3Factory),
+		factoryABI: factoryABI,
+		tokens:     make(map[common.Address]bool),
+		fees:       make(map[uint32]bool),
+	}
+	for _, token := range tokens {
+		w.tokens[common.HexToAddress(token)] = true
+	}
+	for _, fee := range fees {
+		w.fees[fee] = true
+	}
+	return w
+}
+
+func TestFactoryDecode(t *testing.T) {
+	w := newTestFactoryWatch(t, nil, nil)
+
+	vLog := poolCreatedLog(12376729)
+	event, err := w.decode(vLog)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%+v", &PoolCreatedEvent{
+		Token0:      common.HexToAddress(usdc),
+		Token1:      common.HexToAddress(weth),
+		Fee:         big.NewInt(500),
+		TickSpacing: big.NewInt(10),
+		Pool:        common.HexToAddress(usdcWethPool),
+		Raw:         vLog,
+	}), fmt.Sprintf("%+v", event))
+
+	_, err = w.decode(goldenLog(nil, "0x"))
+	assert.ErrorIs(t, err, errNoTopics)
+
+	// The factory's other events, e.g. OwnerChanged
+	owner := w.factoryABI.Events["OwnerChanged"].ID.Hex()
+	_, err = w.decode(goldenLog([]string{owner, routerTopic, routerTopic}, "0x"))
+	assert.ErrorIs(t, err, errUnhandledEvent)
+
+	truncated := poolCreatedLog(12376729)
+	truncated.Data = truncated.Data[:32]
+	_, err = w.decode(truncated)
+	assert.Error(t, err)
+}
+
+func TestFactoryWatchMatches(t *testing.T) {
+	usdcWeth := &PoolCreatedEvent{Token0: common.HexToAddress(usdc), Token1: common.HexToAddress(weth), Fee: big.NewInt(500)}
+	wbtcWeth := &PoolCreatedEvent{Token0: common.HexToAddress(wbtc), Token1: common.HexToAddress(weth), Fee: big.NewInt(3000)}
+
+	testCases := []struct {
+		name    string
+		tokens  []string
+		fees    []uint32
+		event   *PoolCreatedEvent
+		matches bool
+	}{
+		{name: "no filters", event: wbtcWeth, matches: true},
+		{name: "allowed tokens", tokens: []string{usdc, weth}, event: usdcWeth, matches: true},
+		{name: "one token not allowed", tokens: []string{usdc, weth}, event: wbtcWeth},
+		{name: "allowed fee", fees: []uint32{500, 10000}, event: usdcWeth, matches: true},
+		{name: "fee not allowed", fees: []uint32{500, 10000}, event: wbtcWeth},
+		{name: "both filters", tokens: []string{wbtc, weth}, fees: []uint32{3000}, event: wbtcWeth, matches: true},
+		{name: "tokens allowed, fee not", tokens: []string{usdc, weth}, fees: []uint32{3000}, event: usdcWeth},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			w := newTestFactoryWatch(t, tc.tokens, tc.fees)
+			assert.Equal(t, tc.matches, w.matches(tc.event))
+		})
+	}
+}
+
+func TestNewFactoryWatch(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name   string
+		tokens string
+		fees   string
+		valid  bool
+	}{
+		{name: "no filters", valid: true},
+		{name: "filters", tokens: fmt.Sprintf(`["%s","%s"]`, usdc, weth), fees: "[500,3000]", valid: true},
+		{name: "invalid token list", tokens: usdc},
+		{name: "invalid token", tokens: `["0x1234"]`},
+		{name: "invalid fee list", fees: `["500"]`},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := constants.Config.PoolConfig
+			defer func() { constants.Config.PoolConfig = cfg }()
+
+			constants.Config.PoolConfig.POOL_FACTORY_WATCH = true
+			constants.Config.PoolConfig.POOL_FACTORY_ADDRESS = uniswapV3Factory
+			constants.Config.PoolConfig.POOL_FACTORY_TOKENS = tc.tokens
+			constants.Config.PoolConfig.POOL_FACTORY_FEES = tc.fees
+
+			w, err := newFactoryWatch()
+			if !tc.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, common.HexToAddress(uniswapV3Factory), w.address)
+			assert.Equal(t, tc.tokens != "", w.tokens[common.HexToAddress(usdc)])
+			assert.Equal(t, tc.fees != "", w.fees[3000])
+		})
+	}
+}
+
+func TestHandleFactoryLog(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name    string
+		fees    []uint32
+		created bool
+		tracked bool
+		// followed is whether the pool joins the ingestion
+		followed bool
+	}{
+		{name: "new matching pool", created: true, tracked: true, followed: true},
+		{name: "new filtered pool", fees: []uint32{3000}, created: true},
+		// Already in the registry, e.g. created again after a reorg
+		{name: "known pool", tracked: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, client := newTestNode(t, 0)
+			u, stores := newTestPool(t, client, 100)
+			u.factory = newTestFactoryWatch(t, nil, tc.fees)
+
+			vLog := poolCreatedLog(12376729)
+			stores.registry.EXPECT().StoreFactoryPool(gomock.Any(), posts.Pool{
+				Address:     common.HexToAddress(usdcWethPool).String(),
+				Token0:      common.HexToAddress(usdc).String(),
+				Token1:      common.HexToAddress(weth).String(),
+				Fee:         500,
+				TickSpacing: 10,
+				TxnId:       vLog.TxHash.String(),
+				BlockNumber: vLog.BlockNumber,
+				BlockHash:   vLog.BlockHash.String(),
+				LogIndex:    vLog.Index,
+				Tracked:     tc.tracked,
+				Source:      posts.POOL_SOURCE_FACTORY,
+			}).Return(tc.created, nil)
+
+			err := u.handleLog(context.Background(), vLog)
+			assert.NoError(t, err)
+
+			start, ok := u.pools.startBlock(common.HexToAddress(usdcWethPool))
+			assert.Equal(t, tc.followed, ok)
+			if tc.followed {
+				assert.Equal(t, vLog.BlockNumber, start)
+			}
+		})
+	}
+}
+
+func TestPruneOrphanedPools(t *testing.T) {
+	setupTest(t)
+
+	node, client := newTestNode(t, 10)
+	u, stores := newTestPool(t, client, 100)
+	u.factory = newTestFactoryWatch(t, nil, nil)
+
+	canonical := posts.Pool{Address: "0x01", BlockNumber: 6, BlockHash: node.Header(6).Hash().String()}
+	orphaned := posts.Pool{Address: "0x02", BlockNumber: 7, BlockHash: common.HexToHash("0xbad").String()}
+	// The new chain is shorter than the one the pool was created in
+	beyondHead := posts.Pool{Address: "0x03", BlockNumber: 12, BlockHash: common.HexToHash("0xbad").String()}
+
+	stores.registry.EXPECT().GetFactoryPoolsAfterBlock(gomock.Any(), uint64(5)).Return([]posts.Pool{canonical, orphaned, beyondHead}, nil)
+	stores.registry.EXPECT().DeletePool(gomock.Any(), orphaned.Address).Return(nil)
+	stores.registry.EXPECT().DeletePool(gomock.Any(), beyondHead.Address).Return(nil)
+
+	err := u.pruneOrphanedPools(context.Background(), 5)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,72 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"uniswapper/internal/app/db/dto/numeric"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/v3math/swapmath"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SwapFees splits the fee charged on the input of a swap, given by the
+// positive amount, between the liquidity providers and the protocol. The
+// protocol takes 1/x of the fee, with x the lower four bits of feeProtocol
+// for token0 and the upper four for token1, or nothing if x is 0.
+func SwapFees(amount0, amount1 *big.Int, feePips uint32, feeProtocol uint8) (*big.Int, *big.Int) {
+	amountIn, share := amount1, feeProtocol>>4
+	if amount0.Sign() > 0 {
+		amountIn, share = amount0, feeProtocol%16
+	}
+	if amountIn.Sign() <= 0 {
+		return new(big.Int), new(big.Int)
+	}
+
+	fee := new(big.Int).Mul(amountIn, big.NewInt(int64(feePips)))
+	fee.Quo(fee, big.NewInt(swapmath.FEE_DENOMINATOR))
+
+	protocolFee := new(big.Int)
+	if share > 0 {
+		protocolFee.Quo(fee, big.NewInt(int64(share)))
+	}
+	return fee, protocolFee
+}
+
+// swapFees computes the fee of a swap and the protocol's share of it. The
+// swap is stored without them when the fee tier or the protocol fee of the
+// pool cannot be resolved.
+func (u *UniswapV3Pool) swapFees(ctx context.Context, e *SwapEvent) (numeric.BigInt, numeric.BigInt) {
+	log := logger.Logger(ctx)
+
+	metadata, err := u.Metadata.GetPoolMetadata(ctx, e.Raw.Address.String())
+	if err != nil {
+		log.Warnf("error while resolving the fee of swap %s of pool %s: %v", e.Raw.TxHash.String(), e.Raw.Address.String(), err)
+		return numeric.BigInt{}, numeric.BigInt{}
+	}
+
+	feeProtocol, err := u.feeProtocol(ctx, e.Raw.Address, e.Raw.BlockNumber)
+	if err != nil {
+		log.Warnf("error while resolving the protocol fee of pool %s: %v", e.Raw.Address.String(), err)
+		return numeric.BigInt{}, numeric.BigInt{}
+	}
+
+	fee, protocolFee := SwapFees(e.Amount0, e.Amount1, metadata.Fee, feeProtocol)
+	return numeric.NewBigInt(fee), numeric.NewBigInt(protocolFee)
+}
+
+// feeProtocol returns the protocol fee setting of a pool. It is read from
+// slot0 at block the first time and then follows SetFeeProtocol events.
+func (u *UniswapV3Pool) feeProtocol(ctx context.Context, address common.Address, block uint64) (uint8, error) {
+	if feeProtocol, ok := u.feeProtocols[address]; ok {
+		return feeProtocol, nil
+	}
+
+	slot0, err := callPool(ctx, u.client, u.poolABI, address, "slot0", new(big.Int).SetUint64(block))
+	if err != nil {
+		return 0, err
+	}
+	feeProtocol := slot0[5].(uint8)
+	u.feeProtocols[address] = feeProtocol
+	return feeProtocol, nil
+}
@@ -0,0 +1,69 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwapFees(t *testing.T) {
+	testCases := []struct {
+		name             string
+		amount0, amount1 int64
+		feePips          uint32
+		feeProtocol      uint8
+		fee, protocolFee int64
+	}{
+		{
+			name:    "token0 in",
+			amount0: 1_000_000, amount1: -990_000,
+			feePips: 3000,
+			fee:     3000,
+		},
+		{
+			name:    "token1 in",
+			amount0: -990_000, amount1: 1_000_000,
+			feePips: 500,
+			fee:     500,
+		},
+		{
+			name:    "rounds down",
+			amount0: 999, amount1: -1,
+			feePips: 3000,
+			fee:     2,
+		},
+		{
+			name:    "protocol fee on token0",
+			amount0: 1_000_000, amount1: -990_000,
+			feePips: 3000, feeProtocol: 4 | 6<<4,
+			fee: 3000, protocolFee: 750,
+		},
+		{
+			name:    "protocol fee on token1",
+			amount0: -990_000, amount1: 1_000_000,
+			feePips: 3000, feeProtocol: 4 | 6<<4,
+			fee: 3000, protocolFee: 500,
+		},
+		{
+			name:    "protocol fee off for the input token",
+			amount0: -990_000, amount1: 1_000_000,
+			feePips: 3000, feeProtocol: 4,
+			fee: 3000,
+		},
+		{
+			name:    "no input",
+			feePips: 3000, feeProtocol: 4 | 4<<4,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			fee, protocolFee := SwapFees(big.NewInt(tc.amount0), big.NewInt(tc.amount1), tc.feePips, tc.feeProtocol)
+			assert.Equal(t, big.NewInt(tc.fee).String(), fee.String())
+			assert.Equal(t, big.NewInt(tc.protocolFee).String(), protocolFee.String())
+		})
+	}
+}
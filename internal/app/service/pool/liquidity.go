@@ -0,0 +1,148 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/liquidity_mock.go uniswapper/internal/app/service/pool ILiquidityService
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrNoLiquidityHistory is returned for pools whose ticks were never seeded
+	ErrNoLiquidityHistory = errors.New("pool has no liquidity history")
+	// ErrBlockBeforeHistory is returned for blocks before the liquidity seed
+	ErrBlockBeforeHistory = errors.New("block is before the liquidity history")
+)
+
+// TickLiquidity is the liquidity of an initialized tick. LiquidityActive is
+// the in-range liquidity from this tick up to the next one.
+type TickLiquidity struct {
+	Tick            int64          `json:"tick"`
+	LiquidityGross  numeric.BigInt `json:"liquidity_gross"`
+	LiquidityNet    numeric.BigInt `json:"liquidity_net"`
+	LiquidityActive numeric.BigInt `json:"liquidity_active"`
+	Price           *Price         `json:"price"`
+}
+
+// LiquidityDistribution is the depth chart of a pool at a block, or after
+// the last stored event if BlockNumber is nil
+type LiquidityDistribution struct {
+	Address     string          `json:"address"`
+	BlockNumber *uint64         `json:"block_number"`
+	SeedBlock   uint64          `json:"seed_block"`
+	Ticks       []TickLiquidity `json:"ticks"`
+}
+
+// ILiquidityService serves the tick liquidity of a pool
+type ILiquidityService interface {
+	GetLiquidity(ctx context.Context, address string, blockNumber *uint64) (*LiquidityDistribution, error)
+}
+
+// LiquidityService rebuilds the tick liquidity of a pool from its seed and
+// the Mint and Burn events stored since
+type LiquidityService struct {
+	LiquidityDBClient pool.ILiquidityRepository
+	Metadata          IPoolMetadataService
+}
+
+func NewLiquidityService(liquidityDBClient pool.ILiquidityRepository, metadata IPoolMetadataService) ILiquidityService {
+	return &LiquidityService{
+		LiquidityDBClient: liquidityDBClient,
+		Metadata:          metadata,
+	}
+}
+
+func (s *LiquidityService) GetLiquidity(ctx context.Context, address string, blockNumber *uint64) (*LiquidityDistribution, error) {
+	log := logger.Logger(ctx)
+	address = common.HexToAddress(address).String()
+
+	seed, err := s.LiquidityDBClient.GetSeed(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if seed == nil {
+		return nil, ErrNoLiquidityHistory
+	}
+
+	toBlock := uint64(math.MaxInt64)
+	if blockNumber != nil {
+		if *blockNumber < seed.BlockNumber {
+			return nil, fmt.Errorf("%w: %d is before block %d", ErrBlockBeforeHistory, *blockNumber, seed.BlockNumber)
+		}
+		toBlock = *blockNumber
+	}
+
+	seedTicks, err := s.LiquidityDBClient.GetSeedTicks(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	deltas, err := s.LiquidityDBClient.GetTickDeltas(ctx, address, seed.BlockNumber, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	ticks := DistributeLiquidity(seedTicks, deltas)
+
+	metadata, err := s.Metadata.GetPoolMetadata(ctx, address)
+	if err != nil {
+		log.Warnf("Error pricing the ticks of pool %s: %v", address, err)
+	} else {
+		for i := range ticks {
+			if ticks[i].Price, err = PriceAtTick(int(ticks[i].Tick), metadata.Token0.Decimals, metadata.Token1.Decimals); err != nil {
+				log.Warnf("Error pricing tick %d of pool %s: %v", ticks[i].Tick, address, err)
+			}
+		}
+	}
+
+	return &LiquidityDistribution{
+		Address:     address,
+		BlockNumber: blockNumber,
+		SeedBlock:   seed.BlockNumber,
+		Ticks:       ticks,
+	}, nil
+}
+
+// DistributeLiquidity adds the liquidity changes to the seeded ticks and
+// returns the initialized ticks in ascending order, with the liquidity
+// active above each. Ticks left without gross liquidity are uninitialized.
+func DistributeLiquidity(seed, deltas []posts.Tick) []TickLiquidity {
+	gross := make(map[int64]*big.Int)
+	net := make(map[int64]*big.Int)
+	for _, tick := range append(append([]posts.Tick{}, seed...), deltas...) {
+		if _, ok := gross[tick.Tick]; !ok {
+			gross[tick.Tick], net[tick.Tick] = new(big.Int), new(big.Int)
+		}
+		gross[tick.Tick].Add(gross[tick.Tick], tick.LiquidityGross.Big())
+		net[tick.Tick].Add(net[tick.Tick], tick.LiquidityNet.Big())
+	}
+
+	ticks := make([]int64, 0, len(gross))
+	for tick, liquidity := range gross {
+		if liquidity.Sign() > 0 {
+			ticks = append(ticks, tick)
+		}
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i] < ticks[j] })
+
+	active := new(big.Int)
+	distribution := make([]TickLiquidity, 0, len(ticks))
+	for _, tick := range ticks {
+		active.Add(active, net[tick])
+		distribution = append(distribution, TickLiquidity{
+			Tick:            tick,
+			LiquidityGross:  numeric.NewBigInt(gross[tick]),
+			LiquidityNet:    numeric.NewBigInt(net[tick]),
+			LiquidityActive: numeric.NewBigInt(active),
+		})
+	}
+	return distribution
+}
@@ -0,0 +1,93 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributeLiquidity(t *testing.T) {
+	tick := func(tick, gross, net int64) posts.Tick {
+		return posts.Tick{
+			Tick:           tick,
+			LiquidityGross: numeric.NewBigInt(big.NewInt(gross)),
+			LiquidityNet:   numeric.NewBigInt(big.NewInt(net)),
+		}
+	}
+	type expected struct {
+		tick               int64
+		gross, net, active int64
+	}
+
+	testCases := []struct {
+		name     string
+		seed     []posts.Tick
+		deltas   []posts.Tick
+		expected []expected
+	}{
+		{
+			name:     "empty",
+			expected: []expected{},
+		},
+		{
+			name: "seed only",
+			seed: []posts.Tick{tick(60, 100, -100), tick(-60, 100, 100)},
+			expected: []expected{
+				{tick: -60, gross: 100, net: 100, active: 100},
+				{tick: 60, gross: 100, net: -100, active: 0},
+			},
+		},
+		{
+			name: "overlapping mint on an empty seed",
+			deltas: []posts.Tick{
+				tick(-120, 50, 50), tick(0, 50, -50),
+				tick(-60, 20, 20), tick(60, 20, -20),
+			},
+			expected: []expected{
+				{tick: -120, gross: 50, net: 50, active: 50},
+				{tick: -60, gross: 20, net: 20, active: 70},
+				{tick: 0, gross: 50, net: -50, active: 20},
+				{tick: 60, gross: 20, net: -20, active: 0},
+			},
+		},
+		{
+			name:   "burn uninitializes ticks",
+			seed:   []posts.Tick{tick(-60, 100, 100), tick(0, 30, 30), tick(60, 130, -130)},
+			deltas: []posts.Tick{tick(0, -30, -30), tick(60, -30, 30)},
+			expected: []expected{
+				{tick: -60, gross: 100, net: 100, active: 100},
+				{tick: 60, gross: 100, net: -100, active: 0},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			distribution := DistributeLiquidity(tc.seed, tc.deltas)
+
+			actual := make([]expected, 0, len(distribution))
+			for _, tick := range distribution {
+				actual = append(actual, expected{
+					tick:   tick.Tick,
+					gross:  tick.LiquidityGross.Int64(),
+					net:    tick.LiquidityNet.Int64(),
+					active: tick.LiquidityActive.Int64(),
+				})
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestTickWord(t *testing.T) {
+	assert.Equal(t, 0, tickWord(0, 60))
+	assert.Equal(t, 0, tickWord(255*60, 60))
+	assert.Equal(t, 1, tickWord(256*60, 60))
+	assert.Equal(t, -1, tickWord(-1, 60))
+	assert.Equal(t, -1, tickWord(-60, 60))
+	assert.Equal(t, -3466, tickWord(-887272, 1))
+	assert.Equal(t, 3465, tickWord(887272, 1))
+}
@@ -0,0 +1,611 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/rpc"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PositionLiquidityEvent is emitted by the position manager when liquidity
+// is added to or removed from a position, with the token amounts moved
+type PositionLiquidityEvent struct {
+	TokenId   *big.Int
+	Liquidity *big.Int
+	Amount0   *big.Int
+	Amount1   *big.Int
+	Raw       types.Log
+}
+
+// PositionCollectEvent is emitted when tokens owed to a position are collected
+type PositionCollectEvent struct {
+	TokenId   *big.Int
+	Recipient common.Address
+	Amount0   *big.Int
+	Amount1   *big.Int
+	Raw       types.Log
+}
+
+// PositionTransferEvent is emitted when a position NFT is minted, moved or
+// burned, from or to the zero address for mints and burns
+type PositionTransferEvent struct {
+	From    common.Address
+	To      common.Address
+	TokenId *big.Int
+	Raw     types.Log
+}
+
+type IPositionTracker interface {
+	RunPositionTracker(ctx context.Context)
+}
+
+// PositionTracker indexes the NonfungiblePositionManager events of the
+// configured token IDs and of every position held by the configured owners.
+// It only follows confirmed blocks, so it has no reorgs to handle. The state
+// of each position touched by a range of blocks is read at its last block.
+type PositionTracker struct {
+	client             *rpc.Client
+	manager            common.Address
+	managerABI         abi.ABI
+	factory            common.Address
+	factoryABI         abi.ABI
+	owners             []common.Address
+	configured         []*big.Int
+	tracked            map[string]*big.Int
+	unread             map[string]*big.Int
+	started            bool
+	startBlock         uint64
+	confirmations      uint64
+	chunkSize          uint64
+	pollInterval       time.Duration
+	blockTimes         *blockTimeCache
+	PositionDBClient   pool.IPositionRepository
+	CheckpointDBClient pool.ICheckpointRepository
+}
+
+func NewPositionTracker(
+	ctx context.Context,
+	rpcClient *rpc.Client,
+	positionDBClient pool.IPositionRepository,
+	checkpointDBClient pool.ICheckpointRepository,
+) IPositionTracker {
+	log := logger.Logger(ctx)
+	cfg := constants.Config.PositionConfig
+
+	if !common.IsHexAddress(cfg.POSITION_MANAGER_ADDRESS) {
+		log.Fatalf("Invalid position manager address %q", cfg.POSITION_MANAGER_ADDRESS)
+	}
+
+	managerABI, err := abi.JSON(strings.NewReader(nonfungiblePositionManagerABI))
+	if err != nil {
+		log.Fatalf("Failed to parse position manager ABI: %v", err)
+	}
+
+	factoryABI, err := abi.JSON(strings.NewReader(uniswapV3FactoryABI))
+	if err != nil {
+		log.Fatalf("Failed to parse factory ABI: %v", err)
+	}
+
+	var owners []common.Address
+	if cfg.POSITION_OWNERS != "" {
+		var addresses []string
+		if err := json.Unmarshal([]byte(cfg.POSITION_OWNERS), &addresses); err != nil {
+			log.Fatalf("Error while reading position owners: %v", err)
+		}
+		for _, address := range addresses {
+			if !common.IsHexAddress(address) {
+				log.Fatalf("Invalid position owner %q", address)
+			}
+			owners = append(owners, common.HexToAddress(address))
+		}
+	}
+
+	var configured []*big.Int
+	if cfg.POSITION_TOKEN_IDS != "" {
+		var tokenIDs []json.Number
+		if err := json.Unmarshal([]byte(cfg.POSITION_TOKEN_IDS), &tokenIDs); err != nil {
+			log.Fatalf("Error while reading position token IDs: %v", err)
+		}
+		for _, tokenID := range tokenIDs {
+			id, ok := new(big.Int).SetString(tokenID.String(), 10)
+			if !ok || id.Sign() < 0 {
+				log.Fatalf("Invalid position token ID %q", tokenID)
+			}
+			configured = append(configured, id)
+		}
+	}
+
+	pollInterval := time.Duration(constants.Config.PoolConfig.POOL_POLL_INTERVAL) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	chunkSize := constants.Config.PoolConfig.POOL_BACKFILL_CHUNK_SIZE
+	if chunkSize == 0 {
+		chunkSize = defaultBackfillChunkSize
+	}
+
+	return &PositionTracker{
+		client:             rpcClient,
+		manager:            common.HexToAddress(cfg.POSITION_MANAGER_ADDRESS),
+		managerABI:         managerABI,
+		factory:            common.HexToAddress(constants.Config.PoolConfig.POOL_FACTORY_ADDRESS),
+		factoryABI:         factoryABI,
+		owners:             owners,
+		configured:         configured,
+		tracked:            make(map[string]*big.Int),
+		unread:             make(map[string]*big.Int),
+		startBlock:         cfg.POSITION_START_BLOCK,
+		confirmations:      constants.Config.PoolConfig.POOL_CONFIRMATIONS,
+		chunkSize:          chunkSize,
+		pollInterval:       pollInterval,
+		blockTimes:         newBlockTimeCache(rpcClient),
+		PositionDBClient:   positionDBClient,
+		CheckpointDBClient: checkpointDBClient,
+	}
+}
+
+// RunPositionTracker syncs the tracked positions with the confirmed blocks
+// on every poll. Failed syncs are retried on the next poll.
+func (u *PositionTracker) RunPositionTracker(ctx context.Context) {
+	log := logger.Logger(ctx)
+
+	if len(u.owners) == 0 && len(u.configured) == 0 {
+		log.Info("Position tracking is disabled, no owners or token IDs are configured")
+		return
+	}
+
+	log.Infof("Tracking the positions of %d owners and %d token IDs", len(u.owners), len(u.configured))
+
+	ticker := time.NewTicker(u.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := u.sync(ctx); err != nil && ctx.Err() == nil {
+			log.Errorf("error while syncing positions: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync processes the confirmed blocks since the checkpoint of the position
+// manager, advancing it after each range
+func (u *PositionTracker) sync(ctx context.Context) error {
+	head, err := u.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching block number: %w", err)
+	}
+	if head < u.confirmations {
+		return nil
+	}
+	safe := head - u.confirmations
+
+	last, err := u.checkpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !u.started {
+		if err := u.start(ctx, last, safe); err != nil {
+			return err
+		}
+		u.started = true
+	}
+
+	for last < safe {
+		to := last + u.chunkSize
+		if to > safe {
+			to = safe
+		}
+
+		if err := u.process(ctx, last+1, to); err != nil {
+			return fmt.Errorf("processing blocks %d-%d: %w", last+1, to, err)
+		}
+		if err := u.CheckpointDBClient.StoreCheckpoint(ctx, u.manager.String(), to); err != nil {
+			return fmt.Errorf("storing checkpoint %d: %w", to, err)
+		}
+		last = to
+	}
+	return nil
+}
+
+// checkpoint returns the last block processed, or the block before the
+// start block on the first run
+func (u *PositionTracker) checkpoint(ctx context.Context) (uint64, error) {
+	checkpoint, err := u.CheckpointDBClient.GetCheckpoint(ctx, u.manager.String())
+	if err != nil {
+		return 0, fmt.Errorf("loading checkpoint: %w", err)
+	}
+	if checkpoint != nil {
+		return checkpoint.BlockNumber, nil
+	}
+	if u.startBlock == 0 {
+		return 0, nil
+	}
+	return u.startBlock - 1, nil
+}
+
+// start tracks the stored positions, the configured token IDs and the
+// positions currently held by the owners. Positions seen for the first time
+// get their history replayed up to the checkpoint.
+func (u *PositionTracker) start(ctx context.Context, last, safe uint64) error {
+	positions, err := u.PositionDBClient.GetPositions(ctx, "")
+	if err != nil {
+		return fmt.Errorf("loading positions: %w", err)
+	}
+	for _, position := range positions {
+		id := position.TokenId.Big()
+		u.tracked[id.String()] = id
+	}
+
+	candidates := append([]*big.Int{}, u.configured...)
+	for _, owner := range u.owners {
+		held, err := u.heldBy(ctx, owner, safe)
+		if err != nil {
+			return fmt.Errorf("listing the positions of %s: %w", owner.String(), err)
+		}
+		candidates = append(candidates, held...)
+	}
+
+	return u.track(ctx, candidates, last)
+}
+
+// heldBy enumerates the position NFTs of an owner at block
+func (u *PositionTracker) heldBy(ctx context.Context, owner common.Address, block uint64) ([]*big.Int, error) {
+	blockNumber := new(big.Int).SetUint64(block)
+
+	out, err := callContract(ctx, u.client, u.managerABI, u.manager, "balanceOf", blockNumber, owner)
+	if err != nil {
+		return nil, err
+	}
+	balance := out[0].(*big.Int)
+
+	var tokenIDs []*big.Int
+	for i := int64(0); i < balance.Int64(); i++ {
+		out, err := callContract(ctx, u.client, u.managerABI, u.manager, "tokenOfOwnerByIndex", blockNumber, owner, big.NewInt(i))
+		if err != nil {
+			return nil, err
+		}
+		tokenIDs = append(tokenIDs, out[0].(*big.Int))
+	}
+	return tokenIDs, nil
+}
+
+// track replays the events of the positions not tracked yet up to block
+// last and starts following them. Their state is read at the end of the next
+// range processed.
+func (u *PositionTracker) track(ctx context.Context, tokenIDs []*big.Int, last uint64) error {
+	log := logger.Logger(ctx)
+
+	added := make(map[string]*big.Int)
+	for _, id := range tokenIDs {
+		if _, ok := u.tracked[id.String()]; !ok {
+			added[id.String()] = id
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	if last >= u.startBlock {
+		log.Infof("Replaying the history of %d new positions from block %d to %d", len(added), u.startBlock, last)
+		if err := u.index(ctx, added, u.startBlock, last); err != nil {
+			return err
+		}
+	}
+
+	for key, id := range added {
+		u.tracked[key] = id
+		u.unread[key] = id
+	}
+	return nil
+}
+
+// process stores the events of [from, to], after tracking the positions
+// that the owners received within it
+func (u *PositionTracker) process(ctx context.Context, from, to uint64) error {
+	if len(u.owners) > 0 {
+		received, minted, err := u.received(ctx, from, to)
+		if err != nil {
+			return err
+		}
+		if err := u.track(ctx, received, from-1); err != nil {
+			return err
+		}
+		// Positions minted within the range have no earlier history
+		for _, id := range minted {
+			u.tracked[id.String()] = id
+		}
+	}
+
+	if len(u.tracked) > 0 {
+		if err := u.index(ctx, u.tracked, from, to); err != nil {
+			return err
+		}
+	}
+
+	// Newly tracked positions are read even without events in the range
+	for key, id := range u.unread {
+		if err := u.refresh(ctx, id, to); err != nil {
+			return fmt.Errorf("reading position %s: %w", key, err)
+		}
+		delete(u.unread, key)
+	}
+	return nil
+}
+
+// received returns the positions transferred to or from an owner in
+// [from, to], apart from those minted within it
+func (u *PositionTracker) received(ctx context.Context, from, to uint64) ([]*big.Int, []*big.Int, error) {
+	transfer := u.managerABI.Events[posts.POSITION_EVENT_TRANSFER].ID
+
+	owners := make([]common.Hash, 0, len(u.owners))
+	for _, owner := range u.owners {
+		owners = append(owners, common.BytesToHash(owner.Bytes()))
+	}
+
+	var received, minted []*big.Int
+	for _, topics := range [][][]common.Hash{
+		{{transfer}, owners},
+		{{transfer}, nil, owners},
+	} {
+		logs, err := u.filterLogs(ctx, from, to, topics)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, vLog := range logs {
+			if len(vLog.Topics) != 4 {
+				continue
+			}
+			if vLog.Topics[1] == (common.Hash{}) {
+				minted = append(minted, vLog.Topics[3].Big())
+			} else {
+				received = append(received, vLog.Topics[3].Big())
+			}
+		}
+	}
+	return received, minted, nil
+}
+
+// index stores the events of the given positions in [from, to] and then
+// refreshes the state of those touched at block to
+func (u *PositionTracker) index(ctx context.Context, tokenIDs map[string]*big.Int, from, to uint64) error {
+	log := logger.Logger(ctx)
+
+	ids := make([]common.Hash, 0, len(tokenIDs))
+	for _, id := range tokenIDs {
+		ids = append(ids, common.BigToHash(id))
+	}
+
+	events := u.managerABI.Events
+	var logs []types.Log
+	for _, topics := range [][][]common.Hash{
+		{{
+			events[posts.POSITION_EVENT_INCREASE_LIQUIDITY].ID,
+			events[posts.POSITION_EVENT_DECREASE_LIQUIDITY].ID,
+			events[posts.POSITION_EVENT_COLLECT].ID,
+		}, ids},
+		{{events[posts.POSITION_EVENT_TRANSFER].ID}, nil, nil, ids},
+	} {
+		found, err := u.filterLogs(ctx, from, to, topics)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, found...)
+	}
+
+	touched := make(map[string]*big.Int)
+	for _, vLog := range logs {
+		// The range is retried rather than skipping the event, as the
+		// checkpoint would move past it
+		event, err := u.decode(ctx, vLog)
+		if err != nil {
+			return fmt.Errorf("decoding position log %d of txn %s: %w", vLog.Index, vLog.TxHash.String(), err)
+		}
+		if err := u.PositionDBClient.StorePositionEvent(ctx, *event); err != nil {
+			return fmt.Errorf("storing %s event of position %s: %w", event.Event, event.TokenId.String(), err)
+		}
+		touched[event.TokenId.String()] = event.TokenId.Big()
+	}
+
+	for _, id := range touched {
+		if err := u.refresh(ctx, id, to); err != nil {
+			return fmt.Errorf("reading position %s: %w", id.String(), err)
+		}
+	}
+
+	if len(logs) > 0 {
+		log.Infof("Indexed %d events of %d positions in blocks %d-%d", len(logs), len(touched), from, to)
+	}
+	return nil
+}
+
+// filterLogs fetches the position manager logs matching topics in [from, to],
+// halving the range of a query that the provider rejects
+func (u *PositionTracker) filterLogs(ctx context.Context, from, to uint64, topics [][]common.Hash) ([]types.Log, error) {
+	log := logger.Logger(ctx)
+
+	var logs []types.Log
+	chunk := u.chunkSize
+	for from <= to {
+		end := from + chunk - 1
+		if end > to || end < from {
+			end = to
+		}
+
+		found, err := u.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{u.manager},
+			Topics:    topics,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if chunk == 1 {
+				return nil, fmt.Errorf("fetching logs of block %d: %w", from, err)
+			}
+			chunk /= 2
+			log.Warnf("Splitting position log range %d-%d to %d blocks: %v", from, end, chunk, err)
+			continue
+		}
+
+		logs = append(logs, found...)
+		from = end + 1
+	}
+	return logs, nil
+}
+
+// decode unpacks a position manager log into the stored event
+func (u *PositionTracker) decode(ctx context.Context, vLog types.Log) (*posts.PositionEvent, error) {
+	if len(vLog.Topics) == 0 {
+		return nil, errNoTopics
+	}
+
+	abiEvent, err := u.managerABI.EventByID(vLog.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	blockTime, err := u.blockTimes.get(ctx, vLog.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &posts.PositionEvent{
+		Event:          abiEvent.Name,
+		TxnId:          vLog.TxHash.String(),
+		BlockNumber:    vLog.BlockNumber,
+		BlockHash:      vLog.BlockHash.String(),
+		LogIndex:       vLog.Index,
+		BlockTimestamp: blockTime,
+	}
+
+	switch abiEvent.Name {
+	case posts.POSITION_EVENT_INCREASE_LIQUIDITY, posts.POSITION_EVENT_DECREASE_LIQUIDITY:
+		out := &PositionLiquidityEvent{Raw: vLog}
+		if err := unpackLog(u.managerABI, out, abiEvent.Name, vLog); err != nil {
+			return nil, fmt.Errorf("unpack %s: %w", abiEvent.Name, err)
+		}
+		event.TokenId = numeric.NewBigInt(out.TokenId)
+		event.Liquidity = numeric.NewBigInt(out.Liquidity)
+		event.Amount0 = numeric.NewBigInt(out.Amount0)
+		event.Amount1 = numeric.NewBigInt(out.Amount1)
+	case posts.POSITION_EVENT_COLLECT:
+		out := &PositionCollectEvent{Raw: vLog}
+		if err := unpackLog(u.managerABI, out, abiEvent.Name, vLog); err != nil {
+			return nil, fmt.Errorf("unpack %s: %w", abiEvent.Name, err)
+		}
+		recipient := out.Recipient.String()
+		event.TokenId = numeric.NewBigInt(out.TokenId)
+		event.Recipient = &recipient
+		event.Amount0 = numeric.NewBigInt(out.Amount0)
+		event.Amount1 = numeric.NewBigInt(out.Amount1)
+	case posts.POSITION_EVENT_TRANSFER:
+		out := &PositionTransferEvent{Raw: vLog}
+		if err := unpackLog(u.managerABI, out, abiEvent.Name, vLog); err != nil {
+			return nil, fmt.Errorf("unpack %s: %w", abiEvent.Name, err)
+		}
+		sender, recipient := out.From.String(), out.To.String()
+		event.TokenId = numeric.NewBigInt(out.TokenId)
+		event.Sender, event.Recipient = &sender, &recipient
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnhandledEvent, abiEvent.Name)
+	}
+	return event, nil
+}
+
+// invalidTokenID is the revert reason of the position manager for a
+// position that does not exist
+const invalidTokenID = "Invalid token ID"
+
+// isBurned reports whether a call to positions() reverted because the
+// position was burned
+func isBurned(err error) bool {
+	return rpc.IsRevert(err) && rpc.RevertReason(err) == invalidTokenID
+}
+
+// refresh reads the state of a position at block. A burned position no
+// longer exists in the manager and keeps its last state with no owner and
+// no liquidity.
+func (u *PositionTracker) refresh(ctx context.Context, tokenID *big.Int, block uint64) error {
+	log := logger.Logger(ctx)
+	blockNumber := new(big.Int).SetUint64(block)
+
+	stored, err := u.PositionDBClient.GetPosition(ctx, tokenID.String())
+	if err != nil {
+		return err
+	}
+
+	out, err := callContract(ctx, u.client, u.managerABI, u.manager, "positions", blockNumber, tokenID)
+	if err != nil {
+		// Any other failure is retried, as the stored state would be lost
+		if !isBurned(err) {
+			return err
+		}
+		if stored == nil {
+			log.Warnf("Position %s does not exist at block %d: %v", tokenID.String(), block, err)
+			return nil
+		}
+		stored.Owner = common.Address{}.String()
+		stored.Liquidity = numeric.NewBigInt(new(big.Int))
+		stored.TokensOwed0 = numeric.NewBigInt(new(big.Int))
+		stored.TokensOwed1 = numeric.NewBigInt(new(big.Int))
+		stored.BlockNumber = block
+		return u.PositionDBClient.StorePosition(ctx, *stored)
+	}
+
+	owner, err := callContract(ctx, u.client, u.managerABI, u.manager, "ownerOf", blockNumber, tokenID)
+	if err != nil {
+		return err
+	}
+
+	token0, token1, fee := out[2].(common.Address), out[3].(common.Address), out[4].(*big.Int)
+
+	var poolAddress string
+	if stored != nil {
+		poolAddress = stored.PoolAddress
+	} else {
+		address, err := callContract(ctx, u.client, u.factoryABI, u.factory, "getPool", blockNumber, token0, token1, fee)
+		if err != nil {
+			return err
+		}
+		poolAddress = address[0].(common.Address).String()
+	}
+
+	return u.PositionDBClient.StorePosition(ctx, posts.Position{
+		TokenId:                  numeric.NewBigInt(tokenID),
+		Owner:                    owner[0].(common.Address).String(),
+		PoolAddress:              poolAddress,
+		Token0:                   token0.String(),
+		Token1:                   token1.String(),
+		Fee:                      uint32(fee.Uint64()),
+		TickLower:                int32(out[5].(*big.Int).Int64()),
+		TickUpper:                int32(out[6].(*big.Int).Int64()),
+		Liquidity:                numeric.NewBigInt(out[7].(*big.Int)),
+		FeeGrowthInside0LastX128: numeric.NewBigInt(out[8].(*big.Int)),
+		FeeGrowthInside1LastX128: numeric.NewBigInt(out[9].(*big.Int)),
+		TokensOwed0:              numeric.NewBigInt(out[10].(*big.Int)),
+		TokensOwed1:              numeric.NewBigInt(out[11].(*big.Int)),
+		BlockNumber:              block,
+	})
+}
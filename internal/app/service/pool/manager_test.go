@@ -0,0 +1,169 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/util/testutils/ethnode"
+	mockDB "uniswapper/internal/app/service/util/testutils/mocks/repository/pool"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionSyncFailedLog(t *testing.T) {
+	setupTest(t)
+
+	managerABI, err := abi.JSON(strings.NewReader(nonfungiblePositionManagerABI))
+	assert.NoError(t, err)
+	manager := common.HexToAddress(positionManager)
+	tokenID := big.NewInt(1)
+
+	// IncreaseLiquidity of token 1 in block 5, with its data cut short when
+	// the test needs it
+	increase := func(data string) types.Log {
+		return types.Log{
+			Address:     manager,
+			Topics:      []common.Hash{managerABI.Events[posts.POSITION_EVENT_INCREASE_LIQUIDITY].ID, common.BigToHash(tokenID)},
+			Data:        common.FromHex(data),
+			BlockNumber: 5,
+			TxHash:      common.HexToHash("0x01"),
+		}
+	}
+	valid := "0x" + strings.Repeat("0", 62) + "64" + strings.Repeat("0", 62) + "0a" + strings.Repeat("0", 62) + "0a"
+
+	testCases := []struct {
+		name  string
+		log   types.Log
+		setup func(node *ethnode.Node)
+		err   string
+	}{
+		{
+			name: "undecodable log",
+			log:  increase("0x" + strings.Repeat("0", 64)),
+			err:  "unpack IncreaseLiquidity",
+		},
+		{
+			// The node falls behind between the log query and the block
+			// time lookup
+			name: "block time unavailable",
+			log:  increase(valid),
+			setup: func(node *ethnode.Node) {
+				node.LogsError = func(from, to uint64) error {
+					node.SetLag(10)
+					return nil
+				}
+			},
+			err: "not found",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestNode(t, 10)
+			node.AddLog(tc.log)
+			if tc.setup != nil {
+				tc.setup(node)
+			}
+
+			ctrl := gomock.NewController(t)
+			positions := mockDB.NewMockIPositionRepository(ctrl)
+			checkpoints := mockDB.NewMockICheckpointRepository(ctrl)
+
+			u := &PositionTracker{
+				client:             client,
+				manager:            manager,
+				managerABI:         managerABI,
+				tracked:            map[string]*big.Int{tokenID.String(): tokenID},
+				unread:             make(map[string]*big.Int),
+				started:            true,
+				startBlock:         1,
+				chunkSize:          100,
+				blockTimes:         newBlockTimeCache(client),
+				PositionDBClient:   positions,
+				CheckpointDBClient: checkpoints,
+			}
+
+			// No event is stored and the checkpoint stays before the log
+			checkpoints.EXPECT().GetCheckpoint(gomock.Any(), manager.String()).Return(&posts.Checkpoint{PoolAddress: manager.String(), BlockNumber: 2}, nil)
+
+			err := u.sync(context.Background())
+			assert.ErrorContains(t, err, "processing blocks 3-10")
+			assert.ErrorContains(t, err, "decoding position log")
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestPositionRefreshFailedCall(t *testing.T) {
+	setupTest(t)
+
+	managerABI, err := abi.JSON(strings.NewReader(nonfungiblePositionManagerABI))
+	assert.NoError(t, err)
+	manager := common.HexToAddress(positionManager)
+	tokenID := big.NewInt(1)
+
+	testCases := []struct {
+		name   string
+		err    error
+		burned bool
+	}{
+		{name: "burned", err: ethnode.Revert("Invalid token ID"), burned: true},
+		{name: "other revert", err: ethnode.Revert("")},
+		{name: "rate limited", err: &ethnode.Error{Code: -32005, Message: "limit exceeded"}},
+		{name: "pruned state", err: &ethnode.Error{Code: -32000, Message: "missing trie node"}},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestNode(t, 10)
+			node.Call = func(common.Address, []byte, uint64) ([]byte, error) {
+				return nil, tc.err
+			}
+
+			ctrl := gomock.NewController(t)
+			positions := mockDB.NewMockIPositionRepository(ctrl)
+			u := &PositionTracker{
+				client:           client,
+				manager:          manager,
+				managerABI:       managerABI,
+				PositionDBClient: positions,
+			}
+
+			stored := &posts.Position{
+				TokenId:     numeric.NewBigInt(tokenID),
+				Owner:       common.HexToAddress(usdc).String(),
+				PoolAddress: common.HexToAddress(usdcWethPool).String(),
+				Liquidity:   numeric.NewBigInt(big.NewInt(1000)),
+				BlockNumber: 5,
+			}
+			positions.EXPECT().GetPosition(gomock.Any(), "1").Return(stored, nil)
+			// Only a burned position loses its owner and liquidity
+			if tc.burned {
+				positions.EXPECT().StorePosition(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, position posts.Position) error {
+					assert.Equal(t, common.Address{}.String(), position.Owner)
+					assert.Equal(t, "0", position.Liquidity.String())
+					assert.Equal(t, uint64(10), position.BlockNumber)
+					return nil
+				})
+			}
+
+			err := u.refresh(context.Background(), tokenID, 10)
+			if tc.burned {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
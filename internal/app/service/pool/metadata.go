@@ -0,0 +1,210 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/metadata_mock.go uniswapper/internal/app/service/pool IPoolMetadataService
+package pool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/rpc"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PoolMetadata describes the immutable parameters of a pool and its tokens
+type PoolMetadata struct {
+	Address     string      `json:"address"`
+	Token0      posts.Token `json:"token0"`
+	Token1      posts.Token `json:"token1"`
+	Fee         uint32      `json:"fee"`
+	TickSpacing int32       `json:"tick_spacing"`
+}
+
+// IPoolMetadataService resolves pool and token metadata
+type IPoolMetadataService interface {
+	GetPoolMetadata(ctx context.Context, address string) (*PoolMetadata, error)
+	GetToken(ctx context.Context, address string) (*posts.Token, error)
+}
+
+// PoolMetadataService resolves metadata through eth_call once, then serves
+// it from the database and an in-memory cache since it never changes
+type PoolMetadataService struct {
+	client        *rpc.Client
+	erc20ABI      abi.ABI
+	mu            sync.RWMutex
+	pools         map[common.Address]*PoolMetadata
+	tokens        map[common.Address]*posts.Token
+	Registry      IPoolRegistry
+	TokenDBClient pool.ITokenRepository
+}
+
+func NewPoolMetadataService(
+	ctx context.Context,
+	rpcClient *rpc.Client,
+	registry IPoolRegistry,
+	tokenDBClient pool.ITokenRepository,
+) IPoolMetadataService {
+	log := logger.Logger(ctx)
+
+	erc20, err := abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		log.Fatalf("Failed to parse ERC-20 ABI: %v", err)
+	}
+
+	return &PoolMetadataService{
+		client:        rpcClient,
+		erc20ABI:      erc20,
+		pools:         make(map[common.Address]*PoolMetadata),
+		tokens:        make(map[common.Address]*posts.Token),
+		Registry:      registry,
+		TokenDBClient: tokenDBClient,
+	}
+}
+
+func (m *PoolMetadataService) GetPoolMetadata(ctx context.Context, address string) (*PoolMetadata, error) {
+	addr := common.HexToAddress(address)
+
+	m.mu.RLock()
+	cached, ok := m.pools[addr]
+	m.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	p, err := m.Registry.GetPool(ctx, addr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	token0, err := m.GetToken(ctx, p.Token0)
+	if err != nil {
+		return nil, err
+	}
+	token1, err := m.GetToken(ctx, p.Token1)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &PoolMetadata{
+		Address:     p.Address,
+		Token0:      *token0,
+		Token1:      *token1,
+		Fee:         p.Fee,
+		TickSpacing: p.TickSpacing,
+	}
+
+	m.mu.Lock()
+	m.pools[addr] = metadata
+	m.mu.Unlock()
+
+	return metadata, nil
+}
+
+func (m *PoolMetadataService) GetToken(ctx context.Context, address string) (*posts.Token, error) {
+	addr := common.HexToAddress(address)
+
+	m.mu.RLock()
+	cached, ok := m.tokens[addr]
+	m.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	token, err := m.TokenDBClient.GetToken(ctx, addr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil {
+		if token, err = m.resolveToken(ctx, addr); err != nil {
+			return nil, err
+		}
+		if err := m.TokenDBClient.StoreToken(ctx, *token); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	m.tokens[addr] = token
+	m.mu.Unlock()
+
+	return token, nil
+}
+
+// resolveToken reads the ERC-20 metadata of a token. The metadata functions
+// are optional in ERC-20, so a call that reverts or returns nothing yields an
+// empty value. Any other failure is returned, so that the token is resolved
+// again rather than stored without its metadata.
+func (m *PoolMetadataService) resolveToken(ctx context.Context, address common.Address) (*posts.Token, error) {
+	symbol, err := m.callString(ctx, address, "symbol")
+	if err != nil {
+		return nil, err
+	}
+	name, err := m.callString(ctx, address, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	var decimals uint8
+	output, err := m.call(ctx, address, "decimals")
+	if err != nil {
+		return nil, err
+	}
+	if len(output) > 0 {
+		out, err := m.erc20ABI.Unpack("decimals", output)
+		if err != nil {
+			return nil, fmt.Errorf("decoding decimals of %s: %w", address.String(), err)
+		}
+		decimals = out[0].(uint8)
+	}
+
+	return &posts.Token{
+		Address:  address.String(),
+		Symbol:   symbol,
+		Name:     name,
+		Decimals: decimals,
+	}, nil
+}
+
+// callString reads a string function that some tokens implement as bytes32
+func (m *PoolMetadataService) callString(ctx context.Context, address common.Address, method string) (string, error) {
+	output, err := m.call(ctx, address, method)
+	if err != nil || len(output) == 0 {
+		return "", err
+	}
+
+	// A bytes32 result is a single word, a string is at least an offset and a length
+	if len(output) == 32 {
+		return string(bytes.TrimRight(output, "\x00")), nil
+	}
+
+	out, err := m.erc20ABI.Unpack(method, output)
+	if err != nil {
+		return "", fmt.Errorf("decoding %s of %s: %w", method, address.String(), err)
+	}
+	return out[0].(string), nil
+}
+
+// call executes a function without arguments, returning no output if it
+// reverted and the error of any other failed call
+func (m *PoolMetadataService) call(ctx context.Context, address common.Address, method string) ([]byte, error) {
+	input, err := m.erc20ABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := m.client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: input}, nil)
+	if err != nil {
+		if rpc.IsRevert(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return output, nil
+}
@@ -0,0 +1,149 @@
+package pool
+
+import (
+	"context"
+	"strings"
+	"testing"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/util/testutils/ethnode"
+	mockDB "uniswapper/internal/app/service/util/testutils/mocks/repository/pool"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// bytes32 pads a string to a single word, like the metadata of early tokens
+func bytes32(s string) []byte {
+	return common.RightPadBytes([]byte(s), 32)
+}
+
+func TestResolveToken(t *testing.T) {
+	setupTest(t)
+
+	erc20, err := abi.JSON(strings.NewReader(erc20ABI))
+	assert.NoError(t, err)
+
+	pack := func(method string, value interface{}) []byte {
+		output, err := erc20.Methods[method].Outputs.Pack(value)
+		assert.NoError(t, err)
+		return output
+	}
+	rateLimited := &ethnode.Error{Code: -32005, Message: "limit exceeded"}
+
+	testCases := []struct {
+		name string
+		// results answers each method with its output or error
+		results  map[string]interface{}
+		expected *posts.Token
+		err      bool
+	}{
+		{
+			name: "string metadata",
+			results: map[string]interface{}{
+				"symbol":   pack("symbol", "USDC"),
+				"name":     pack("name", "USD Coin"),
+				"decimals": pack("decimals", uint8(6)),
+			},
+			expected: &posts.Token{Symbol: "USDC", Name: "USD Coin", Decimals: 6},
+		},
+		{
+			// MKR returns bytes32 from symbol() and name()
+			name: "bytes32 metadata",
+			results: map[string]interface{}{
+				"symbol":   bytes32("MKR"),
+				"name":     bytes32("Maker"),
+				"decimals": pack("decimals", uint8(18)),
+			},
+			expected: &posts.Token{Symbol: "MKR", Name: "Maker", Decimals: 18},
+		},
+		{
+			name: "reverting decimals",
+			results: map[string]interface{}{
+				"symbol":   pack("symbol", "TKN"),
+				"name":     pack("name", "Token"),
+				"decimals": ethnode.Revert(""),
+			},
+			expected: &posts.Token{Symbol: "TKN", Name: "Token"},
+		},
+		{
+			name: "missing name",
+			results: map[string]interface{}{
+				"symbol":   pack("symbol", "TKN"),
+				"name":     []byte{},
+				"decimals": pack("decimals", uint8(18)),
+			},
+			expected: &posts.Token{Symbol: "TKN", Decimals: 18},
+		},
+		{
+			name: "rate limited",
+			results: map[string]interface{}{
+				"symbol":   pack("symbol", "USDC"),
+				"name":     pack("name", "USD Coin"),
+				"decimals": rateLimited,
+			},
+			err: true,
+		},
+		{
+			name: "pruned state",
+			results: map[string]interface{}{
+				"symbol": &ethnode.Error{Code: -32000, Message: "missing trie node"},
+			},
+			err: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestNode(t, 10)
+			node.Call = func(_ common.Address, data []byte, _ uint64) ([]byte, error) {
+				method, err := erc20.MethodById(data)
+				if err != nil {
+					return nil, err
+				}
+				switch result := tc.results[method.Name].(type) {
+				case error:
+					return nil, result
+				case []byte:
+					return result, nil
+				}
+				return nil, ethnode.Revert("")
+			}
+
+			ctrl := gomock.NewController(t)
+			tokens := mockDB.NewMockITokenRepository(ctrl)
+			m := &PoolMetadataService{
+				client:        client,
+				erc20ABI:      erc20,
+				pools:         make(map[common.Address]*PoolMetadata),
+				tokens:        make(map[common.Address]*posts.Token),
+				TokenDBClient: tokens,
+			}
+
+			address := common.HexToAddress(usdc).String()
+			tokens.EXPECT().GetToken(gomock.Any(), address).Return(nil, nil)
+			// A token that failed to resolve is neither stored nor cached
+			if tc.expected != nil {
+				tc.expected.Address = address
+				tokens.EXPECT().StoreToken(gomock.Any(), *tc.expected).Return(nil)
+			}
+
+			token, err := m.GetToken(context.Background(), address)
+			if tc.err {
+				assert.Error(t, err)
+				assert.Empty(t, m.tokens)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, token)
+
+			// Served from the cache afterwards
+			cached, err := m.GetToken(context.Background(), address)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, cached)
+		})
+	}
+}
@@ -0,0 +1,452 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/pnl_mock.go uniswapper/internal/app/service/pool IPnLService
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/v3math/liquidityamounts"
+	"uniswapper/internal/app/service/v3math/tickmath"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInvalidDeposit is returned for hypothetical deposits that would not
+// provide any liquidity to their range
+var ErrInvalidDeposit = errors.New("deposit provides no liquidity")
+
+// PnLDay values a position at End, the close of a UTC day or the end of the
+// period. Amounts are in base units and values in token1, adjusted for the
+// decimals of the tokens. Hodl0 and Hodl1 are the tokens the position would
+// hold had they not been deposited, and Fees0 and Fees1 the fees it earned
+// since the start of the period. ImpermanentLoss is the position value
+// relative to the HODL value, minus one, and NetPnL the position and fees
+// value minus the HODL value.
+type PnLDay struct {
+	Start           time.Time      `json:"start"`
+	End             time.Time      `json:"end"`
+	Tick            int64          `json:"tick"`
+	Price           *Price         `json:"price"`
+	Liquidity       numeric.BigInt `json:"liquidity"`
+	Amount0         numeric.BigInt `json:"amount0"`
+	Amount1         numeric.BigInt `json:"amount1"`
+	Hodl0           numeric.BigInt `json:"hodl0"`
+	Hodl1           numeric.BigInt `json:"hodl1"`
+	Fees0           numeric.BigInt `json:"fees0"`
+	Fees1           numeric.BigInt `json:"fees1"`
+	PositionValue   string         `json:"position_value"`
+	HodlValue       string         `json:"hodl_value"`
+	FeesValue       string         `json:"fees_value"`
+	ImpermanentLoss *string        `json:"impermanent_loss"`
+	NetPnL          string         `json:"net_pnl"`
+}
+
+// PnL is the daily performance of a tracked position, or of a hypothetical
+// deposit when TokenId is nil, over [From, To]
+type PnL struct {
+	TokenId     *numeric.BigInt `json:"token_id"`
+	PoolAddress string          `json:"pool_address"`
+	Token0      string          `json:"token0"`
+	Token1      string          `json:"token1"`
+	TickLower   int32           `json:"tick_lower"`
+	TickUpper   int32           `json:"tick_upper"`
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	Days        []PnLDay        `json:"days"`
+}
+
+// LiquidityChange is a change of the liquidity of a position in chain order.
+// Amount0 and Amount1 are the tokens deposited by an increase.
+type LiquidityChange struct {
+	BlockNumber uint64
+	LogIndex    uint
+	Time        time.Time
+	Liquidity   *big.Int
+	Amount0     *big.Int
+	Amount1     *big.Int
+}
+
+// PnLHistory is the stored history replayed by DailyPnL. Swaps start with
+// the last swap at or before From, as returned by GetSwapsSince, and the
+// Changes at or before From set the liquidity the position starts with.
+type PnLHistory struct {
+	TickLower    int32
+	TickUpper    int32
+	Changes      []LiquidityChange
+	Swaps        []posts.Swap
+	Hypothetical bool
+	From         time.Time
+	To           time.Time
+	Decimals0    uint8
+	Decimals1    uint8
+}
+
+// IPnLService reports the impermanent loss and PnL of LP positions from the
+// stored swaps
+type IPnLService interface {
+	GetPositionPnL(ctx context.Context, tokenID *big.Int, from, to time.Time) (*PnL, error)
+	GetRangePnL(ctx context.Context, address string, tickLower, tickUpper int32, amount0, amount1 *big.Int, from, to time.Time) (*PnL, error)
+}
+
+// PnLService replays the swaps stored by the ingestor against the liquidity
+// of a position, so that no third-party price history is needed
+type PnLService struct {
+	PositionDBClient pool.IPositionRepository
+	EventsDBClient   pool.IPoolEventsRepository
+	Metadata         IPoolMetadataService
+}
+
+func NewPnLService(
+	positionDBClient pool.IPositionRepository,
+	eventsDBClient pool.IPoolEventsRepository,
+	metadata IPoolMetadataService,
+) IPnLService {
+	return &PnLService{
+		PositionDBClient: positionDBClient,
+		EventsDBClient:   eventsDBClient,
+		Metadata:         metadata,
+	}
+}
+
+// GetPositionPnL reports a tracked position. The HODL baseline holds the
+// tokens of its liquidity at from, plus those deposited later, and shrinks
+// with the liquidity withdrawn.
+func (s *PnLService) GetPositionPnL(ctx context.Context, tokenID *big.Int, from, to time.Time) (*PnL, error) {
+	position, err := s.PositionDBClient.GetPosition(ctx, tokenID.String())
+	if err != nil {
+		return nil, err
+	}
+	if position == nil {
+		return nil, ErrPositionNotFound
+	}
+
+	metadata, err := s.Metadata.GetPoolMetadata(ctx, position.PoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.PositionDBClient.GetLiquidityEvents(ctx, tokenID.String(), to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]LiquidityChange, 0, len(events))
+	for _, event := range events {
+		change := LiquidityChange{
+			BlockNumber: event.BlockNumber,
+			LogIndex:    event.LogIndex,
+			Time:        event.BlockTimestamp,
+			Liquidity:   event.Liquidity.Big(),
+			Amount0:     event.Amount0.Big(),
+			Amount1:     event.Amount1.Big(),
+		}
+		if event.Event == posts.POSITION_EVENT_DECREASE_LIQUIDITY {
+			change.Liquidity.Neg(change.Liquidity)
+			change.Amount0, change.Amount1 = new(big.Int), new(big.Int)
+		}
+		changes = append(changes, change)
+	}
+
+	swaps, err := s.EventsDBClient.GetSwapsSince(ctx, metadata.Address, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := DailyPnL(PnLHistory{
+		TickLower: position.TickLower,
+		TickUpper: position.TickUpper,
+		Changes:   changes,
+		Swaps:     swaps,
+		From:      from,
+		To:        to,
+		Decimals0: metadata.Token0.Decimals,
+		Decimals1: metadata.Token1.Decimals,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	id := numeric.NewBigInt(tokenID)
+	return &PnL{
+		TokenId:     &id,
+		PoolAddress: metadata.Address,
+		Token0:      metadata.Token0.Address,
+		Token1:      metadata.Token1.Address,
+		TickLower:   position.TickLower,
+		TickUpper:   position.TickUpper,
+		From:        from,
+		To:          to,
+		Days:        days,
+	}, nil
+}
+
+// GetRangePnL reports a hypothetical position minted at from with as much
+// liquidity as amount0 and amount1 provide to the range at the price then.
+// The HODL baseline holds the tokens actually deposited.
+func (s *PnLService) GetRangePnL(
+	ctx context.Context,
+	address string,
+	tickLower, tickUpper int32,
+	amount0, amount1 *big.Int,
+	from, to time.Time,
+) (*PnL, error) {
+	metadata, err := s.Metadata.GetPoolMetadata(ctx, common.HexToAddress(address).String())
+	if err != nil {
+		return nil, err
+	}
+
+	sqrtRatioAX96, err := tickmath.GetSqrtRatioAtTick(int(tickLower))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDeposit, err)
+	}
+	sqrtRatioBX96, err := tickmath.GetSqrtRatioAtTick(int(tickUpper))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDeposit, err)
+	}
+
+	swaps, err := s.EventsDBClient.GetSwapsSince(ctx, metadata.Address, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(swaps) == 0 || swaps[0].BlockTimestamp.After(from) {
+		return nil, fmt.Errorf("%w: no swap stored at or before %s", ErrNoTickHistory, from.Format(time.RFC3339))
+	}
+
+	liquidity, err := liquidityamounts.GetLiquidityForAmounts(swaps[0].SqrtPriceX96.Big(), sqrtRatioAX96, sqrtRatioBX96, amount0, amount1)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDeposit, err)
+	}
+	if liquidity.Sign() <= 0 {
+		return nil, ErrInvalidDeposit
+	}
+
+	days, err := DailyPnL(PnLHistory{
+		TickLower:    tickLower,
+		TickUpper:    tickUpper,
+		Changes:      []LiquidityChange{{Time: from, Liquidity: liquidity}},
+		Swaps:        swaps,
+		Hypothetical: true,
+		From:         from,
+		To:           to,
+		Decimals0:    metadata.Token0.Decimals,
+		Decimals1:    metadata.Token1.Decimals,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PnL{
+		PoolAddress: metadata.Address,
+		Token0:      metadata.Token0.Address,
+		Token1:      metadata.Token1.Address,
+		TickLower:   tickLower,
+		TickUpper:   tickUpper,
+		From:        from,
+		To:          to,
+		Days:        days,
+	}, nil
+}
+
+// DailyPnL replays the swaps and liquidity changes of a position in chain
+// order and values it at the close of each UTC day of [From, To]. A swap
+// earns the position its share of the LP fee if it starts within the range,
+// against the pool liquidity left by the previous swap, so liquidity added
+// or removed by others in between is not seen. Hypothetical positions are
+// not part of that liquidity and are added to it.
+func DailyPnL(h PnLHistory) ([]PnLDay, error) {
+	if len(h.Swaps) == 0 || h.Swaps[0].BlockTimestamp.After(h.From) {
+		return nil, fmt.Errorf("%w: no swap stored at or before %s", ErrNoTickHistory, h.From.Format(time.RFC3339))
+	}
+
+	sqrtRatioAX96, err := tickmath.GetSqrtRatioAtTick(int(h.TickLower))
+	if err != nil {
+		return nil, err
+	}
+	sqrtRatioBX96, err := tickmath.GetSqrtRatioAtTick(int(h.TickUpper))
+	if err != nil {
+		return nil, err
+	}
+
+	state := h.Swaps[0]
+	liquidity := new(big.Int)
+	c := 0
+	for ; c < len(h.Changes) && !h.Changes[c].Time.After(h.From); c++ {
+		liquidity.Add(liquidity, h.Changes[c].Liquidity)
+	}
+	if liquidity.Sign() < 0 {
+		liquidity.SetInt64(0)
+	}
+
+	hodl0, hodl1, err := liquidityamounts.GetAmountsForLiquidity(state.SqrtPriceX96.Big(), sqrtRatioAX96, sqrtRatioBX96, liquidity)
+	if err != nil {
+		return nil, err
+	}
+	fees0, fees1 := new(big.Int), new(big.Int)
+
+	var days []PnLDay
+	s := 1
+	for start := h.From; ; {
+		end := start.Truncate(24 * time.Hour).Add(24 * time.Hour)
+		if end.After(h.To) {
+			end = h.To
+		}
+
+		for {
+			hasSwap := s < len(h.Swaps) && !h.Swaps[s].BlockTimestamp.After(end)
+			hasChange := c < len(h.Changes) && !h.Changes[c].Time.After(end)
+			if !hasSwap && !hasChange {
+				break
+			}
+
+			if hasChange && (!hasSwap || changesFirst(h.Changes[c], h.Swaps[s])) {
+				applyLiquidityChange(h.Changes[c], liquidity, hodl0, hodl1)
+				c++
+				continue
+			}
+
+			swap := h.Swaps[s]
+			if fee := swapFeeShare(state, swap, liquidity, h); fee != nil {
+				if swap.Amount0.Big().Sign() > 0 {
+					fees0.Add(fees0, fee)
+				} else {
+					fees1.Add(fees1, fee)
+				}
+			}
+			state = swap
+			s++
+		}
+
+		day, err := closeDay(h, state, liquidity, sqrtRatioAX96, sqrtRatioBX96, hodl0, hodl1, fees0, fees1)
+		if err != nil {
+			return nil, err
+		}
+		day.Start, day.End = start, end
+		days = append(days, *day)
+
+		if !end.Before(h.To) {
+			return days, nil
+		}
+		start = end
+	}
+}
+
+// changesFirst tells whether a liquidity change precedes a swap on chain
+func changesFirst(change LiquidityChange, swap posts.Swap) bool {
+	if change.BlockNumber != swap.BlockNumber {
+		return change.BlockNumber < swap.BlockNumber
+	}
+	return change.LogIndex < swap.LogIndex
+}
+
+// applyLiquidityChange adds the tokens deposited by an increase to the HODL
+// baseline, and removes from it the share of the liquidity withdrawn by a
+// decrease
+func applyLiquidityChange(change LiquidityChange, liquidity, hodl0, hodl1 *big.Int) {
+	if change.Liquidity.Sign() >= 0 {
+		liquidity.Add(liquidity, change.Liquidity)
+		hodl0.Add(hodl0, change.Amount0)
+		hodl1.Add(hodl1, change.Amount1)
+		return
+	}
+
+	if liquidity.Sign() <= 0 {
+		return
+	}
+	remaining := new(big.Int).Add(liquidity, change.Liquidity)
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+	hodl0.Mul(hodl0, remaining).Quo(hodl0, liquidity)
+	hodl1.Mul(hodl1, remaining).Quo(hodl1, liquidity)
+	liquidity.Set(remaining)
+}
+
+// swapFeeShare returns the LP fee a swap earns the position, in its input
+// token, or nil if the position is out of range or empty
+func swapFeeShare(state, swap posts.Swap, liquidity *big.Int, h PnLHistory) *big.Int {
+	if liquidity.Sign() <= 0 || state.Tick < int64(h.TickLower) || state.Tick >= int64(h.TickUpper) {
+		return nil
+	}
+
+	fee := new(big.Int).Sub(swap.FeeAmount.Big(), swap.ProtocolFeeAmount.Big())
+	if fee.Sign() <= 0 {
+		return nil
+	}
+
+	active := state.Liquidity.Big()
+	if h.Hypothetical {
+		active.Add(active, liquidity)
+	}
+	if active.Cmp(liquidity) < 0 {
+		active.Set(liquidity)
+	}
+	return fee.Mul(fee, liquidity).Quo(fee, active)
+}
+
+// closeDay values the position, its HODL baseline and its fees at the price
+// left by the last swap
+func closeDay(
+	h PnLHistory,
+	state posts.Swap,
+	liquidity, sqrtRatioAX96, sqrtRatioBX96, hodl0, hodl1, fees0, fees1 *big.Int,
+) (*PnLDay, error) {
+	sqrtPriceX96 := state.SqrtPriceX96.Big()
+	amount0, amount1, err := liquidityamounts.GetAmountsForLiquidity(sqrtPriceX96, sqrtRatioAX96, sqrtRatioBX96, liquidity)
+	if err != nil {
+		return nil, err
+	}
+
+	positionValue := valueInToken1(amount0, amount1, sqrtPriceX96, h.Decimals1)
+	hodlValue := valueInToken1(hodl0, hodl1, sqrtPriceX96, h.Decimals1)
+	feesValue := valueInToken1(fees0, fees1, sqrtPriceX96, h.Decimals1)
+
+	netPnL := new(big.Rat).Add(positionValue, feesValue)
+	netPnL.Sub(netPnL, hodlValue)
+
+	day := &PnLDay{
+		Tick:          state.Tick,
+		Price:         PriceFromSqrtPriceX96(sqrtPriceX96, h.Decimals0, h.Decimals1),
+		Liquidity:     numeric.NewBigInt(liquidity),
+		Amount0:       numeric.NewBigInt(amount0),
+		Amount1:       numeric.NewBigInt(amount1),
+		Hodl0:         numeric.NewBigInt(hodl0),
+		Hodl1:         numeric.NewBigInt(hodl1),
+		Fees0:         numeric.NewBigInt(fees0),
+		Fees1:         numeric.NewBigInt(fees1),
+		PositionValue: formatValue(positionValue),
+		HodlValue:     formatValue(hodlValue),
+		FeesValue:     formatValue(feesValue),
+		NetPnL:        formatValue(netPnL),
+	}
+
+	if hodlValue.Sign() > 0 {
+		impermanentLoss := new(big.Rat).Quo(positionValue, hodlValue)
+		impermanentLoss.Sub(impermanentLoss, big.NewRat(1, 1))
+		formatted := formatValue(impermanentLoss)
+		day.ImpermanentLoss = &formatted
+	}
+	return day, nil
+}
+
+// valueInToken1 values amounts of both tokens at sqrtPriceX96, in whole
+// token1: (amount0 * sqrtPriceX96^2 / 2^192 + amount1) / 10^decimals1
+func valueInToken1(amount0, amount1, sqrtPriceX96 *big.Int, decimals1 uint8) *big.Rat {
+	num := new(big.Int).Mul(amount0, sqrtPriceX96)
+	num.Mul(num, sqrtPriceX96)
+	num.Add(num, new(big.Int).Mul(amount1, q192))
+
+	return new(big.Rat).SetFrac(num, new(big.Int).Mul(q192, pow10(decimals1)))
+}
+
+// formatValue renders a signed value like formatPrice
+func formatValue(r *big.Rat) string {
+	if r.Sign() < 0 {
+		return "-" + formatPrice(new(big.Rat).Neg(r))
+	}
+	return formatPrice(r)
+}
@@ -0,0 +1,105 @@
+package pool
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/v3math/tickmath"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyPnL(t *testing.T) {
+	from := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 3, 6, 0, 0, 0, time.UTC)
+
+	swap := func(block uint64, at time.Time, tick int, amount0, amount1, fee int64) posts.Swap {
+		sqrtPriceX96, err := tickmath.GetSqrtRatioAtTick(tick)
+		assert.NoError(t, err)
+		return posts.Swap{
+			BlockNumber:       block,
+			BlockTimestamp:    at,
+			Amount0:           numeric.NewBigInt(big.NewInt(amount0)),
+			Amount1:           numeric.NewBigInt(big.NewInt(amount1)),
+			SqrtPriceX96:      numeric.NewBigInt(sqrtPriceX96),
+			Liquidity:         numeric.NewBigInt(big.NewInt(1_000_000_000_000_000_000)),
+			Tick:              int64(tick),
+			FeeAmount:         numeric.NewBigInt(big.NewInt(fee)),
+			ProtocolFeeAmount: numeric.NewBigInt(new(big.Int)),
+		}
+	}
+	swaps := []posts.Swap{
+		swap(10, from.Add(-time.Hour), 0, 0, 0, 0),
+		// In range all day: only fees are earned
+		swap(20, time.Date(2026, 10, 1, 18, 0, 0, 0, time.UTC), 0, 1_000_000_000_000_000, -1, 3_000_000_000_000),
+		// Moves the price within the range
+		swap(30, time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC), 300, -1, 1_000_000_000_000_000, 3_000_000_000_000),
+		// Starts in range and leaves it above
+		swap(40, time.Date(2026, 10, 3, 1, 0, 0, 0, time.UTC), 1200, -1, 1_000_000_000_000_000, 3_000_000_000_000),
+	}
+	deposit := []LiquidityChange{{BlockNumber: 5, Time: from.Add(-2 * time.Hour), Liquidity: big.NewInt(100_000_000_000_000_000)}}
+
+	history := PnLHistory{
+		TickLower: -600,
+		TickUpper: 600,
+		Changes:   deposit,
+		Swaps:     swaps,
+		From:      from,
+		To:        to,
+		Decimals0: 18,
+		Decimals1: 18,
+	}
+
+	days, err := DailyPnL(history)
+	assert.NoError(t, err)
+	assert.Len(t, days, 3)
+
+	// Days close at UTC midnight, except the last one
+	assert.Equal(t, from, days[0].Start)
+	assert.Equal(t, time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), days[0].End)
+	assert.Equal(t, days[0].End, days[1].Start)
+	assert.Equal(t, to, days[2].End)
+
+	// The position holds a tenth of the active liquidity
+	assert.Equal(t, "0", *days[0].ImpermanentLoss)
+	assert.Equal(t, "300000000000", days[0].Fees0.String())
+	assert.Equal(t, "0", days[0].Fees1.String())
+	assert.Equal(t, days[0].FeesValue, days[0].NetPnL)
+
+	assert.True(t, strings.HasPrefix(*days[1].ImpermanentLoss, "-"))
+	assert.Equal(t, "300000000000", days[1].Fees1.String())
+	assert.Equal(t, days[0].Hodl0.String(), days[1].Hodl0.String())
+
+	// Above the range the position only holds token1
+	assert.Equal(t, int64(1200), days[2].Tick)
+	assert.Equal(t, "0", days[2].Amount0.String())
+	assert.Equal(t, "600000000000", days[2].Fees1.String())
+
+	// A hypothetical position adds to the active liquidity
+	history.Hypothetical = true
+	days, err = DailyPnL(history)
+	assert.NoError(t, err)
+	assert.Equal(t, "272727272727", days[0].Fees0.String())
+
+	// Withdrawing half of the liquidity halves the HODL baseline
+	history.Hypothetical = false
+	history.Changes = append(deposit, LiquidityChange{
+		BlockNumber: 25,
+		Time:        time.Date(2026, 10, 2, 1, 0, 0, 0, time.UTC),
+		Liquidity:   big.NewInt(-50_000_000_000_000_000),
+	})
+	days, err = DailyPnL(history)
+	assert.NoError(t, err)
+	assert.Equal(t, "50000000000000000", days[1].Liquidity.String())
+	half := new(big.Int).Quo(days[0].Hodl0.Big(), big.NewInt(2))
+	assert.Equal(t, half.String(), days[1].Hodl0.String())
+	assert.Equal(t, "150000000000", new(big.Int).Sub(days[1].Fees1.Big(), days[0].Fees1.Big()).String())
+
+	// The price at from must be known
+	history.From = from.Add(-2 * time.Hour)
+	_, err = DailyPnL(history)
+	assert.ErrorIs(t, err, ErrNoTickHistory)
+}
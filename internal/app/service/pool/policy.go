@@ -0,0 +1,119 @@
+package pool
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Ingestion policies deciding which swaps are persisted as pool_logs snapshots.
+// Decoded events are always written to their per-event tables.
+const (
+	POLICY_EVERY_EVENT    = "every_event"
+	POLICY_BLOCK_INTERVAL = "block_interval"
+	POLICY_TIME_INTERVAL  = "time_interval"
+)
+
+type ingestionPolicy interface {
+	// shouldStore reports whether a snapshot of the pool at the given block
+	// should be stored, and records it as stored if so
+	shouldStore(pool common.Address, blockNumber uint64, blockTime time.Time) bool
+	// reset forgets the snapshots of the pool stored after afterBlock, once
+	// their rows are deleted, so that the replayed blocks are sampled again
+	reset(pool common.Address, afterBlock uint64)
+}
+
+func newIngestionPolicy(policy string, blocks uint64, interval time.Duration) (ingestionPolicy, error) {
+	switch policy {
+	case "", POLICY_EVERY_EVENT:
+		return everyEventPolicy{}, nil
+	case POLICY_BLOCK_INTERVAL:
+		if blocks == 0 {
+			return nil, fmt.Errorf("%s policy needs a block interval greater than zero", policy)
+		}
+		return &blockIntervalPolicy{blocks: blocks, last: make(map[common.Address]uint64)}, nil
+	case POLICY_TIME_INTERVAL:
+		if interval <= 0 {
+			return nil, fmt.Errorf("%s policy needs a time interval greater than zero", policy)
+		}
+		return &timeIntervalPolicy{interval: interval, last: make(map[common.Address]storedSnapshot)}, nil
+	default:
+		return nil, fmt.Errorf("unknown ingestion policy %q", policy)
+	}
+}
+
+// everyEventPolicy stores a snapshot for every swap
+type everyEventPolicy struct{}
+
+func (everyEventPolicy) shouldStore(common.Address, uint64, time.Time) bool {
+	return true
+}
+
+func (everyEventPolicy) reset(common.Address, uint64) {}
+
+// blockIntervalPolicy stores at most one snapshot per pool every N blocks
+type blockIntervalPolicy struct {
+	mu     sync.Mutex
+	blocks uint64
+	last   map[common.Address]uint64
+}
+
+func (p *blockIntervalPolicy) shouldStore(pool common.Address, blockNumber uint64, _ time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if last, ok := p.last[pool]; ok && blockNumber < last+p.blocks {
+		return false
+	}
+	p.last[pool] = blockNumber
+	return true
+}
+
+// reset restarts the sampling of the pool at the next swap if its last
+// snapshot was deleted
+func (p *blockIntervalPolicy) reset(pool common.Address, afterBlock uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if last, ok := p.last[pool]; ok && last > afterBlock {
+		delete(p.last, pool)
+	}
+}
+
+// timeIntervalPolicy stores at most one snapshot per pool per time interval,
+// measured in block time so that backfilled and live data sample alike
+type timeIntervalPolicy struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     map[common.Address]storedSnapshot
+}
+
+// storedSnapshot is the block and block time of the last snapshot of a pool
+type storedSnapshot struct {
+	block uint64
+	time  time.Time
+}
+
+func (p *timeIntervalPolicy) shouldStore(pool common.Address, blockNumber uint64, blockTime time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if last, ok := p.last[pool]; ok && blockTime.Before(last.time.Add(p.interval)) {
+		return false
+	}
+	p.last[pool] = storedSnapshot{block: blockNumber, time: blockTime}
+	return true
+}
+
+// reset restarts the sampling of the pool at the next swap if its last
+// snapshot was deleted
+func (p *timeIntervalPolicy) reset(pool common.Address, afterBlock uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if last, ok := p.last[pool]; ok && last.block > afterBlock {
+		delete(p.last, pool)
+	}
+}
@@ -0,0 +1,131 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIngestionPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   string
+		blocks   uint64
+		interval time.Duration
+		valid    bool
+	}{
+		{name: "default", policy: "", valid: true},
+		{name: "every event", policy: POLICY_EVERY_EVENT, valid: true},
+		{name: "block interval", policy: POLICY_BLOCK_INTERVAL, blocks: 12, valid: true},
+		{name: "block interval without blocks", policy: POLICY_BLOCK_INTERVAL},
+		{name: "time interval", policy: POLICY_TIME_INTERVAL, interval: time.Minute, valid: true},
+		{name: "time interval without interval", policy: POLICY_TIME_INTERVAL},
+		{name: "unknown", policy: "sometimes", blocks: 12, interval: time.Minute},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := newIngestionPolicy(tc.policy, tc.blocks, tc.interval)
+			if tc.valid {
+				assert.NoError(t, err)
+				assert.NotNil(t, policy)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestIngestionPolicies(t *testing.T) {
+	pool := common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+	other := common.HexToAddress("0x8ad599c3A0ff1De082011EFDDc58f1908eb6e6D8")
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// A swap every block, 12 seconds apart, unless the step resets the policy
+	type step struct {
+		pool  common.Address
+		block uint64
+		reset bool
+		// afterBlock is the block rows are deleted after, for resets
+		afterBlock uint64
+		stored     bool
+	}
+
+	testCases := []struct {
+		name     string
+		policy   string
+		blocks   uint64
+		interval time.Duration
+		steps    []step
+	}{
+		{
+			name:   "every event",
+			policy: POLICY_EVERY_EVENT,
+			steps: []step{
+				{pool: pool, block: 100, stored: true},
+				{pool: pool, block: 100, stored: true},
+				{pool: pool, block: 101, stored: true},
+			},
+		},
+		{
+			name:   "block interval",
+			policy: POLICY_BLOCK_INTERVAL,
+			blocks: 10,
+			steps: []step{
+				{pool: pool, block: 100, stored: true},
+				{pool: pool, block: 105},
+				{pool: pool, block: 109},
+				{pool: pool, block: 110, stored: true},
+				// Each pool is sampled on its own
+				{pool: other, block: 111, stored: true},
+				{pool: pool, block: 115},
+				// The snapshot of block 110 is deleted and its block replayed
+				{pool: pool, reset: true, afterBlock: 105},
+				{pool: pool, block: 110, stored: true},
+				// Deleting rows after the last snapshot keeps it
+				{pool: pool, reset: true, afterBlock: 110},
+				{pool: pool, block: 111},
+				{pool: pool, block: 120, stored: true},
+			},
+		},
+		{
+			name:     "time interval",
+			policy:   POLICY_TIME_INTERVAL,
+			interval: time.Minute,
+			steps: []step{
+				{pool: pool, block: 100, stored: true},
+				{pool: pool, block: 104},
+				{pool: pool, block: 105, stored: true},
+				{pool: other, block: 106, stored: true},
+				{pool: pool, block: 109},
+				{pool: pool, reset: true, afterBlock: 104},
+				{pool: pool, block: 105, stored: true},
+				{pool: pool, reset: true, afterBlock: 105},
+				{pool: pool, block: 106},
+				{pool: pool, block: 110, stored: true},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := newIngestionPolicy(tc.policy, tc.blocks, tc.interval)
+			assert.NoError(t, err)
+
+			for _, s := range tc.steps {
+				if s.reset {
+					policy.reset(s.pool, s.afterBlock)
+					continue
+				}
+				blockTime := start.Add(time.Duration(s.block) * 12 * time.Second)
+				assert.Equal(t, s.stored, policy.shouldStore(s.pool, s.block, blockTime), "block %d", s.block)
+			}
+		})
+	}
+}
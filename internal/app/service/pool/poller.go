@@ -0,0 +1,143 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Ingestion modes selected by POOL_INGESTION_MODE
+const (
+	INGESTION_MODE_SUBSCRIPTION = "subscription"
+	INGESTION_MODE_POLLING      = "polling"
+)
+
+const defaultPollInterval = 12 * time.Second
+
+// runPollingSession follows the chain with eth_blockNumber and eth_getLogs,
+// for providers that do not support subscriptions. Logs go through the same
+// handler as the subscription path so the stored records are identical.
+func (u *UniswapV3Pool) runPollingSession(ctx context.Context) error {
+	log := logger.Logger(ctx)
+
+	if err := u.client.CheckHealth(ctx); err != nil {
+		return fmt.Errorf("checking RPC endpoints: %w", err)
+	}
+
+	if err := u.startSession(ctx); err != nil {
+		return err
+	}
+
+	header, err := u.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("fetching chain head: %w", err)
+	}
+	last := header.Number.Uint64()
+
+	if err := u.catchUp(ctx, last); err != nil {
+		return fmt.Errorf("catching up to block %d: %w", last, err)
+	}
+	u.blocks.add(last, header.Hash())
+
+	log.Infof("Polling pool logs from block %d every %s", last, u.pollInterval)
+
+	ticker := time.NewTicker(u.pollInterval)
+	defer ticker.Stop()
+
+	lastProgress := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-u.pools.changed:
+			return errPoolsChanged
+		case <-u.Registry.Changed():
+			return errPoolsChanged
+		case <-ticker.C:
+		}
+
+		head, err := u.client.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("fetching block number: %w", err)
+		}
+
+		if head <= last {
+			// A provider that stops producing blocks is treated as failed
+			if time.Since(lastProgress) > u.headTimeout {
+				return fmt.Errorf("no new block for %s", u.headTimeout)
+			}
+			continue
+		}
+
+		// Large gaps are processed in windows the block tracker can cover
+		for last < head {
+			to := head
+			if to-last > u.blocks.size {
+				to = last + u.blocks.size
+			}
+
+			ok, err := u.poll(ctx, last+1, to)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			last = to
+			lastProgress = time.Now()
+		}
+	}
+}
+
+// poll stores the logs of [from, to] and then feeds the block headers to the
+// reorg detection, exactly like the subscription path does with live logs
+// and heads. It returns false without storing anything if the chain changed
+// between fetching the headers and the logs, so the range is retried.
+func (u *UniswapV3Pool) poll(ctx context.Context, from, to uint64) (bool, error) {
+	log := logger.Logger(ctx)
+
+	headers := make(map[uint64]*types.Header, to-from+1)
+	for number := from; number <= to; number++ {
+		header, err := u.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return false, fmt.Errorf("fetching block %d: %w", number, err)
+		}
+		headers[number] = header
+	}
+
+	logs, err := u.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: u.following,
+	})
+	if err != nil {
+		return false, fmt.Errorf("fetching logs of blocks %d-%d: %w", from, to, err)
+	}
+
+	for _, vLog := range logs {
+		if header, ok := headers[vLog.BlockNumber]; !ok || header.Hash() != vLog.BlockHash {
+			log.Warnf("Block %d changed while polling, retrying blocks %d-%d", vLog.BlockNumber, from, to)
+			return false, nil
+		}
+	}
+
+	for _, vLog := range logs {
+		if err := u.handleLog(ctx, vLog); err != nil {
+			return false, err
+		}
+	}
+
+	// A failed rollback has not replayed the orphaned range, so the session
+	// is restarted from the checkpoint instead of confirming past it
+	for number := from; number <= to; number++ {
+		if err := u.handleHead(ctx, headers[number]); err != nil {
+			return false, fmt.Errorf("processing block %d: %w", number, err)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,127 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// staticRegistry is a pool registry whose pools never change
+type staticRegistry struct {
+	IPoolRegistry
+}
+
+func (staticRegistry) Seed(context.Context, []common.Address) error {
+	return nil
+}
+
+func (staticRegistry) Changed() <-chan struct{} {
+	return nil
+}
+
+// mintLog is a golden Mint of the USDC/WETH pool in block
+func mintLog(block uint64) types.Log {
+	vLog := goldenLog(
+		[]string{mintTopic, managerTopic, minTickTopic, maxTickTopic},
+		"0x000000000000000000000000c36442b4a4522e871399cd717abdd847ab11fe88"+
+			"0000000000000000000000000000000000000000000000000000048c27395000"+
+			"000000000000000000000000000000000000000000000000000000003b9aca00"+
+			"000000000000000000000000000000000000000000000000058d15e176280000",
+	)
+	vLog.BlockNumber = block
+	return vLog
+}
+
+func TestPollChangedBlock(t *testing.T) {
+	setupTest(t)
+
+	node, client := newTestNode(t, 10)
+	u, stores := newTestPool(t, client, 100)
+	node.AddLog(mintLog(6))
+
+	// The chain reorganizes from block 5 between reading the headers and the
+	// logs, so the mint is returned with the hash of the new block 6
+	reorged := false
+	node.LogsError = func(from, to uint64) error {
+		if !reorged {
+			reorged = true
+			node.Reorg(5)
+			node.AddLog(mintLog(6))
+		}
+		return nil
+	}
+
+	ok, err := u.poll(context.Background(), 1, 10)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	// Nothing was stored or tracked from the stale headers
+	_, tracked := u.blocks.get(10)
+	assert.False(t, tracked)
+
+	// The retry reads consistent headers and logs
+	canonical := node.Header(6).Hash()
+	stores.events.EXPECT().StoreMint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mint posts.Mint) error {
+		assert.Equal(t, canonical.String(), mint.BlockHash)
+		return nil
+	})
+
+	ok, err = u.poll(context.Background(), 1, 10)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	hash, tracked := u.blocks.get(10)
+	assert.True(t, tracked)
+	assert.Equal(t, node.Header(10).Hash(), hash)
+	assert.Len(t, node.Queries(), 2)
+}
+
+func TestPollFailedRollback(t *testing.T) {
+	setupTest(t)
+
+	node, client := newTestNode(t, 10)
+	u, stores := newTestPool(t, client, 100)
+	track(u, node, 1, 5)
+	// Blocks 4 and 5 were orphaned since they were processed
+	node.Reorg(4)
+
+	pool := common.HexToAddress(usdcWethPool).String()
+	stores.events.EXPECT().DeleteEventsAfterBlock(gomock.Any(), pool, uint64(3)).Return(errors.New("connection reset"))
+
+	// The checkpoint is neither rewound nor advanced
+	ok, err := u.poll(context.Background(), 6, 10)
+	assert.ErrorContains(t, err, "processing block 6")
+	assert.False(t, ok)
+	_, tracked := u.blocks.get(6)
+	assert.False(t, tracked)
+}
+
+func TestPollingSessionHeadTimeout(t *testing.T) {
+	setupTest(t)
+
+	_, client := newTestNode(t, 10)
+	u, stores := newTestPool(t, client, 100)
+	u.Registry = staticRegistry{}
+	u.pollInterval = 5 * time.Millisecond
+	u.headTimeout = 50 * time.Millisecond
+
+	pool := common.HexToAddress(usdcWethPool).String()
+	stores.registry.EXPECT().GetTrackedPools(gomock.Any()).Return([]posts.Pool{{Address: pool, Tracked: true}}, nil)
+	// The pool is already processed up to the head
+	stores.checkpoints.EXPECT().GetCheckpoint(gomock.Any(), pool).Return(&posts.Checkpoint{PoolAddress: pool, BlockNumber: 10}, nil)
+
+	// The node stops producing blocks, so the session is restarted
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	err := u.runPollingSession(ctx)
+	assert.ErrorContains(t, err, "no new block for 50ms")
+	assert.NoError(t, ctx.Err())
+	assert.GreaterOrEqual(t, time.Since(started), u.headTimeout)
+}
@@ -0,0 +1,95 @@
+package pool
+
+import (
+	"sync"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// poolSet is the set of pools to ingest, loaded from the registry when a
+// session starts. Pools discovered while a session is running are added
+// directly; the session picks them up by restarting when changed fires.
+type poolSet struct {
+	mu      sync.RWMutex
+	order   []common.Address
+	starts  map[common.Address]uint64
+	changed chan struct{}
+}
+
+func newPoolSet() *poolSet {
+	return &poolSet{
+		starts:  make(map[common.Address]uint64),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// replace sets the pools to the tracked, unpaused pools of the registry
+func (s *poolSet) replace(pools []posts.Pool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.order = nil
+	s.starts = make(map[common.Address]uint64)
+	for _, p := range pools {
+		address := common.HexToAddress(p.Address)
+		if p.Paused || !p.Tracked {
+			continue
+		}
+		if _, ok := s.starts[address]; ok {
+			continue
+		}
+		s.order = append(s.order, address)
+		s.starts[address] = p.BlockNumber
+	}
+}
+
+// add inserts a pool whose history starts at startBlock (0 if unknown) and
+// reports whether it was new
+func (s *poolSet) add(address common.Address, startBlock uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.starts[address]; ok {
+		return false
+	}
+	s.order = append(s.order, address)
+	s.starts[address] = startBlock
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// list returns a copy of the pool addresses in insertion order
+func (s *poolSet) list() []common.Address {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	addresses := make([]common.Address, len(s.order))
+	copy(addresses, s.order)
+	return addresses
+}
+
+// startBlock returns the creation block of a discovered pool
+func (s *poolSet) startBlock(address common.Address) (uint64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	start, ok := s.starts[address]
+	return start, ok && start > 0
+}
+
+// drain clears a pending change notification
+func (s *poolSet) drain() {
+	drain(s.changed)
+}
+
+func drain(changed <-chan struct{}) {
+	select {
+	case <-changed:
+	default:
+	}
+}
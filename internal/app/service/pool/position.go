@@ -0,0 +1,300 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/position_mock.go uniswapper/internal/app/service/pool IPositionService
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/rpc"
+	"uniswapper/internal/app/service/v3math/fixedpoint"
+	"uniswapper/internal/app/service/v3math/fullmath"
+	"uniswapper/internal/app/service/v3math/liquidityamounts"
+	"uniswapper/internal/app/service/v3math/tickmath"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrPositionNotFound is returned for token IDs that are not tracked
+var ErrPositionNotFound = errors.New("position is not tracked")
+
+// PositionDetails is a tracked position valued at the current state of its
+// pool. Amount0 and Amount1 are the tokens its liquidity would withdraw,
+// excluding the tokens owed. They, InRange and CurrentTick are nil when the
+// pool state cannot be read.
+type PositionDetails struct {
+	posts.Position
+	PriceLower  *Price         `json:"price_lower"`
+	PriceUpper  *Price         `json:"price_upper"`
+	Amount0     numeric.BigInt `json:"amount0"`
+	Amount1     numeric.BigInt `json:"amount1"`
+	InRange     *bool          `json:"in_range"`
+	CurrentTick *int32         `json:"current_tick"`
+}
+
+// PositionFees are the fees a position could collect at BlockNumber, in base
+// units. Fees0 and Fees1 include the tokens already owed to the position.
+type PositionFees struct {
+	TokenId              numeric.BigInt `json:"token_id"`
+	PoolAddress          string         `json:"pool_address"`
+	Token0               string         `json:"token0"`
+	Token1               string         `json:"token1"`
+	BlockNumber          uint64         `json:"block_number"`
+	Liquidity            numeric.BigInt `json:"liquidity"`
+	FeeGrowthInside0X128 numeric.BigInt `json:"fee_growth_inside0_x128"`
+	FeeGrowthInside1X128 numeric.BigInt `json:"fee_growth_inside1_x128"`
+	TokensOwed0          numeric.BigInt `json:"tokens_owed0"`
+	TokensOwed1          numeric.BigInt `json:"tokens_owed1"`
+	Fees0                numeric.BigInt `json:"fees0"`
+	Fees1                numeric.BigInt `json:"fees1"`
+}
+
+// IPositionService serves the LP positions indexed by the position tracker
+type IPositionService interface {
+	GetPosition(ctx context.Context, tokenID *big.Int) (*PositionDetails, error)
+	GetPositions(ctx context.Context, owner string) ([]PositionDetails, error)
+	GetFees(ctx context.Context, tokenID *big.Int) (*PositionFees, error)
+}
+
+// PositionService values the stored positions with the cached pool states.
+// Uncollected fees are read from the chain, since they grow with every swap.
+type PositionService struct {
+	client           *rpc.Client
+	manager          common.Address
+	managerABI       abi.ABI
+	poolABI          abi.ABI
+	PositionDBClient pool.IPositionRepository
+	PoolState        IPoolStateService
+	Prices           IPriceService
+}
+
+func NewPositionService(
+	ctx context.Context,
+	rpcClient *rpc.Client,
+	positionDBClient pool.IPositionRepository,
+	poolState IPoolStateService,
+	prices IPriceService,
+) IPositionService {
+	log := logger.Logger(ctx)
+
+	managerABI, err := abi.JSON(strings.NewReader(nonfungiblePositionManagerABI))
+	if err != nil {
+		log.Fatalf("Failed to parse position manager ABI: %v", err)
+	}
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	if err != nil {
+		log.Fatalf("Failed to parse contract ABI: %v", err)
+	}
+
+	return &PositionService{
+		client:           rpcClient,
+		manager:          common.HexToAddress(constants.Config.PositionConfig.POSITION_MANAGER_ADDRESS),
+		managerABI:       managerABI,
+		poolABI:          poolABI,
+		PositionDBClient: positionDBClient,
+		PoolState:        poolState,
+		Prices:           prices,
+	}
+}
+
+func (s *PositionService) GetPosition(ctx context.Context, tokenID *big.Int) (*PositionDetails, error) {
+	position, err := s.PositionDBClient.GetPosition(ctx, tokenID.String())
+	if err != nil {
+		return nil, err
+	}
+	if position == nil {
+		return nil, ErrPositionNotFound
+	}
+
+	details := s.details(ctx, *position)
+	return &details, nil
+}
+
+// GetPositions returns the positions of an owner, or every tracked position
+// if owner is empty
+func (s *PositionService) GetPositions(ctx context.Context, owner string) ([]PositionDetails, error) {
+	if owner != "" {
+		owner = common.HexToAddress(owner).String()
+	}
+
+	positions, err := s.PositionDBClient.GetPositions(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]PositionDetails, 0, len(positions))
+	for _, position := range positions {
+		details = append(details, s.details(ctx, position))
+	}
+	return details, nil
+}
+
+// GetFees returns the uncollected fees of a tracked position at the latest
+// block. The position and its pool are read at the same block, and the fees
+// are accrued since the last update of the position the way the position
+// manager does on its next collect.
+func (s *PositionService) GetFees(ctx context.Context, tokenID *big.Int) (*PositionFees, error) {
+	stored, err := s.PositionDBClient.GetPosition(ctx, tokenID.String())
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrPositionNotFound
+	}
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blockNumber := new(big.Int).SetUint64(head)
+
+	out, err := callContract(ctx, s.client, s.managerABI, s.manager, "positions", blockNumber, tokenID)
+	if err != nil {
+		if rpc.IsRevert(err) {
+			// The position manager reverts for burned positions
+			return nil, fmt.Errorf("%w: %v", ErrPositionNotFound, err)
+		}
+		return nil, err
+	}
+	tickLower, tickUpper := out[5].(*big.Int), out[6].(*big.Int)
+	liquidity := out[7].(*big.Int)
+	feeGrowthInside0LastX128, feeGrowthInside1LastX128 := out[8].(*big.Int), out[9].(*big.Int)
+	tokensOwed0, tokensOwed1 := out[10].(*big.Int), out[11].(*big.Int)
+
+	state, err := s.PoolState.GetPoolState(ctx, stored.PoolAddress, &head)
+	if err != nil {
+		return nil, err
+	}
+
+	poolAddress := common.HexToAddress(stored.PoolAddress)
+	lower, err := callContract(ctx, s.client, s.poolABI, poolAddress, "ticks", blockNumber, tickLower)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := callContract(ctx, s.client, s.poolABI, poolAddress, "ticks", blockNumber, tickUpper)
+	if err != nil {
+		return nil, err
+	}
+
+	tick := state.Slot0.Tick
+	lowerTick, upperTick := int32(tickLower.Int64()), int32(tickUpper.Int64())
+	feeGrowthInside0X128 := FeeGrowthInside(lowerTick, upperTick, tick,
+		state.FeeGrowthGlobal0X128.Big(), lower[2].(*big.Int), upper[2].(*big.Int))
+	feeGrowthInside1X128 := FeeGrowthInside(lowerTick, upperTick, tick,
+		state.FeeGrowthGlobal1X128.Big(), lower[3].(*big.Int), upper[3].(*big.Int))
+
+	fees0, err := UncollectedFees(liquidity, feeGrowthInside0X128, feeGrowthInside0LastX128, tokensOwed0)
+	if err != nil {
+		return nil, err
+	}
+	fees1, err := UncollectedFees(liquidity, feeGrowthInside1X128, feeGrowthInside1LastX128, tokensOwed1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PositionFees{
+		TokenId:              numeric.NewBigInt(tokenID),
+		PoolAddress:          stored.PoolAddress,
+		Token0:               stored.Token0,
+		Token1:               stored.Token1,
+		BlockNumber:          head,
+		Liquidity:            numeric.NewBigInt(liquidity),
+		FeeGrowthInside0X128: numeric.NewBigInt(feeGrowthInside0X128),
+		FeeGrowthInside1X128: numeric.NewBigInt(feeGrowthInside1X128),
+		TokensOwed0:          numeric.NewBigInt(tokensOwed0),
+		TokensOwed1:          numeric.NewBigInt(tokensOwed1),
+		Fees0:                numeric.NewBigInt(fees0),
+		Fees1:                numeric.NewBigInt(fees1),
+	}, nil
+}
+
+// details prices the range of a position and values it at the latest state
+// of its pool. Errors are logged and leave the fields empty.
+func (s *PositionService) details(ctx context.Context, position posts.Position) PositionDetails {
+	log := logger.Logger(ctx)
+	details := PositionDetails{Position: position}
+
+	var err error
+	if details.PriceLower, err = s.Prices.GetPriceAtTick(ctx, position.PoolAddress, int(position.TickLower)); err != nil {
+		log.Warnf("Error pricing the range of position %s: %v", position.TokenId.String(), err)
+	} else if details.PriceUpper, err = s.Prices.GetPriceAtTick(ctx, position.PoolAddress, int(position.TickUpper)); err != nil {
+		log.Warnf("Error pricing the range of position %s: %v", position.TokenId.String(), err)
+	}
+
+	state, err := s.PoolState.GetPoolState(ctx, position.PoolAddress, nil)
+	if err != nil {
+		log.Warnf("Error reading the pool of position %s: %v", position.TokenId.String(), err)
+		return details
+	}
+
+	amount0, amount1, err := PositionAmounts(position, state.Slot0.SqrtPriceX96.Big())
+	if err != nil {
+		log.Warnf("Error valuing position %s: %v", position.TokenId.String(), err)
+		return details
+	}
+
+	tick := state.Slot0.Tick
+	inRange := position.TickLower <= tick && tick < position.TickUpper
+	details.Amount0, details.Amount1 = numeric.NewBigInt(amount0), numeric.NewBigInt(amount1)
+	details.InRange, details.CurrentTick = &inRange, &tick
+	return details
+}
+
+// PositionAmounts returns the token amounts held by the liquidity of a
+// position at sqrtPriceX96, rounded down like a burn
+func PositionAmounts(position posts.Position, sqrtPriceX96 *big.Int) (*big.Int, *big.Int, error) {
+	sqrtRatioAX96, err := tickmath.GetSqrtRatioAtTick(int(position.TickLower))
+	if err != nil {
+		return nil, nil, err
+	}
+	sqrtRatioBX96, err := tickmath.GetSqrtRatioAtTick(int(position.TickUpper))
+	if err != nil {
+		return nil, nil, err
+	}
+	return liquidityamounts.GetAmountsForLiquidity(sqrtPriceX96, sqrtRatioAX96, sqrtRatioBX96, position.Liquidity.Big())
+}
+
+// FeeGrowthInside ports Tick.getFeeGrowthInside: the fee growth per unit of
+// liquidity between tickLower and tickUpper, from the global fee growth and
+// the fee growth outside of each tick. Like the contract, it relies on
+// uint256 overflow, so only differences between its results are meaningful.
+func FeeGrowthInside(tickLower, tickUpper, tickCurrent int32, feeGrowthGlobalX128, lowerOutsideX128, upperOutsideX128 *big.Int) *big.Int {
+	feeGrowthBelow := lowerOutsideX128
+	if tickCurrent < tickLower {
+		feeGrowthBelow = new(big.Int).Sub(feeGrowthGlobalX128, lowerOutsideX128)
+	}
+
+	feeGrowthAbove := upperOutsideX128
+	if tickCurrent >= tickUpper {
+		feeGrowthAbove = new(big.Int).Sub(feeGrowthGlobalX128, upperOutsideX128)
+	}
+
+	inside := new(big.Int).Sub(feeGrowthGlobalX128, feeGrowthBelow)
+	inside.Sub(inside, feeGrowthAbove)
+	return inside.And(inside, fullmath.MaxUint256)
+}
+
+// UncollectedFees returns the tokens owed to a position once the fees
+// accrued since feeGrowthInsideLastX128 are credited to it, rounded down and
+// truncated to uint128 like the position manager does
+func UncollectedFees(liquidity, feeGrowthInsideX128, feeGrowthInsideLastX128, tokensOwed *big.Int) (*big.Int, error) {
+	delta := new(big.Int).Sub(feeGrowthInsideX128, feeGrowthInsideLastX128)
+	delta.And(delta, fullmath.MaxUint256)
+
+	accrued, err := fullmath.MulDiv(delta, liquidity, fixedpoint.Q128)
+	if err != nil {
+		return nil, err
+	}
+	accrued.And(accrued, maxUint128)
+
+	fees := accrued.Add(accrued, tokensOwed)
+	return fees.And(fees, maxUint128), nil
+}
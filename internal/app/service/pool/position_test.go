@@ -0,0 +1,122 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/v3math/fixedpoint"
+	"uniswapper/internal/app/service/v3math/fullmath"
+	"uniswapper/internal/app/service/v3math/tickmath"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionAmounts(t *testing.T) {
+	position := posts.Position{
+		TickLower: -60,
+		TickUpper: 60,
+		Liquidity: numeric.NewBigInt(big.NewInt(1_000_000_000_000)),
+	}
+	sqrtPriceAt := func(tick int) *big.Int {
+		sqrtPriceX96, err := tickmath.GetSqrtRatioAtTick(tick)
+		assert.NoError(t, err)
+		return sqrtPriceX96
+	}
+
+	testCases := []struct {
+		name           string
+		tick           int
+		token0, token1 bool
+	}{
+		{name: "below the range", tick: -120, token0: true},
+		{name: "on the lower tick", tick: -60, token0: true},
+		{name: "in range", tick: 0, token0: true, token1: true},
+		{name: "on the upper tick", tick: 60, token1: true},
+		{name: "above the range", tick: 120, token1: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			amount0, amount1, err := PositionAmounts(position, sqrtPriceAt(tc.tick))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.token0, amount0.Sign() > 0)
+			assert.Equal(t, tc.token1, amount1.Sign() > 0)
+		})
+	}
+
+	// At the middle of a symmetric range both sides hold about the same amount
+	amount0, amount1, err := PositionAmounts(position, sqrtPriceAt(0))
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, new(big.Int).Abs(new(big.Int).Sub(amount0, amount1)).Int64(), int64(1))
+
+	position.Liquidity = numeric.BigInt{}
+	amount0, amount1, err = PositionAmounts(position, sqrtPriceAt(0))
+	assert.NoError(t, err)
+	assert.Equal(t, "0", amount0.String())
+	assert.Equal(t, "0", amount1.String())
+}
+
+func TestFeeGrowthInside(t *testing.T) {
+	// 100 of the global growth happened below the range, 600 inside it and
+	// 300 above it. The growth outside a tick is on the other side of it from
+	// the current tick.
+	global := big.NewInt(1000)
+
+	testCases := []struct {
+		name                       string
+		tick                       int32
+		lowerOutside, upperOutside int64
+	}{
+		{name: "in range", tick: 0, lowerOutside: 100, upperOutside: 300},
+		{name: "on the lower tick", tick: -60, lowerOutside: 100, upperOutside: 300},
+		{name: "below the range", tick: -120, lowerOutside: 900, upperOutside: 300},
+		{name: "on the upper tick", tick: 60, lowerOutside: 100, upperOutside: 700},
+		{name: "above the range", tick: 120, lowerOutside: 100, upperOutside: 700},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			inside := FeeGrowthInside(-60, 60, tc.tick, global, big.NewInt(tc.lowerOutside), big.NewInt(tc.upperOutside))
+			assert.Equal(t, "600", inside.String())
+		})
+	}
+
+	// The outside values may exceed the global growth, and the result wraps
+	inside := FeeGrowthInside(-60, 60, 0, big.NewInt(10), big.NewInt(20), big.NewInt(0))
+	expected := new(big.Int).Sub(fullmath.MaxUint256, big.NewInt(9))
+	assert.Equal(t, expected.String(), inside.String())
+}
+
+func TestUncollectedFees(t *testing.T) {
+	liquidity := big.NewInt(1_000_000)
+	owed := big.NewInt(7)
+
+	// One token per unit of liquidity
+	last := new(big.Int).Lsh(big.NewInt(5), 128)
+	current := new(big.Int).Add(last, fixedpoint.Q128)
+	fees, err := UncollectedFees(liquidity, current, last, owed)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000007", fees.String())
+
+	// The growth inside wrapped around since the last update
+	last = new(big.Int).Sub(fullmath.MaxUint256, new(big.Int).Sub(fixedpoint.Q128, big.NewInt(1)))
+	current = new(big.Int).Set(fixedpoint.Q128)
+	fees, err = UncollectedFees(liquidity, current, last, owed)
+	assert.NoError(t, err)
+	assert.Equal(t, "2000007", fees.String())
+
+	// Fractions of a token are rounded down
+	current = new(big.Int).Rsh(fixedpoint.Q128, 1)
+	fees, err = UncollectedFees(big.NewInt(3), current, big.NewInt(0), big.NewInt(0))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", fees.String())
+
+	fees, err = UncollectedFees(big.NewInt(0), current, big.NewInt(0), owed)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", fees.String())
+}
@@ -0,0 +1,116 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/price_mock.go uniswapper/internal/app/service/pool IPriceService
+package pool
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"uniswapper/internal/app/service/v3math/tickmath"
+)
+
+// priceSignificantDigits is the precision of the formatted prices
+const priceSignificantDigits = 18
+
+// q192 is the scale of a squared sqrtPriceX96
+var q192 = new(big.Int).Lsh(big.NewInt(1), 192)
+
+// Price is the human-readable price of a pool, adjusted for the decimals of
+// its tokens. Prices are decimal strings to stay exact in the API and the
+// NUMERIC columns.
+type Price struct {
+	Token1PerToken0 string `json:"token1_per_token0"`
+	Token0PerToken1 string `json:"token0_per_token1"`
+}
+
+// IPriceService derives the prices of a pool from its sqrtPriceX96 or tick
+type IPriceService interface {
+	GetPrice(ctx context.Context, address string, sqrtPriceX96 *big.Int) (*Price, error)
+	GetPriceAtTick(ctx context.Context, address string, tick int) (*Price, error)
+}
+
+// PriceService looks up the token decimals of a pool through its metadata
+type PriceService struct {
+	Metadata IPoolMetadataService
+}
+
+func NewPriceService(metadata IPoolMetadataService) IPriceService {
+	return &PriceService{
+		Metadata: metadata,
+	}
+}
+
+func (p *PriceService) GetPrice(ctx context.Context, address string, sqrtPriceX96 *big.Int) (*Price, error) {
+	metadata, err := p.Metadata.GetPoolMetadata(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return PriceFromSqrtPriceX96(sqrtPriceX96, metadata.Token0.Decimals, metadata.Token1.Decimals), nil
+}
+
+func (p *PriceService) GetPriceAtTick(ctx context.Context, address string, tick int) (*Price, error) {
+	metadata, err := p.Metadata.GetPoolMetadata(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return PriceAtTick(tick, metadata.Token0.Decimals, metadata.Token1.Decimals)
+}
+
+// PriceFromSqrtPriceX96 converts a Q64.96 square root price into token1 per
+// token0 and its inverse: sqrtPriceX96^2 / 2^192 * 10^decimals0 / 10^decimals1.
+// A zero price, as before a pool is initialized, has no inverse and yields nil.
+func PriceFromSqrtPriceX96(sqrtPriceX96 *big.Int, decimals0, decimals1 uint8) *Price {
+	if sqrtPriceX96 == nil || sqrtPriceX96.Sign() <= 0 {
+		return nil
+	}
+
+	num := new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96)
+	num.Mul(num, pow10(decimals0))
+	den := new(big.Int).Mul(q192, pow10(decimals1))
+
+	price := new(big.Rat).SetFrac(num, den)
+	return &Price{
+		Token1PerToken0: formatPrice(price),
+		Token0PerToken1: formatPrice(new(big.Rat).Inv(price)),
+	}
+}
+
+// PriceAtTick returns the price at the lower bound of a tick
+func PriceAtTick(tick int, decimals0, decimals1 uint8) (*Price, error) {
+	sqrtPriceX96, err := tickmath.GetSqrtRatioAtTick(tick)
+	if err != nil {
+		return nil, err
+	}
+	return PriceFromSqrtPriceX96(sqrtPriceX96, decimals0, decimals1), nil
+}
+
+// formatPrice renders a positive price with priceSignificantDigits
+// significant digits in plain notation, without trailing zeros
+func formatPrice(r *big.Rat) string {
+	// Integer digits of the price, or minus the leading zeros of the fraction.
+	// The difference of the digit counts is either that or one less.
+	magnitude := len(r.Num().String()) - len(r.Denom().String())
+	num, denom := new(big.Int).Set(r.Num()), new(big.Int).Set(r.Denom())
+	if magnitude >= 0 {
+		denom.Mul(denom, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(magnitude)), nil))
+	} else {
+		num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-magnitude)), nil))
+	}
+	if num.Cmp(denom) >= 0 {
+		magnitude++
+	}
+
+	places := priceSignificantDigits - magnitude
+	if places < 0 {
+		places = 0
+	}
+
+	s := r.FloatString(places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
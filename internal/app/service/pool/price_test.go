@@ -0,0 +1,91 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceFromSqrtPriceX96(t *testing.T) {
+	testCases := []struct {
+		name         string
+		sqrtPriceX96 string
+		decimals0    uint8
+		decimals1    uint8
+		expected     *Price
+	}{
+		{
+			name:         "parity",
+			sqrtPriceX96: "79228162514264337593543950336",
+			decimals0:    18,
+			decimals1:    18,
+			expected:     &Price{Token1PerToken0: "1", Token0PerToken1: "1"},
+		},
+		{
+			name:         "USDC/WETH",
+			sqrtPriceX96: "1350174849792634181862360983626536",
+			decimals0:    6,
+			decimals1:    18,
+			expected:     &Price{Token1PerToken0: "0.000290416214657745054", Token0PerToken1: "3443.33391018989095"},
+		},
+		{
+			name:         "uninitialized",
+			sqrtPriceX96: "0",
+			decimals0:    6,
+			decimals1:    18,
+			expected:     nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqrtPriceX96, _ := new(big.Int).SetString(tc.sqrtPriceX96, 10)
+			assert.Equal(t, tc.expected, PriceFromSqrtPriceX96(sqrtPriceX96, tc.decimals0, tc.decimals1))
+		})
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		price    string
+		expected string
+	}{
+		{name: "zero", price: "0", expected: "0"},
+		{name: "integer", price: "3443", expected: "3443"},
+		// 18 significant digits whatever the magnitude
+		{name: "thousands", price: "10000000/2903", expected: "3444.7123665173958"},
+		{name: "tens", price: "200/3", expected: "66.6666666666666667"},
+		{name: "units", price: "8/3", expected: "2.66666666666666667"},
+		{name: "fraction", price: "2/3", expected: "0.666666666666666667"},
+		{name: "small", price: "1/3000", expected: "0.000333333333333333333"},
+		{name: "large", price: "1000000000000000000/3", expected: "333333333333333333"},
+		{name: "more integer digits than significant", price: "10000000000000000000/3", expected: "3333333333333333333"},
+		{name: "rounded to the next power of ten", price: "9.9999999999999999999", expected: "10"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			price, ok := new(big.Rat).SetString(tc.price)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, formatPrice(price))
+		})
+	}
+}
+
+func TestPriceAtTick(t *testing.T) {
+	price, err := PriceAtTick(0, 6, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, &Price{Token1PerToken0: "1", Token0PerToken1: "1"}, price)
+
+	// 1.0001^6932 is just above 2
+	price, err = PriceAtTick(6932, 18, 18)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.00003632383094732", price.Token1PerToken0)
+
+	_, err = PriceAtTick(887273, 18, 18)
+	assert.Error(t, err)
+}
@@ -0,0 +1,323 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/quote_mock.go uniswapper/internal/app/service/pool IQuoteService
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"uniswapper/internal/app/db/dto/numeric"
+	"uniswapper/internal/app/service/v3math/swapmath"
+	"uniswapper/internal/app/service/v3math/tickbitmap"
+	"uniswapper/internal/app/service/v3math/tickmath"
+)
+
+var (
+	// ErrTokenNotInPool is returned when quoting a token the pool does not trade
+	ErrTokenNotInPool = errors.New("token is not traded by the pool")
+	// ErrInsufficientPoolLiquidity is returned when the swap would exhaust
+	// the liquidity of the pool before the amount is filled
+	ErrInsufficientPoolLiquidity = errors.New("pool liquidity cannot fill the amount")
+	// ErrTickMapBehind is returned when the stored tick liquidity does not
+	// add up to the on-chain liquidity yet, as events are still being ingested
+	ErrTickMapBehind = errors.New("tick liquidity is behind the chain")
+)
+
+// Quote is the simulated outcome of swapping against a pool. PriceImpact is
+// the shortfall of the output from the amount in valued at the price before
+// the swap, fees included, in percent.
+type Quote struct {
+	Address                 string         `json:"address"`
+	BlockNumber             uint64         `json:"block_number"`
+	TokenIn                 string         `json:"token_in"`
+	TokenOut                string         `json:"token_out"`
+	ExactInput              bool           `json:"exact_input"`
+	AmountIn                numeric.BigInt `json:"amount_in"`
+	AmountOut               numeric.BigInt `json:"amount_out"`
+	FeeAmount               numeric.BigInt `json:"fee_amount"`
+	SqrtPriceX96After       numeric.BigInt `json:"sqrt_price_x96_after"`
+	TickAfter               int            `json:"tick_after"`
+	InitializedTicksCrossed int            `json:"initialized_ticks_crossed"`
+	PriceImpact             string         `json:"price_impact"`
+	PriceAfter              *Price         `json:"price_after"`
+}
+
+// IQuoteService quotes swaps without calling the Quoter contract
+type IQuoteService interface {
+	GetQuote(ctx context.Context, address, tokenIn string, amount *big.Int, exactInput bool) (*Quote, error)
+}
+
+// QuoteService runs the swap loop of the pool contract over its current
+// state and the tick liquidity rebuilt from events. The state comes from the
+// cached state reads, so quoting costs no RPC call per request.
+type QuoteService struct {
+	PoolState IPoolStateService
+	Liquidity ILiquidityService
+	Metadata  IPoolMetadataService
+}
+
+func NewQuoteService(poolState IPoolStateService, liquidity ILiquidityService, metadata IPoolMetadataService) IQuoteService {
+	return &QuoteService{
+		PoolState: poolState,
+		Liquidity: liquidity,
+		Metadata:  metadata,
+	}
+}
+
+// GetQuote simulates swapping amount of tokenIn in for the other token of
+// the pool, or swapping in for amount of the other token if not exactInput
+func (s *QuoteService) GetQuote(ctx context.Context, address, tokenIn string, amount *big.Int, exactInput bool) (*Quote, error) {
+	metadata, err := s.Metadata.GetPoolMetadata(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var zeroForOne bool
+	switch {
+	case strings.EqualFold(tokenIn, metadata.Token0.Address):
+		zeroForOne = true
+	case strings.EqualFold(tokenIn, metadata.Token1.Address):
+		zeroForOne = false
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotInPool, tokenIn)
+	}
+
+	state, err := s.PoolState.GetPoolState(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	distribution, err := s.Liquidity.GetLiquidity(ctx, address, &state.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := NewSwapPool(state, distribution.Ticks, metadata.Fee, int(metadata.TickSpacing))
+	if err != nil {
+		return nil, err
+	}
+
+	amountSpecified := new(big.Int).Set(amount)
+	if !exactInput {
+		amountSpecified.Neg(amountSpecified)
+	}
+	result, err := pool.Swap(zeroForOne, amountSpecified)
+	if err != nil {
+		return nil, err
+	}
+
+	amountIn, amountOut := result.Amount0, new(big.Int).Neg(result.Amount1)
+	tokenOut := metadata.Token1.Address
+	if !zeroForOne {
+		amountIn, amountOut = result.Amount1, new(big.Int).Neg(result.Amount0)
+		tokenIn, tokenOut = metadata.Token1.Address, metadata.Token0.Address
+	} else {
+		tokenIn = metadata.Token0.Address
+	}
+
+	// The swap stopped at the price limit with the amount partly filled
+	if (exactInput && amountIn.Cmp(amount) != 0) || (!exactInput && amountOut.Cmp(amount) != 0) {
+		return nil, ErrInsufficientPoolLiquidity
+	}
+
+	return &Quote{
+		Address:                 state.Address,
+		BlockNumber:             state.BlockNumber,
+		TokenIn:                 tokenIn,
+		TokenOut:                tokenOut,
+		ExactInput:              exactInput,
+		AmountIn:                numeric.NewBigInt(amountIn),
+		AmountOut:               numeric.NewBigInt(amountOut),
+		FeeAmount:               numeric.NewBigInt(result.FeeAmount),
+		SqrtPriceX96After:       numeric.NewBigInt(result.SqrtPriceX96),
+		TickAfter:               result.Tick,
+		InitializedTicksCrossed: result.InitializedTicksCrossed,
+		PriceImpact:             priceImpact(state.Slot0.SqrtPriceX96.Big(), amountIn, amountOut, zeroForOne),
+		PriceAfter:              PriceFromSqrtPriceX96(result.SqrtPriceX96, metadata.Token0.Decimals, metadata.Token1.Decimals),
+	}, nil
+}
+
+// SwapPool is the part of the state of a pool that swaps read and change
+type SwapPool struct {
+	SqrtPriceX96 *big.Int
+	Tick         int
+	Liquidity    *big.Int
+	Fee          uint32
+	TickSpacing  int
+	bitmap       tickbitmap.TickBitmap
+	liquidityNet map[int]*big.Int
+}
+
+// SwapResult holds the token deltas of the pool, positive when the pool
+// receives tokens, and its state after the swap
+type SwapResult struct {
+	Amount0                 *big.Int
+	Amount1                 *big.Int
+	FeeAmount               *big.Int
+	SqrtPriceX96            *big.Int
+	Tick                    int
+	Liquidity               *big.Int
+	InitializedTicksCrossed int
+}
+
+// NewSwapPool builds a swappable pool from its state and initialized ticks,
+// checking that the ticks account for the liquidity in range
+func NewSwapPool(state *PoolState, ticks []TickLiquidity, fee uint32, tickSpacing int) (*SwapPool, error) {
+	if tickSpacing <= 0 {
+		return nil, fmt.Errorf("invalid tick spacing %d", tickSpacing)
+	}
+
+	pool := &SwapPool{
+		SqrtPriceX96: state.Slot0.SqrtPriceX96.Big(),
+		Tick:         int(state.Slot0.Tick),
+		Liquidity:    state.Liquidity.Big(),
+		Fee:          fee,
+		TickSpacing:  tickSpacing,
+		bitmap:       tickbitmap.TickBitmap{},
+		liquidityNet: make(map[int]*big.Int),
+	}
+
+	active := new(big.Int)
+	for _, tick := range ticks {
+		if err := pool.bitmap.FlipTick(int(tick.Tick), tickSpacing); err != nil {
+			return nil, fmt.Errorf("tick %d: %w", tick.Tick, err)
+		}
+		pool.liquidityNet[int(tick.Tick)] = tick.LiquidityNet.Big()
+		if int(tick.Tick) <= pool.Tick {
+			active = tick.LiquidityActive.Big()
+		}
+	}
+
+	if active.Cmp(pool.Liquidity) != 0 {
+		return nil, fmt.Errorf("%w: ticks hold %s in range, the pool %s", ErrTickMapBehind, active, pool.Liquidity)
+	}
+	return pool, nil
+}
+
+// Swap simulates UniswapV3Pool.swap without a price limit, as the Quoter
+// does: a positive amountSpecified is an exact input, a negative one an
+// exact output. The pool is not changed.
+func (p *SwapPool) Swap(zeroForOne bool, amountSpecified *big.Int) (*SwapResult, error) {
+	if amountSpecified.Sign() == 0 {
+		return nil, errors.New("amount must not be zero")
+	}
+	if p.SqrtPriceX96.Sign() == 0 {
+		return nil, errors.New("pool is not initialized")
+	}
+
+	sqrtPriceLimitX96 := new(big.Int).Sub(tickmath.MAX_SQRT_RATIO, big.NewInt(1))
+	if zeroForOne {
+		sqrtPriceLimitX96 = new(big.Int).Add(tickmath.MIN_SQRT_RATIO, big.NewInt(1))
+	}
+	exactInput := amountSpecified.Sign() > 0
+
+	var (
+		amountSpecifiedRemaining = new(big.Int).Set(amountSpecified)
+		amountCalculated         = new(big.Int)
+		feeAmount                = new(big.Int)
+		sqrtPriceX96             = new(big.Int).Set(p.SqrtPriceX96)
+		tick                     = p.Tick
+		liquidity                = new(big.Int).Set(p.Liquidity)
+		crossed                  int
+	)
+
+	// Continue swapping as long as the amount is not filled and the price
+	// limit is not reached
+	for amountSpecifiedRemaining.Sign() != 0 && sqrtPriceX96.Cmp(sqrtPriceLimitX96) != 0 {
+		sqrtPriceStartX96 := sqrtPriceX96
+
+		tickNext, initialized := p.bitmap.NextInitializedTickWithinOneWord(tick, p.TickSpacing, zeroForOne)
+		// The bitmap is not aware of the tick bounds
+		if tickNext < tickmath.MIN_TICK {
+			tickNext = tickmath.MIN_TICK
+		} else if tickNext > tickmath.MAX_TICK {
+			tickNext = tickmath.MAX_TICK
+		}
+
+		sqrtPriceNextX96, err := tickmath.GetSqrtRatioAtTick(tickNext)
+		if err != nil {
+			return nil, err
+		}
+
+		sqrtPriceTargetX96 := sqrtPriceNextX96
+		if (zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) < 0) || (!zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) > 0) {
+			sqrtPriceTargetX96 = sqrtPriceLimitX96
+		}
+
+		step, err := swapmath.ComputeSwapStep(sqrtPriceX96, sqrtPriceTargetX96, liquidity, amountSpecifiedRemaining, p.Fee)
+		if err != nil {
+			return nil, err
+		}
+		sqrtPriceX96 = step.SqrtRatioNextX96
+		feeAmount.Add(feeAmount, step.FeeAmount)
+
+		if exactInput {
+			amountSpecifiedRemaining.Sub(amountSpecifiedRemaining, new(big.Int).Add(step.AmountIn, step.FeeAmount))
+			amountCalculated.Sub(amountCalculated, step.AmountOut)
+		} else {
+			amountSpecifiedRemaining.Add(amountSpecifiedRemaining, step.AmountOut)
+			amountCalculated.Add(amountCalculated, new(big.Int).Add(step.AmountIn, step.FeeAmount))
+		}
+
+		if sqrtPriceX96.Cmp(sqrtPriceNextX96) == 0 {
+			// Cross the tick, moving its net liquidity in or out of range
+			if initialized {
+				liquidityNet := new(big.Int).Set(p.liquidityNet[tickNext])
+				if zeroForOne {
+					liquidityNet.Neg(liquidityNet)
+				}
+				liquidity.Add(liquidity, liquidityNet)
+				if liquidity.Sign() < 0 {
+					return nil, fmt.Errorf("%w: liquidity is negative after crossing tick %d", ErrTickMapBehind, tickNext)
+				}
+				crossed++
+			}
+
+			tick = tickNext
+			if zeroForOne {
+				tick = tickNext - 1
+			}
+		} else if sqrtPriceX96.Cmp(sqrtPriceStartX96) != 0 {
+			// The price moved without reaching the next tick
+			if tick, err = tickmath.GetTickAtSqrtRatio(sqrtPriceX96); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	filled := new(big.Int).Sub(amountSpecified, amountSpecifiedRemaining)
+	result := &SwapResult{
+		Amount0:                 filled,
+		Amount1:                 amountCalculated,
+		FeeAmount:               feeAmount,
+		SqrtPriceX96:            sqrtPriceX96,
+		Tick:                    tick,
+		Liquidity:               liquidity,
+		InitializedTicksCrossed: crossed,
+	}
+	if zeroForOne != exactInput {
+		result.Amount0, result.Amount1 = amountCalculated, filled
+	}
+	return result, nil
+}
+
+// priceImpact compares amountOut to amountIn valued at the price before the
+// swap, in percent
+func priceImpact(sqrtPriceX96, amountIn, amountOut *big.Int, zeroForOne bool) string {
+	price := new(big.Rat).SetFrac(new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96), q192)
+	if !zeroForOne {
+		price.Inv(price)
+	}
+
+	quoted := new(big.Rat).Mul(new(big.Rat).SetInt(amountIn), price)
+	if quoted.Sign() == 0 {
+		return "0"
+	}
+
+	shortfall := new(big.Rat).Sub(quoted, new(big.Rat).SetInt(amountOut))
+	if shortfall.Sign() <= 0 {
+		return "0"
+	}
+	impact := new(big.Rat).Quo(shortfall, quoted)
+	return formatPrice(impact.Mul(impact, big.NewRat(100, 1)))
+}
@@ -0,0 +1,254 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+	"uniswapper/internal/app/db/dto/numeric"
+	"uniswapper/internal/app/service/v3math/liquidityamounts"
+	"uniswapper/internal/app/service/v3math/swapmath"
+	"uniswapper/internal/app/service/v3math/tickmath"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	wideLiquidity   = big.NewInt(1e18)
+	narrowLiquidity = big.NewInt(5e17)
+)
+
+// testSwapPool builds a pool at tick 0 with a position over [-600, 600)
+// and, if narrow, another over [-60, 60)
+func testSwapPool(t *testing.T, narrow bool) *SwapPool {
+	ticks := []TickLiquidity{
+		{Tick: -600, LiquidityNet: numeric.NewBigInt(wideLiquidity), LiquidityActive: numeric.NewBigInt(wideLiquidity)},
+		{Tick: 600, LiquidityNet: numeric.NewBigInt(new(big.Int).Neg(wideLiquidity)), LiquidityActive: numeric.NewBigInt(new(big.Int))},
+	}
+	liquidity := new(big.Int).Set(wideLiquidity)
+	if narrow {
+		liquidity.Add(liquidity, narrowLiquidity)
+		ticks = []TickLiquidity{
+			ticks[0],
+			{Tick: -60, LiquidityNet: numeric.NewBigInt(narrowLiquidity), LiquidityActive: numeric.NewBigInt(liquidity)},
+			{Tick: 60, LiquidityNet: numeric.NewBigInt(new(big.Int).Neg(narrowLiquidity)), LiquidityActive: numeric.NewBigInt(wideLiquidity)},
+			ticks[1],
+		}
+	}
+
+	state := &PoolState{
+		Slot0:     Slot0{SqrtPriceX96: numeric.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 96))},
+		Liquidity: numeric.NewBigInt(liquidity),
+	}
+
+	pool, err := NewSwapPool(state, ticks, 3000, 60)
+	assert.NoError(t, err)
+	return pool
+}
+
+func sqrtRatioAtTick(t *testing.T, tick int) *big.Int {
+	sqrtPriceX96, err := tickmath.GetSqrtRatioAtTick(tick)
+	assert.NoError(t, err)
+	return sqrtPriceX96
+}
+
+func TestSwapWithinRange(t *testing.T) {
+	pool := testSwapPool(t, false)
+	amountIn := big.NewInt(1e15)
+
+	result, err := pool.Swap(true, amountIn)
+	assert.NoError(t, err)
+
+	// A swap that stays within a range is a single step
+	step, err := swapmath.ComputeSwapStep(pool.SqrtPriceX96, sqrtRatioAtTick(t, -600), wideLiquidity, amountIn, 3000)
+	assert.NoError(t, err)
+	tick, err := tickmath.GetTickAtSqrtRatio(step.SqrtRatioNextX96)
+	assert.NoError(t, err)
+
+	assert.Equal(t, amountIn, result.Amount0)
+	assert.Equal(t, new(big.Int).Neg(step.AmountOut), result.Amount1)
+	assert.Equal(t, step.FeeAmount, result.FeeAmount)
+	assert.Equal(t, step.SqrtRatioNextX96, result.SqrtPriceX96)
+	assert.Equal(t, tick, result.Tick)
+	assert.Equal(t, wideLiquidity, result.Liquidity)
+	assert.Equal(t, 0, result.InitializedTicksCrossed)
+
+	// The pool is left unchanged
+	assert.Equal(t, 0, pool.Tick)
+	assert.Equal(t, wideLiquidity, pool.Liquidity)
+}
+
+func TestSwapCrossingTicks(t *testing.T) {
+	pool := testSwapPool(t, true)
+	amountIn := big.NewInt(1e16)
+	liquidity := new(big.Int).Add(wideLiquidity, narrowLiquidity)
+
+	result, err := pool.Swap(true, amountIn)
+	assert.NoError(t, err)
+
+	// The swap exhausts the narrow position, then continues in the wide one
+	first, err := swapmath.ComputeSwapStep(pool.SqrtPriceX96, sqrtRatioAtTick(t, -60), liquidity, amountIn, 3000)
+	assert.NoError(t, err)
+	assert.Equal(t, sqrtRatioAtTick(t, -60), first.SqrtRatioNextX96)
+
+	remaining := new(big.Int).Sub(amountIn, new(big.Int).Add(first.AmountIn, first.FeeAmount))
+	second, err := swapmath.ComputeSwapStep(first.SqrtRatioNextX96, sqrtRatioAtTick(t, -600), wideLiquidity, remaining, 3000)
+	assert.NoError(t, err)
+
+	amountOut := new(big.Int).Add(first.AmountOut, second.AmountOut)
+	assert.Equal(t, amountIn, result.Amount0)
+	assert.Equal(t, amountOut.Neg(amountOut), result.Amount1)
+	assert.Equal(t, new(big.Int).Add(first.FeeAmount, second.FeeAmount), result.FeeAmount)
+	assert.Equal(t, second.SqrtRatioNextX96, result.SqrtPriceX96)
+	assert.Equal(t, wideLiquidity, result.Liquidity)
+	assert.Equal(t, 1, result.InitializedTicksCrossed)
+	assert.Less(t, result.Tick, -60)
+}
+
+func TestSwapExactOutput(t *testing.T) {
+	pool := testSwapPool(t, true)
+	amountOut := big.NewInt(1e16)
+
+	result, err := pool.Swap(false, new(big.Int).Neg(amountOut))
+	assert.NoError(t, err)
+
+	assert.Equal(t, new(big.Int).Neg(amountOut), result.Amount0)
+	assert.Equal(t, 1, result.Amount1.Sign())
+	assert.Equal(t, wideLiquidity, result.Liquidity)
+	assert.Equal(t, 1, result.InitializedTicksCrossed)
+	assert.GreaterOrEqual(t, result.Tick, 60)
+}
+
+func TestSwapExhaustingLiquidity(t *testing.T) {
+	pool := testSwapPool(t, false)
+	amountIn := new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)
+
+	result, err := pool.Swap(false, amountIn)
+	assert.NoError(t, err)
+
+	// The swap stops at the price limit with part of the amount left
+	assert.Equal(t, -1, result.Amount1.Cmp(amountIn))
+	assert.Equal(t, new(big.Int).Sub(tickmath.MAX_SQRT_RATIO, big.NewInt(1)), result.SqrtPriceX96)
+	assert.Equal(t, 0, result.Liquidity.Sign())
+	assert.Equal(t, 1, result.InitializedTicksCrossed)
+}
+
+// quoterFixturePool builds the pool of the QuoterV2 tests of
+// v3-periphery (createPoolWithMultiplePositions): a 0.3% pool at price 1
+// with 1e6 of each token minted over the full range and 100 of each over
+// [-60, 60) and [-120, 120), the liquidity computed the way the position
+// manager mints it
+func quoterFixturePool(t *testing.T) *SwapPool {
+	sqrtPriceX96 := new(big.Int).Lsh(big.NewInt(1), 96)
+	minTick, maxTick := -887220, 887220
+
+	positions := []struct {
+		lower, upper int
+		amount       int64
+	}{
+		{lower: minTick, upper: maxTick, amount: 1_000_000},
+		{lower: -60, upper: 60, amount: 100},
+		{lower: -120, upper: 120, amount: 100},
+	}
+
+	liquidityNet := make(map[int]*big.Int)
+	active := new(big.Int)
+	for _, p := range positions {
+		liquidity, err := liquidityamounts.GetLiquidityForAmounts(
+			sqrtPriceX96, sqrtRatioAtTick(t, p.lower), sqrtRatioAtTick(t, p.upper), big.NewInt(p.amount), big.NewInt(p.amount),
+		)
+		assert.NoError(t, err)
+
+		for _, tick := range []int{p.lower, p.upper} {
+			if liquidityNet[tick] == nil {
+				liquidityNet[tick] = new(big.Int)
+			}
+		}
+		liquidityNet[p.lower].Add(liquidityNet[p.lower], liquidity)
+		liquidityNet[p.upper].Sub(liquidityNet[p.upper], liquidity)
+		active.Add(active, liquidity)
+	}
+
+	var ticks []TickLiquidity
+	inRange := new(big.Int)
+	for _, tick := range []int{minTick, -120, -60, 60, 120, maxTick} {
+		inRange.Add(inRange, liquidityNet[tick])
+		ticks = append(ticks, TickLiquidity{
+			Tick:            int64(tick),
+			LiquidityNet:    numeric.NewBigInt(liquidityNet[tick]),
+			LiquidityActive: numeric.NewBigInt(new(big.Int).Set(inRange)),
+		})
+	}
+
+	state := &PoolState{
+		Slot0:     Slot0{SqrtPriceX96: numeric.NewBigInt(sqrtPriceX96)},
+		Liquidity: numeric.NewBigInt(active),
+	}
+	pool, err := NewSwapPool(state, ticks, 3000, 60)
+	assert.NoError(t, err)
+	return pool
+}
+
+// TestSwapQuoterV2 checks the simulation against the results of the QuoterV2
+// contract in the v3-periphery tests (QuoterV2.spec.ts), quoted over the
+// deployed fixture pool
+func TestSwapQuoterV2(t *testing.T) {
+	testCases := []struct {
+		name       string
+		zeroForOne bool
+		// amount is the exact input, or the exact output if negative
+		amount            int64
+		amountIn          int64
+		amountOut         int64
+		sqrtPriceX96After string
+		ticksCrossed      int
+	}{
+		{name: "exact input crossing two ticks", zeroForOne: true, amount: 10000, amountIn: 10000, amountOut: 9871, sqrtPriceX96After: "78461846509168490764501028180", ticksCrossed: 2},
+		{name: "exact input ending on an initialized tick", zeroForOne: true, amount: 6200, amountIn: 6200, amountOut: 6143, sqrtPriceX96After: "78757224507315167622282810783", ticksCrossed: 1},
+		{name: "exact input crossing one tick", zeroForOne: true, amount: 4000, amountIn: 4000, amountOut: 3971, sqrtPriceX96After: "78926452400586371254602774705", ticksCrossed: 1},
+		{name: "exact input within the range", zeroForOne: true, amount: 10, amountIn: 10, amountOut: 8, sqrtPriceX96After: "79227483487511329217250071027"},
+		{name: "exact input one for zero crossing two ticks", amount: 10000, amountIn: 10000, amountOut: 9871, sqrtPriceX96After: "80001962924147897865541384515", ticksCrossed: 2},
+		{name: "exact input one for zero ending on an initialized tick", amount: 6250, amountIn: 6250, amountOut: 6190, sqrtPriceX96After: "79705728824507063507279123685", ticksCrossed: 2},
+		{name: "exact output crossing two ticks", zeroForOne: true, amount: -15000, amountIn: 15273, amountOut: 15000, sqrtPriceX96After: "78055527257643669242286029831", ticksCrossed: 2},
+		{name: "exact output ending on an initialized tick", zeroForOne: true, amount: -6143, amountIn: 6200, amountOut: 6143, sqrtPriceX96After: "78757225449310403327341205211", ticksCrossed: 1},
+		{name: "exact output crossing one tick", zeroForOne: true, amount: -4000, amountIn: 4029, amountOut: 4000, sqrtPriceX96After: "78924219757724709840818372098", ticksCrossed: 1},
+		{name: "exact output one for zero crossing two ticks", amount: -15000, amountIn: 15273, amountOut: 15000, sqrtPriceX96After: "80418414376567919517220409857", ticksCrossed: 2},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			pool := quoterFixturePool(t)
+
+			result, err := pool.Swap(tc.zeroForOne, big.NewInt(tc.amount))
+			assert.NoError(t, err)
+
+			amountIn, amountOut := result.Amount0, new(big.Int).Neg(result.Amount1)
+			if !tc.zeroForOne {
+				amountIn, amountOut = result.Amount1, new(big.Int).Neg(result.Amount0)
+			}
+			assert.Equal(t, big.NewInt(tc.amountIn), amountIn)
+			assert.Equal(t, big.NewInt(tc.amountOut), amountOut)
+			assert.Equal(t, tc.sqrtPriceX96After, result.SqrtPriceX96.String())
+			assert.Equal(t, tc.ticksCrossed, result.InitializedTicksCrossed)
+		})
+	}
+}
+
+func TestNewSwapPoolBehind(t *testing.T) {
+	state := &PoolState{
+		Slot0:     Slot0{SqrtPriceX96: numeric.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 96))},
+		Liquidity: numeric.NewBigInt(wideLiquidity),
+	}
+
+	_, err := NewSwapPool(state, nil, 3000, 60)
+	assert.ErrorIs(t, err, ErrTickMapBehind)
+}
+
+func TestPriceImpact(t *testing.T) {
+	sqrtPriceX96 := new(big.Int).Lsh(big.NewInt(1), 96)
+
+	assert.Equal(t, "0", priceImpact(sqrtPriceX96, big.NewInt(1000), big.NewInt(1000), true))
+	assert.Equal(t, "0.3", priceImpact(sqrtPriceX96, big.NewInt(1000), big.NewInt(997), true))
+	// At a price of 4 token1 per token0, 1000 token1 are worth 250 token0
+	assert.Equal(t, "10", priceImpact(new(big.Int).Lsh(sqrtPriceX96, 1), big.NewInt(1000), big.NewInt(225), false))
+}
@@ -0,0 +1,282 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/registry_mock.go uniswapper/internal/app/service/pool IPoolRegistry
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"uniswapper/internal/app/constants"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/rpc"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrPoolNotFound       = errors.New("pool is not tracked")
+	ErrPoolAlreadyTracked = errors.New("pool is already tracked")
+	ErrNotAV3Pool         = errors.New("address is not a Uniswap V3 pool")
+)
+
+// IPoolRegistry manages the set of pools ingested by the running service
+type IPoolRegistry interface {
+	GetPools(ctx context.Context) ([]posts.Pool, error)
+	GetPool(ctx context.Context, address string) (*posts.Pool, error)
+	AddPool(ctx context.Context, address string) (*posts.Pool, error)
+	RemovePool(ctx context.Context, address string) error
+	SetPaused(ctx context.Context, address string, paused bool) (*posts.Pool, error)
+	Seed(ctx context.Context, addresses []common.Address) error
+	Changed() <-chan struct{}
+}
+
+type PoolRegistry struct {
+	client           *rpc.Client
+	poolABI          abi.ABI
+	factoryABI       abi.ABI
+	factory          common.Address
+	changed          chan struct{}
+	RegistryDBClient pool.IPoolRegistryRepository
+}
+
+func NewPoolRegistry(
+	ctx context.Context,
+	rpcClient *rpc.Client,
+	registryDBClient pool.IPoolRegistryRepository,
+) IPoolRegistry {
+	log := logger.Logger(ctx)
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	if err != nil {
+		log.Fatalf("Failed to parse contract ABI: %v", err)
+	}
+
+	factoryABI, err := abi.JSON(strings.NewReader(uniswapV3FactoryABI))
+	if err != nil {
+		log.Fatalf("Failed to parse factory ABI: %v", err)
+	}
+
+	if !common.IsHexAddress(constants.Config.PoolConfig.POOL_FACTORY_ADDRESS) {
+		log.Fatalf("Invalid pool factory address %q", constants.Config.PoolConfig.POOL_FACTORY_ADDRESS)
+	}
+
+	return &PoolRegistry{
+		client:           rpcClient,
+		poolABI:          poolABI,
+		factoryABI:       factoryABI,
+		factory:          common.HexToAddress(constants.Config.PoolConfig.POOL_FACTORY_ADDRESS),
+		changed:          make(chan struct{}, 1),
+		RegistryDBClient: registryDBClient,
+	}
+}
+
+// GetPools returns every tracked pool, paused or not
+func (r *PoolRegistry) GetPools(ctx context.Context) ([]posts.Pool, error) {
+	return r.RegistryDBClient.GetTrackedPools(ctx)
+}
+
+// GetPool returns the registry entry of any pool. Pools unknown to the
+// registry are inspected on-chain and stored untracked.
+func (r *PoolRegistry) GetPool(ctx context.Context, address string) (*posts.Pool, error) {
+	addr := common.HexToAddress(address)
+
+	existing, err := r.RegistryDBClient.GetPool(ctx, addr.String())
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	p, err := r.inspect(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	p.Source = posts.POOL_SOURCE_LOOKUP
+
+	if _, err := r.RegistryDBClient.StorePool(ctx, *p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// AddPool starts tracking a pool. Pools unknown to the registry are first
+// checked on-chain to really be pools deployed by the factory.
+func (r *PoolRegistry) AddPool(ctx context.Context, address string) (*posts.Pool, error) {
+	p, err := r.add(ctx, common.HexToAddress(address), posts.POOL_SOURCE_API)
+	if err != nil {
+		return nil, err
+	}
+	r.notify()
+	return p, nil
+}
+
+// RemovePool stops tracking a pool. Its stored history is kept so that
+// adding it again resumes from its checkpoint.
+func (r *PoolRegistry) RemovePool(ctx context.Context, address string) error {
+	p, err := r.tracked(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	if err := r.RegistryDBClient.SetTracked(ctx, p.Address, false); err != nil {
+		return err
+	}
+	r.notify()
+	return nil
+}
+
+// SetPaused pauses or resumes the ingestion of a tracked pool
+func (r *PoolRegistry) SetPaused(ctx context.Context, address string, paused bool) (*posts.Pool, error) {
+	p, err := r.tracked(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Paused != paused {
+		if err := r.RegistryDBClient.SetPaused(ctx, p.Address, paused); err != nil {
+			return nil, err
+		}
+		p.Paused = paused
+		r.notify()
+	}
+	return p, nil
+}
+
+// Changed fires after the tracked pools were modified
+func (r *PoolRegistry) Changed() <-chan struct{} {
+	return r.changed
+}
+
+// Seed adds the configured pools the registry does not know yet. Pools
+// removed through the API stay removed.
+func (r *PoolRegistry) Seed(ctx context.Context, addresses []common.Address) error {
+	log := logger.Logger(ctx)
+
+	for _, address := range addresses {
+		existing, err := r.RegistryDBClient.GetPool(ctx, address.String())
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+
+		if _, err := r.add(ctx, address, posts.POOL_SOURCE_CONFIG); err != nil {
+			if errors.Is(err, ErrNotAV3Pool) {
+				log.Errorf("Skipping configured pool %s: %v", address.String(), err)
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// tracked returns the registry entry of a tracked pool
+func (r *PoolRegistry) tracked(ctx context.Context, address string) (*posts.Pool, error) {
+	p, err := r.RegistryDBClient.GetPool(ctx, common.HexToAddress(address).String())
+	if err != nil {
+		return nil, err
+	}
+	if p == nil || !p.Tracked {
+		return nil, ErrPoolNotFound
+	}
+	return p, nil
+}
+
+func (r *PoolRegistry) add(ctx context.Context, address common.Address, source string) (*posts.Pool, error) {
+	existing, err := r.RegistryDBClient.GetPool(ctx, address.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		if existing.Tracked {
+			return nil, ErrPoolAlreadyTracked
+		}
+		if err := r.RegistryDBClient.SetTracked(ctx, existing.Address, true); err != nil {
+			return nil, err
+		}
+		existing.Tracked = true
+		return existing, nil
+	}
+
+	p, err := r.inspect(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	p.Tracked = true
+	p.Source = source
+
+	if _, err := r.RegistryDBClient.StorePool(ctx, *p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// inspect reads the immutables of a pool and checks that the factory it
+// reports maps its tokens and fee back to the same address
+func (r *PoolRegistry) inspect(ctx context.Context, address common.Address) (*posts.Pool, error) {
+	factory, err := r.callPool(ctx, address, "factory")
+	if err != nil {
+		return nil, err
+	}
+	if factory.(common.Address) != r.factory {
+		return nil, fmt.Errorf("%w: deployed by %s", ErrNotAV3Pool, factory.(common.Address).String())
+	}
+
+	token0, err := r.callPool(ctx, address, "token0")
+	if err != nil {
+		return nil, err
+	}
+	token1, err := r.callPool(ctx, address, "token1")
+	if err != nil {
+		return nil, err
+	}
+	fee, err := r.callPool(ctx, address, "fee")
+	if err != nil {
+		return nil, err
+	}
+	tickSpacing, err := r.callPool(ctx, address, "tickSpacing")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := callContract(ctx, r.client, r.factoryABI, r.factory, "getPool", nil, token0, token1, fee)
+	if err != nil {
+		return nil, err
+	}
+	if out[0].(common.Address) != address {
+		return nil, fmt.Errorf("%w: factory does not know it", ErrNotAV3Pool)
+	}
+
+	return &posts.Pool{
+		Address:     address.String(),
+		Token0:      token0.(common.Address).String(),
+		Token1:      token1.(common.Address).String(),
+		Fee:         uint32(fee.(*big.Int).Uint64()),
+		TickSpacing: int32(tickSpacing.(*big.Int).Int64()),
+	}, nil
+}
+
+// callPool calls a pool view function without arguments and returns its
+// single output
+func (r *PoolRegistry) callPool(ctx context.Context, address common.Address, method string) (interface{}, error) {
+	out, err := callPool(ctx, r.client, r.poolABI, address, method, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != 1 {
+		return nil, fmt.Errorf("%w: %s() returned %d values", ErrNotAV3Pool, method, len(out))
+	}
+	return out[0], nil
+}
+
+func (r *PoolRegistry) notify() {
+	select {
+	case r.changed <- struct{}{}:
+	default:
+	}
+}
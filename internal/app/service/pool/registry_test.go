@@ -0,0 +1,76 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"uniswapper/internal/app/service/util/testutils/ethnode"
+	mockDB "uniswapper/internal/app/service/util/testutils/mocks/repository/pool"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedFailedCall(t *testing.T) {
+	setupTest(t)
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	assert.NoError(t, err)
+	factoryABI, err := abi.JSON(strings.NewReader(uniswapV3FactoryABI))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		result []byte
+		err    error
+		// skipped is whether the pool is skipped as not a pool
+		skipped bool
+	}{
+		{name: "reverted", err: ethnode.Revert(""), skipped: true},
+		// An account without code returns nothing
+		{name: "no code", result: []byte{}, skipped: true},
+		{name: "rate limited", err: &ethnode.Error{Code: -32005, Message: "limit exceeded"}},
+		{name: "pruned state", err: &ethnode.Error{Code: -32000, Message: "missing trie node"}},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestNode(t, 10)
+			node.Call = func(common.Address, []byte, uint64) ([]byte, error) {
+				return tc.result, tc.err
+			}
+
+			ctrl := gomock.NewController(t)
+			registry := mockDB.NewMockIPoolRegistryRepository(ctrl)
+			r := &PoolRegistry{
+				client:           client,
+				poolABI:          poolABI,
+				factoryABI:       factoryABI,
+				factory:          common.HexToAddress(uniswapV3Factory),
+				changed:          make(chan struct{}, 1),
+				RegistryDBClient: registry,
+			}
+
+			pool := common.HexToAddress(usdcWethPool)
+			registry.EXPECT().GetPool(gomock.Any(), pool.String()).Return(nil, nil).AnyTimes()
+
+			_, err := r.inspect(context.Background(), pool)
+			assert.Equal(t, tc.skipped, errors.Is(err, ErrNotAV3Pool))
+
+			// Only an address that is not a pool is skipped, the seed is
+			// retried on any other failure
+			err = r.Seed(context.Background(), []common.Address{pool})
+			if tc.skipped {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.False(t, errors.Is(err, ErrNotAV3Pool))
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// minReorgWindow is the minimum number of recent block hashes kept to detect reorgs
+const minReorgWindow uint64 = 64
+
+// blockTracker remembers the hashes of the most recent canonical blocks
+type blockTracker struct {
+	size   uint64
+	hashes map[uint64]common.Hash
+}
+
+func newBlockTracker(confirmations uint64) *blockTracker {
+	size := confirmations * 2
+	if size < minReorgWindow {
+		size = minReorgWindow
+	}
+	return &blockTracker{size: size, hashes: make(map[uint64]common.Hash)}
+}
+
+func (t *blockTracker) add(number uint64, hash common.Hash) {
+	t.hashes[number] = hash
+	for n := range t.hashes {
+		if n+t.size <= number {
+			delete(t.hashes, n)
+		}
+	}
+}
+
+func (t *blockTracker) get(number uint64) (common.Hash, bool) {
+	hash, ok := t.hashes[number]
+	return hash, ok
+}
+
+// truncate forgets every block after number
+func (t *blockTracker) truncate(number uint64) {
+	for n := range t.hashes {
+		if n > number {
+			delete(t.hashes, n)
+		}
+	}
+}
+
+// handleHead checks a new chain head against the tracked blocks, rolling back
+// and replaying the orphaned range on a reorg, and advances the checkpoints
+// to the last block with enough confirmations to be considered final
+func (u *UniswapV3Pool) handleHead(ctx context.Context, header *types.Header) error {
+	log := logger.Logger(ctx)
+	number := header.Number.Uint64()
+
+	reorged := false
+	if hash, ok := u.blocks.get(number); ok && hash != header.Hash() {
+		reorged = true
+	}
+	if parent, ok := u.blocks.get(number - 1); ok && parent != header.ParentHash {
+		reorged = true
+	}
+
+	if reorged && number > 0 {
+		ancestor, err := u.findCommonAncestor(ctx, number-1)
+		if err != nil {
+			return err
+		}
+
+		log.Warnf("Chain reorganization detected at block %d, rolling back to block %d", number, ancestor)
+		// Replay up to the new head itself, as its logs may have been
+		// delivered by the log subscription before this header
+		if err := u.rollback(ctx, ancestor, number); err != nil {
+			return err
+		}
+	}
+
+	u.blocks.add(number, header.Hash())
+
+	if number > u.confirmations {
+		return u.confirm(ctx, number-u.confirmations)
+	}
+	return nil
+}
+
+// findCommonAncestor walks back from number until the tracked hash matches
+// the canonical chain again
+func (u *UniswapV3Pool) findCommonAncestor(ctx context.Context, number uint64) (uint64, error) {
+	for ; number > 0; number-- {
+		tracked, ok := u.blocks.get(number)
+		if !ok {
+			return number, nil
+		}
+
+		header, err := u.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return 0, err
+		}
+		if header.Hash() == tracked {
+			return number, nil
+		}
+	}
+	return 0, nil
+}
+
+// rollback deletes everything stored after ancestor, rewinds the checkpoints
+// and replays (ancestor, to] from the canonical chain. Liquidity seeds that
+// were deleted are read again at ancestor.
+func (u *UniswapV3Pool) rollback(ctx context.Context, ancestor, to uint64) error {
+	for _, address := range u.following {
+		if err := u.deleteAfterBlock(ctx, address, ancestor); err != nil {
+			return err
+		}
+		u.seedLiquidity(ctx, address, ancestor)
+
+		checkpoint, err := u.CheckpointDBClient.GetCheckpoint(ctx, address.String())
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil && checkpoint.BlockNumber > ancestor {
+			if err := u.CheckpointDBClient.StoreCheckpoint(ctx, address.String(), ancestor); err != nil {
+				return err
+			}
+		}
+	}
+
+	u.blocks.truncate(ancestor)
+	u.blockTimes.reset()
+	u.feeProtocols = make(map[common.Address]uint8)
+
+	if to > ancestor {
+		if err := u.backfill(ctx, u.following, ancestor+1, to); err != nil {
+			return err
+		}
+	}
+	return u.pruneOrphanedPools(ctx, ancestor)
+}
+
+// deleteAfterBlock removes all rows of a pool newer than number. Pools
+// discovered by the factory are only pruned once the blocks were replayed,
+// see pruneOrphanedPools.
+func (u *UniswapV3Pool) deleteAfterBlock(ctx context.Context, address common.Address, number uint64) error {
+	if u.isFactory(address) {
+		return nil
+	}
+
+	if err := u.PoolEventsDBClient.DeleteEventsAfterBlock(ctx, address.String(), number); err != nil {
+		return err
+	}
+	if err := u.CandleDBClient.RebuildCandlesAfterBlock(ctx, address.String(), number); err != nil {
+		return err
+	}
+	if err := u.RollupDBClient.RebuildRollupsAfterBlock(ctx, address.String(), number); err != nil {
+		return err
+	}
+	if err := u.PoolLogsDBClient.DeletePoolLogsAfterBlock(ctx, address.String(), number); err != nil {
+		return err
+	}
+	u.policy.reset(address, number)
+	// A seed read at an orphaned block is taken again at number
+	u.seeder.storing.Lock()
+	err := u.LiquidityDBClient.DeleteSeedAfterBlock(ctx, address.String(), number)
+	u.seeder.storing.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Snapshots are of final blocks, only a reorg deeper than the
+	// confirmations can orphan them
+	delete(u.lastSnapshots, address)
+	return u.SnapshotDBClient.DeleteSnapshotsAfterBlock(ctx, address.String(), number)
+}
+
+// removeLog deletes the rows stored from a log whose block was orphaned
+func (u *UniswapV3Pool) removeLog(ctx context.Context, vLog types.Log) error {
+	blockHash, txnID := vLog.BlockHash.String(), vLog.TxHash.String()
+	// The log may have changed the protocol fee
+	delete(u.feeProtocols, vLog.Address)
+
+	if err := u.PoolEventsDBClient.DeleteEvent(ctx, blockHash, txnID, vLog.Index); err != nil {
+		return err
+	}
+	if vLog.BlockNumber > 0 {
+		if err := u.CandleDBClient.RebuildCandlesAfterBlock(ctx, vLog.Address.String(), vLog.BlockNumber-1); err != nil {
+			return err
+		}
+		if err := u.RollupDBClient.RebuildRollupsAfterBlock(ctx, vLog.Address.String(), vLog.BlockNumber-1); err != nil {
+			return err
+		}
+	}
+	if err := u.PoolLogsDBClient.DeletePoolLog(ctx, blockHash, txnID, vLog.Index); err != nil {
+		return err
+	}
+	if vLog.BlockNumber > 0 {
+		u.policy.reset(vLog.Address, vLog.BlockNumber-1)
+	}
+	return nil
+}
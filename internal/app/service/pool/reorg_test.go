@@ -0,0 +1,228 @@
+package pool
+
+import (
+	"context"
+	"strings"
+	"testing"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/rpc"
+	"uniswapper/internal/app/service/util/testutils/ethnode"
+	mockDB "uniswapper/internal/app/service/util/testutils/mocks/repository/pool"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// testStores are the repositories of a test pool ingestion
+type testStores struct {
+	logs        *mockDB.MockIPoolLogsRepository
+	events      *mockDB.MockIPoolEventsRepository
+	checkpoints *mockDB.MockICheckpointRepository
+	registry    *mockDB.MockIPoolRegistryRepository
+	snapshots   *mockDB.MockISnapshotRepository
+	liquidity   *mockDB.MockILiquidityRepository
+	candles     *mockDB.MockICandleRepository
+	rollups     *mockDB.MockIRollupRepository
+}
+
+// newTestPool ingests the USDC/WETH pool through client into mocked
+// repositories. Blocks are only confirmed once confirmations is reached.
+func newTestPool(t *testing.T, client *rpc.Client, confirmations uint64) (*UniswapV3Pool, *testStores) {
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	assert.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	stores := &testStores{
+		logs:        mockDB.NewMockIPoolLogsRepository(ctrl),
+		events:      mockDB.NewMockIPoolEventsRepository(ctrl),
+		checkpoints: mockDB.NewMockICheckpointRepository(ctrl),
+		registry:    mockDB.NewMockIPoolRegistryRepository(ctrl),
+		snapshots:   mockDB.NewMockISnapshotRepository(ctrl),
+		liquidity:   mockDB.NewMockILiquidityRepository(ctrl),
+		candles:     mockDB.NewMockICandleRepository(ctrl),
+		rollups:     mockDB.NewMockIRollupRepository(ctrl),
+	}
+
+	u := &UniswapV3Pool{
+		client:             client,
+		following:          []common.Address{common.HexToAddress(usdcWethPool)},
+		pools:              newPoolSet(),
+		poolABI:            poolABI,
+		policy:             everyEventPolicy{},
+		backfillChunkSize:  defaultBackfillChunkSize,
+		confirmations:      confirmations,
+		blocks:             newBlockTracker(confirmations),
+		blockTimes:         newBlockTimeCache(client),
+		lastSnapshots:      make(map[common.Address]uint64),
+		feeProtocols:       make(map[common.Address]uint8),
+		seeder:             newLiquiditySeeder(),
+		PoolLogsDBClient:   stores.logs,
+		PoolEventsDBClient: stores.events,
+		CheckpointDBClient: stores.checkpoints,
+		RegistryDBClient:   stores.registry,
+		SnapshotDBClient:   stores.snapshots,
+		LiquidityDBClient:  stores.liquidity,
+		CandleDBClient:     stores.candles,
+		RollupDBClient:     stores.rollups,
+	}
+	return u, stores
+}
+
+// track records the canonical blocks [from, to] of node as already processed
+func track(u *UniswapV3Pool, node *ethnode.Node, from, to uint64) {
+	for number := from; number <= to; number++ {
+		u.blocks.add(number, node.Header(number).Hash())
+	}
+}
+
+// expectRollback expects the rows of the pool after ancestor to be deleted
+// and its checkpoint rewound from checkpoint
+func (s *testStores) expectRollback(ancestor, checkpoint uint64) {
+	pool := common.HexToAddress(usdcWethPool).String()
+	s.events.EXPECT().DeleteEventsAfterBlock(gomock.Any(), pool, ancestor).Return(nil)
+	s.candles.EXPECT().RebuildCandlesAfterBlock(gomock.Any(), pool, ancestor).Return(nil)
+	s.rollups.EXPECT().RebuildRollupsAfterBlock(gomock.Any(), pool, ancestor).Return(nil)
+	s.logs.EXPECT().DeletePoolLogsAfterBlock(gomock.Any(), pool, ancestor).Return(nil)
+	s.liquidity.EXPECT().DeleteSeedAfterBlock(gomock.Any(), pool, ancestor).Return(nil)
+	s.snapshots.EXPECT().DeleteSnapshotsAfterBlock(gomock.Any(), pool, ancestor).Return(nil)
+	// The seed read before the orphaned blocks is kept
+	s.liquidity.EXPECT().GetSeed(gomock.Any(), pool).Return(&posts.LiquiditySeed{PoolAddress: pool}, nil)
+	s.checkpoints.EXPECT().GetCheckpoint(gomock.Any(), pool).Return(&posts.Checkpoint{PoolAddress: pool, BlockNumber: checkpoint}, nil)
+	if checkpoint > ancestor {
+		s.checkpoints.EXPECT().StoreCheckpoint(gomock.Any(), pool, ancestor).Return(nil)
+	}
+}
+
+func TestHandleHead(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name string
+		// reorg replaces the chain from this block on, if set
+		reorg    uint64
+		mine     uint64
+		head     uint64
+		ancestor uint64
+		replayed []ethnode.Range
+	}{
+		{
+			name: "next block",
+			mine: 1,
+			head: 11,
+		},
+		{
+			name:     "parent hash mismatch",
+			reorg:    8,
+			mine:     1,
+			head:     11,
+			ancestor: 7,
+			// The orphaned blocks and the new head are replayed
+			replayed: []ethnode.Range{{From: 8, To: 11}},
+		},
+		{
+			name:     "replaced head",
+			reorg:    10,
+			head:     10,
+			ancestor: 9,
+			replayed: []ethnode.Range{{From: 10, To: 10}},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestNode(t, 10)
+			u, stores := newTestPool(t, client, 100)
+			track(u, node, 1, 10)
+			orphaned := node.Header(10).Hash()
+
+			if tc.reorg > 0 {
+				node.Reorg(tc.reorg)
+				stores.expectRollback(tc.ancestor, 9)
+			}
+			node.Mine(tc.mine)
+
+			err := u.handleHead(context.Background(), node.Header(tc.head))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.replayed, node.Queries())
+
+			hash, ok := u.blocks.get(tc.head)
+			assert.True(t, ok)
+			assert.Equal(t, node.Header(tc.head).Hash(), hash)
+			if tc.reorg > 0 {
+				// Blocks after the ancestor are forgotten until seen again
+				_, ok = u.blocks.get(tc.ancestor + 1)
+				assert.Equal(t, tc.ancestor+1 == tc.head, ok)
+				assert.NotEqual(t, orphaned, node.Header(10).Hash())
+			}
+		})
+	}
+}
+
+func TestFindCommonAncestor(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name     string
+		tracked  uint64
+		reorg    uint64
+		expected uint64
+	}{
+		{name: "no reorg", tracked: 1, reorg: 11, expected: 10},
+		{name: "within the window", tracked: 1, reorg: 6, expected: 5},
+		// Blocks before the window cannot be compared, the oldest untracked
+		// block is assumed canonical
+		{name: "beyond the window", tracked: 9, reorg: 5, expected: 8},
+		{name: "back to genesis", tracked: 1, reorg: 1, expected: 0},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestNode(t, 10)
+			u, _ := newTestPool(t, client, 100)
+			track(u, node, tc.tracked, 10)
+			if tc.reorg <= 10 {
+				node.Reorg(tc.reorg)
+			}
+
+			ancestor, err := u.findCommonAncestor(context.Background(), 10)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ancestor)
+		})
+	}
+}
+
+func TestRemoveLog(t *testing.T) {
+	setupTest(t)
+
+	_, client := newTestNode(t, 20)
+	u, stores := newTestPool(t, client, 100)
+	pool := common.HexToAddress(usdcWethPool)
+	u.feeProtocols[pool] = 4
+
+	vLog := goldenLog([]string{swapTopic, routerTopic, routerTopic}, "0x")
+	vLog.BlockNumber = 12
+	vLog.BlockHash = common.HexToHash("0xbad")
+	vLog.Removed = true
+
+	// The stored rows of the log are deleted and the aggregates rebuilt
+	// from the rows of the blocks before it
+	blockHash, txnID := vLog.BlockHash.String(), vLog.TxHash.String()
+	gomock.InOrder(
+		stores.events.EXPECT().DeleteEvent(gomock.Any(), blockHash, txnID, vLog.Index).Return(nil),
+		stores.candles.EXPECT().RebuildCandlesAfterBlock(gomock.Any(), pool.String(), uint64(11)).Return(nil),
+		stores.rollups.EXPECT().RebuildRollupsAfterBlock(gomock.Any(), pool.String(), uint64(11)).Return(nil),
+		stores.logs.EXPECT().DeletePoolLog(gomock.Any(), blockHash, txnID, vLog.Index).Return(nil),
+	)
+
+	err := u.handleLog(context.Background(), vLog)
+	assert.NoError(t, err)
+	// The protocol fee is read again, the log may have changed it
+	_, ok := u.feeProtocols[pool]
+	assert.False(t, ok)
+}
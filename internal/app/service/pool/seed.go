@@ -0,0 +1,224 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/v3math/tickmath"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// seedLiquidity takes the liquidity of every initialized tick of a pool at
+// block as its seed, unless the pool was already seeded. Mint and Burn events
+// stored after the seed block are replayed on top of it to get the tick
+// liquidity at any later block.
+//
+// Reading the ticks costs one eth_call per word of the tick bitmap, 6932
+// words for a tick spacing of 1 and 116 for 60, plus one per initialized
+// tick, so it is left to the seeder rather than holding up the ingestion.
+// Errors are logged, as the depth chart is not needed to ingest events.
+func (u *UniswapV3Pool) seedLiquidity(ctx context.Context, address common.Address, block uint64) {
+	log := logger.Logger(ctx)
+
+	if u.isFactory(address) {
+		return
+	}
+
+	seed, err := u.LiquidityDBClient.GetSeed(ctx, address.String())
+	if err != nil {
+		log.Errorf("error while loading the liquidity seed of pool %s: %v", address.String(), err)
+		return
+	}
+	if seed != nil {
+		return
+	}
+
+	// A discovered pool has no liquidity before its creation, so its whole
+	// distribution is replayed from events
+	if start, ok := u.pools.startBlock(address); !ok || start <= block {
+		u.seeder.enqueue(address, block)
+		return
+	}
+
+	err = u.LiquidityDBClient.StoreSeed(ctx, posts.LiquiditySeed{PoolAddress: address.String(), BlockNumber: block}, nil)
+	if err != nil {
+		log.Errorf("error while storing the liquidity seed of pool %s: %v", address.String(), err)
+		return
+	}
+	log.Infof("Seeded pool %s without ticks at block %d, before its creation", address.String(), block)
+}
+
+// liquiditySeeder queues the pools whose ticks are to be read. A later
+// request for a queued pool replaces the earlier one, e.g. after a rollback.
+type liquiditySeeder struct {
+	mu     sync.Mutex
+	queued map[common.Address]uint64
+	order  []common.Address
+	wake   chan struct{}
+	// storing is held while a seed is stored and while seeds are deleted,
+	// so that a rollback cannot miss a seed read at an orphaned block
+	storing sync.Mutex
+}
+
+func newLiquiditySeeder() *liquiditySeeder {
+	return &liquiditySeeder{queued: make(map[common.Address]uint64), wake: make(chan struct{}, 1)}
+}
+
+func (s *liquiditySeeder) enqueue(address common.Address, block uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.queued[address]; !ok {
+		s.order = append(s.order, address)
+	}
+	s.queued[address] = block
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next takes the oldest queued pool, if any
+func (s *liquiditySeeder) next() (common.Address, uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.order) == 0 {
+		return common.Address{}, 0, false
+	}
+	address := s.order[0]
+	s.order = s.order[1:]
+	block := s.queued[address]
+	delete(s.queued, address)
+	return address, block, true
+}
+
+// runSeeder seeds the queued pools one at a time until ctx is done. It runs
+// across ingestion sessions, so a restart does not lose the queue.
+func (u *UniswapV3Pool) runSeeder(ctx context.Context) {
+	log := logger.Logger(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-u.seeder.wake:
+		}
+
+		for ctx.Err() == nil {
+			address, block, ok := u.seeder.next()
+			if !ok {
+				break
+			}
+			if err := u.seed(ctx, address, block); err != nil {
+				log.Errorf("error while seeding the liquidity of pool %s at block %d: %v", address.String(), block, err)
+			}
+		}
+	}
+}
+
+// seed reads the ticks of a pool at block and stores them, unless the block
+// was orphaned meanwhile or the pool was seeded by an earlier request
+func (u *UniswapV3Pool) seed(ctx context.Context, address common.Address, block uint64) error {
+	log := logger.Logger(ctx)
+	blockNumber := new(big.Int).SetUint64(block)
+
+	header, err := u.client.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("fetching block %d: %w", block, err)
+	}
+
+	ticks, err := u.readTicks(ctx, address, block)
+	if err != nil {
+		return err
+	}
+
+	u.seeder.storing.Lock()
+	defer u.seeder.storing.Unlock()
+
+	// The reads are by number, they were all from the same block only if
+	// its hash did not change in between
+	after, err := u.client.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("fetching block %d: %w", block, err)
+	}
+	if after.Hash() != header.Hash() {
+		log.Warnf("Block %d changed while seeding pool %s, dropping the seed", block, address.String())
+		return nil
+	}
+
+	seed, err := u.LiquidityDBClient.GetSeed(ctx, address.String())
+	if err != nil {
+		return err
+	}
+	if seed != nil {
+		return nil
+	}
+
+	err = u.LiquidityDBClient.StoreSeed(ctx, posts.LiquiditySeed{PoolAddress: address.String(), BlockNumber: block}, ticks)
+	if err != nil {
+		return err
+	}
+	log.Infof("Seeded pool %s with %d initialized ticks at block %d", address.String(), len(ticks), block)
+	return nil
+}
+
+// readTicks walks the tick bitmap of a pool, one 256-tick word per call, and
+// reads the liquidity of each initialized tick at block
+func (u *UniswapV3Pool) readTicks(ctx context.Context, address common.Address, block uint64) ([]posts.Tick, error) {
+	metadata, err := u.Metadata.GetPoolMetadata(ctx, address.String())
+	if err != nil {
+		return nil, err
+	}
+	spacing := int(metadata.TickSpacing)
+	if spacing <= 0 {
+		return nil, fmt.Errorf("invalid tick spacing %d", spacing)
+	}
+
+	blockNumber := new(big.Int).SetUint64(block)
+	minWord, maxWord := tickWord(tickmath.MIN_TICK, spacing), tickWord(tickmath.MAX_TICK, spacing)
+
+	var ticks []posts.Tick
+	for word := minWord; word <= maxWord; word++ {
+		out, err := callContract(ctx, u.client, u.poolABI, address, "tickBitmap", blockNumber, int16(word))
+		if err != nil {
+			return nil, fmt.Errorf("tickBitmap(%d): %w", word, err)
+		}
+
+		bitmap := out[0].(*big.Int)
+		for bit := 0; bit < bitmap.BitLen(); bit++ {
+			if bitmap.Bit(bit) == 0 {
+				continue
+			}
+
+			tick := (word*256 + bit) * spacing
+			out, err := callContract(ctx, u.client, u.poolABI, address, "ticks", blockNumber, big.NewInt(int64(tick)))
+			if err != nil {
+				return nil, fmt.Errorf("ticks(%d): %w", tick, err)
+			}
+
+			ticks = append(ticks, posts.Tick{
+				Tick:           int64(tick),
+				LiquidityGross: numeric.NewBigInt(out[0].(*big.Int)),
+				LiquidityNet:   numeric.NewBigInt(out[1].(*big.Int)),
+			})
+		}
+	}
+	return ticks, nil
+}
+
+// tickWord returns the position in the tick bitmap of the word holding tick,
+// rounding the compressed tick towards negative infinity like the contract
+func tickWord(tick, spacing int) int {
+	compressed := tick / spacing
+	if tick < 0 && tick%spacing != 0 {
+		compressed--
+	}
+	return compressed >> 8
+}
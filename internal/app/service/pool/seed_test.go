@@ -0,0 +1,150 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// tickPool answers the tick reads of a pool with two initialized ticks,
+// 200100 and 200300 at a tick spacing of 10
+func tickPool(t *testing.T, poolABI abi.ABI) func(common.Address, []byte, uint64) ([]byte, error) {
+	return func(_ common.Address, data []byte, _ uint64) ([]byte, error) {
+		method, err := poolABI.MethodById(data)
+		if err != nil {
+			return nil, err
+		}
+		args, err := method.Inputs.Unpack(data[4:])
+		assert.NoError(t, err)
+
+		switch method.Name {
+		case "tickBitmap":
+			bitmap := new(big.Int)
+			// Both ticks are in word 78, at bits 42 and 62
+			if args[0].(int16) == 78 {
+				bitmap.SetBit(bitmap, 42, 1).SetBit(bitmap, 62, 1)
+			}
+			return method.Outputs.Pack(bitmap)
+		case "ticks":
+			net := big.NewInt(1000)
+			if args[0].(*big.Int).Int64() == 200300 {
+				net.Neg(net)
+			}
+			zero := new(big.Int)
+			return method.Outputs.Pack(big.NewInt(1000), net, zero, zero, zero, zero, uint32(0), true)
+		}
+		return nil, nil
+	}
+}
+
+func TestSeedLiquidity(t *testing.T) {
+	setupTest(t)
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	assert.NoError(t, err)
+
+	node, client := newTestNode(t, 10)
+	u, stores := newTestPool(t, client, 100)
+	u.Metadata = staticMetadata{}
+	node.Call = tickPool(t, poolABI)
+
+	pool := common.HexToAddress(usdcWethPool)
+	stores.liquidity.EXPECT().GetSeed(gomock.Any(), pool.String()).Return(nil, nil).Times(2)
+
+	// The ticks are not read by the ingestion
+	u.seedLiquidity(context.Background(), pool, 8)
+	assert.Equal(t, 0, node.Requests("eth_call"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stores.liquidity.EXPECT().StoreSeed(gomock.Any(), posts.LiquiditySeed{PoolAddress: pool.String(), BlockNumber: 8}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ posts.LiquiditySeed, ticks []posts.Tick) error {
+			assert.Len(t, ticks, 2)
+			assert.Equal(t, int64(200100), ticks[0].Tick)
+			assert.Equal(t, "1000", ticks[0].LiquidityNet.String())
+			assert.Equal(t, int64(200300), ticks[1].Tick)
+			assert.Equal(t, "-1000", ticks[1].LiquidityNet.String())
+			cancel()
+			return nil
+		})
+
+	u.runSeeder(ctx)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	// One read per word of the bitmap and per initialized tick
+	assert.Equal(t, 694+2, node.Requests("eth_call"))
+}
+
+func TestSeedLiquidityBeforeCreation(t *testing.T) {
+	setupTest(t)
+
+	node, client := newTestNode(t, 10)
+	u, stores := newTestPool(t, client, 100)
+
+	pool := common.HexToAddress(usdcWethPool)
+	u.pools.add(pool, 9)
+	stores.liquidity.EXPECT().GetSeed(gomock.Any(), pool.String()).Return(nil, nil)
+	stores.liquidity.EXPECT().StoreSeed(gomock.Any(), posts.LiquiditySeed{PoolAddress: pool.String(), BlockNumber: 8}, nil).Return(nil)
+
+	u.seedLiquidity(context.Background(), pool, 8)
+	_, _, queued := u.seeder.next()
+	assert.False(t, queued)
+	assert.Equal(t, 0, node.Requests("eth_call"))
+}
+
+func TestSeedReorged(t *testing.T) {
+	setupTest(t)
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	assert.NoError(t, err)
+
+	node, client := newTestNode(t, 10)
+	u, _ := newTestPool(t, client, 100)
+	u.Metadata = staticMetadata{}
+
+	// Block 8 is orphaned while the ticks are read, so the seed is dropped
+	// without being stored
+	reads := tickPool(t, poolABI)
+	reorged := false
+	node.Call = func(to common.Address, data []byte, block uint64) ([]byte, error) {
+		if !reorged {
+			reorged = true
+			node.Reorg(8)
+		}
+		return reads(to, data, block)
+	}
+
+	err = u.seed(context.Background(), common.HexToAddress(usdcWethPool), 8)
+	assert.NoError(t, err)
+}
+
+func TestLiquiditySeederQueue(t *testing.T) {
+	s := newLiquiditySeeder()
+	a, b := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	s.enqueue(a, 9)
+	s.enqueue(b, 5)
+	// A rollback asks for an earlier seed of a queued pool
+	s.enqueue(a, 7)
+
+	address, block, ok := s.next()
+	assert.True(t, ok)
+	assert.Equal(t, a, address)
+	assert.Equal(t, uint64(7), block)
+
+	address, block, ok = s.next()
+	assert.True(t, ok)
+	assert.Equal(t, b, address)
+	assert.Equal(t, uint64(5), block)
+
+	_, _, ok = s.next()
+	assert.False(t, ok)
+}
@@ -0,0 +1,130 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// takeSnapshots records the full state of every followed pool at each
+// multiple of the snapshot interval up to confirmed. Snapshots are only taken
+// of final blocks, so they never need to be rolled back. The multiples missed
+// since a pool's last snapshot, e.g. while catching up after downtime, are
+// taken in order; a pool without snapshots starts at the last multiple, as
+// its earlier history is not snapshotted retroactively. A pool whose snapshot
+// fails is retried from there at the next confirmed block.
+func (u *UniswapV3Pool) takeSnapshots(ctx context.Context, confirmed uint64) {
+	log := logger.Logger(ctx)
+
+	if u.snapshotInterval == 0 {
+		return
+	}
+
+	block := confirmed - confirmed%u.snapshotInterval
+	if block == 0 {
+		return
+	}
+
+	for _, address := range u.following {
+		if u.isFactory(address) {
+			continue
+		}
+
+		last, ok := u.lastSnapshots[address]
+		if !ok {
+			latest, err := u.SnapshotDBClient.GetLatestSnapshot(ctx, address.String())
+			if err != nil {
+				log.Errorf("error while loading the latest snapshot of pool %s: %v", address.String(), err)
+				continue
+			}
+			if latest != nil {
+				last = latest.BlockNumber
+			}
+			u.lastSnapshots[address] = last
+		}
+
+		next := block
+		if last > 0 {
+			next = last - last%u.snapshotInterval + u.snapshotInterval
+		}
+		// Discovered pools have no state before their creation
+		if start, ok := u.pools.startBlock(address); ok && start > next {
+			next = start + (u.snapshotInterval-start%u.snapshotInterval)%u.snapshotInterval
+		}
+
+		for ; next <= block; next += u.snapshotInterval {
+			if err := u.snapshot(ctx, address, next); err != nil {
+				log.Errorf("error while snapshotting pool %s at block %d: %v", address.String(), next, err)
+				break
+			}
+			u.lastSnapshots[address] = next
+		}
+	}
+}
+
+// snapshot reads and stores the state of a pool at block
+func (u *UniswapV3Pool) snapshot(ctx context.Context, address common.Address, block uint64) error {
+	state, err := u.PoolState.GetPoolState(ctx, address.String(), &block)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := u.Metadata.GetPoolMetadata(ctx, address.String())
+	if err != nil {
+		return err
+	}
+
+	token0Balance, err := u.balanceOf(ctx, common.HexToAddress(metadata.Token0.Address), address, block)
+	if err != nil {
+		return err
+	}
+	token1Balance, err := u.balanceOf(ctx, common.HexToAddress(metadata.Token1.Address), address, block)
+	if err != nil {
+		return err
+	}
+
+	blockTime, err := u.blockTimes.get(ctx, block)
+	if err != nil {
+		return err
+	}
+
+	snapshot := posts.Snapshot{
+		PoolAddress:                address.String(),
+		BlockNumber:                block,
+		BlockTimestamp:             blockTime,
+		SqrtPriceX96:               state.Slot0.SqrtPriceX96,
+		Tick:                       int64(state.Slot0.Tick),
+		ObservationIndex:           state.Slot0.ObservationIndex,
+		ObservationCardinality:     state.Slot0.ObservationCardinality,
+		ObservationCardinalityNext: state.Slot0.ObservationCardinalityNext,
+		FeeProtocol:                state.Slot0.FeeProtocol,
+		Unlocked:                   state.Slot0.Unlocked,
+		Liquidity:                  state.Liquidity,
+		FeeGrowthGlobal0X128:       state.FeeGrowthGlobal0X128,
+		FeeGrowthGlobal1X128:       state.FeeGrowthGlobal1X128,
+		ProtocolFeesToken0:         state.ProtocolFees.Token0,
+		ProtocolFeesToken1:         state.ProtocolFees.Token1,
+		Token0Balance:              numeric.NewBigInt(token0Balance),
+		Token1Balance:              numeric.NewBigInt(token1Balance),
+	}
+	if state.Price != nil {
+		snapshot.Token1PerToken0 = &state.Price.Token1PerToken0
+		snapshot.Token0PerToken1 = &state.Price.Token0PerToken1
+	}
+
+	return u.SnapshotDBClient.StoreSnapshot(ctx, snapshot)
+}
+
+// balanceOf reads the balance of a token held by owner at block
+func (u *UniswapV3Pool) balanceOf(ctx context.Context, token, owner common.Address, block uint64) (*big.Int, error) {
+	out, err := callContract(ctx, u.client, u.erc20ABI, token, "balanceOf", new(big.Int).SetUint64(block), owner)
+	if err != nil {
+		return nil, fmt.Errorf("balanceOf %s: %w", token.String(), err)
+	}
+	return out[0].(*big.Int), nil
+}
@@ -0,0 +1,128 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// archiveState serves the same pool state at every block, except the
+// blocks it fails at
+type archiveState struct {
+	fail map[uint64]bool
+}
+
+func (s archiveState) GetPoolState(_ context.Context, address string, blockNumber *uint64) (*PoolState, error) {
+	if s.fail[*blockNumber] {
+		return nil, errors.New("missing trie node")
+	}
+	return &PoolState{Address: address, BlockNumber: *blockNumber, Slot0: Slot0{Tick: 201234, Unlocked: true}}, nil
+}
+
+// staticMetadata resolves every pool to the USDC/WETH 0.05% pool
+type staticMetadata struct {
+	IPoolMetadataService
+}
+
+func (staticMetadata) GetPoolMetadata(_ context.Context, address string) (*PoolMetadata, error) {
+	return &PoolMetadata{
+		Address:     address,
+		Token0:      posts.Token{Address: usdc, Decimals: 6},
+		Token1:      posts.Token{Address: weth, Decimals: 18},
+		Fee:         500,
+		TickSpacing: 10,
+	}, nil
+}
+
+func TestTakeSnapshots(t *testing.T) {
+	setupTest(t)
+
+	pool := common.HexToAddress(usdcWethPool)
+
+	testCases := []struct {
+		name      string
+		confirmed uint64
+		// cached is the last snapshot block kept in memory, if any
+		cached *uint64
+		// stored is the block of the latest snapshot in the database
+		stored uint64
+		// created is the creation block of a discovered pool
+		created  uint64
+		fail     map[uint64]bool
+		expected []uint64
+		last     uint64
+	}{
+		{name: "aligned to the interval", confirmed: 37, expected: []uint64{30}, last: 30},
+		{name: "on a multiple", confirmed: 40, expected: []uint64{40}, last: 40},
+		{name: "before the first multiple", confirmed: 7},
+		{name: "already taken", confirmed: 39, cached: uint64Ptr(30), last: 30},
+		{name: "reloaded from the database", confirmed: 39, stored: 30, last: 30},
+		{name: "next multiple after a reload", confirmed: 45, stored: 30, expected: []uint64{40}, last: 40},
+		// A catch-up confirms many blocks at once
+		{name: "missed multiples", confirmed: 67, cached: uint64Ptr(20), expected: []uint64{30, 40, 50, 60}, last: 60},
+		{name: "unaligned last snapshot", confirmed: 45, stored: 25, expected: []uint64{30, 40}, last: 40},
+		{name: "created after the block", confirmed: 45, created: 41, last: 0},
+		{name: "created after missed multiples", confirmed: 67, cached: uint64Ptr(20), created: 41, expected: []uint64{50, 60}, last: 60},
+		{name: "created on a multiple", confirmed: 67, cached: uint64Ptr(20), created: 50, expected: []uint64{50, 60}, last: 60},
+		{name: "created before the block", confirmed: 45, created: 12, expected: []uint64{40}, last: 40},
+		{name: "failed snapshot", confirmed: 67, cached: uint64Ptr(20), fail: map[uint64]bool{40: true}, expected: []uint64{30}, last: 30},
+	}
+
+	erc20, err := abi.JSON(strings.NewReader(erc20ABI))
+	assert.NoError(t, err)
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestNode(t, 100)
+			u, stores := newTestPool(t, client, 100)
+			u.erc20ABI = erc20
+			u.snapshotInterval = 10
+			u.PoolState = archiveState{fail: tc.fail}
+			u.Metadata = staticMetadata{}
+			if tc.created > 0 {
+				u.pools.add(pool, tc.created)
+			}
+
+			// Both tokens have the same balance at every block
+			node.Call = func(to common.Address, data []byte, block uint64) ([]byte, error) {
+				return common.LeftPadBytes(big.NewInt(1_000_000).Bytes(), 32), nil
+			}
+
+			if tc.cached != nil {
+				u.lastSnapshots[pool] = *tc.cached
+			} else if tc.confirmed >= 10 {
+				var latest *posts.Snapshot
+				if tc.stored > 0 {
+					latest = &posts.Snapshot{PoolAddress: pool.String(), BlockNumber: tc.stored}
+				}
+				stores.snapshots.EXPECT().GetLatestSnapshot(gomock.Any(), pool.String()).Return(latest, nil)
+			}
+
+			var taken []uint64
+			stores.snapshots.EXPECT().StoreSnapshot(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, snapshot posts.Snapshot) error {
+				assert.Equal(t, int64(node.Header(snapshot.BlockNumber).Time), snapshot.BlockTimestamp.Unix())
+				assert.Equal(t, "1000000", snapshot.Token0Balance.String())
+				taken = append(taken, snapshot.BlockNumber)
+				return nil
+			}).AnyTimes()
+
+			u.takeSnapshots(context.Background(), tc.confirmed)
+			assert.Equal(t, tc.expected, taken)
+			assert.Equal(t, tc.last, u.lastSnapshots[pool])
+		})
+	}
+}
+
+func uint64Ptr(n uint64) *uint64 {
+	return &n
+}
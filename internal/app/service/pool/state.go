@@ -0,0 +1,222 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/state_mock.go uniswapper/internal/app/service/pool IPoolStateService
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db/dto/numeric"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/rpc"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrBlockNotMined is returned for state requested at a future block
+var ErrBlockNotMined = errors.New("block is not mined yet")
+
+const defaultStateCacheTTL = 5 * time.Second
+
+// Slot0 is the packed state slot of a pool
+type Slot0 struct {
+	SqrtPriceX96               numeric.BigInt `json:"sqrt_price_x96"`
+	Tick                       int32          `json:"tick"`
+	ObservationIndex           uint16         `json:"observation_index"`
+	ObservationCardinality     uint16         `json:"observation_cardinality"`
+	ObservationCardinalityNext uint16         `json:"observation_cardinality_next"`
+	FeeProtocol                uint8          `json:"fee_protocol"`
+	Unlocked                   bool           `json:"unlocked"`
+}
+
+// ProtocolFees are the fees accrued to the protocol, in each token
+type ProtocolFees struct {
+	Token0 numeric.BigInt `json:"token0"`
+	Token1 numeric.BigInt `json:"token1"`
+}
+
+// PoolState is the on-chain state of a pool at a block
+type PoolState struct {
+	Address              string         `json:"address"`
+	BlockNumber          uint64         `json:"block_number"`
+	Slot0                Slot0          `json:"slot0"`
+	Liquidity            numeric.BigInt `json:"liquidity"`
+	FeeGrowthGlobal0X128 numeric.BigInt `json:"fee_growth_global0_x128"`
+	FeeGrowthGlobal1X128 numeric.BigInt `json:"fee_growth_global1_x128"`
+	ProtocolFees         ProtocolFees   `json:"protocol_fees"`
+	Price                *Price         `json:"price"`
+}
+
+// IPoolStateService reads the state of a pool through eth_call
+type IPoolStateService interface {
+	GetPoolState(ctx context.Context, address string, blockNumber *uint64) (*PoolState, error)
+}
+
+// PoolStateService reads every field of the state at the same block and
+// caches the result briefly, so that bursts of requests cost one read
+type PoolStateService struct {
+	client  *rpc.Client
+	poolABI abi.ABI
+	cache   *stateCache
+	Prices  IPriceService
+}
+
+func NewPoolStateService(ctx context.Context, rpcClient *rpc.Client, prices IPriceService) IPoolStateService {
+	log := logger.Logger(ctx)
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	if err != nil {
+		log.Fatalf("Failed to parse contract ABI: %v", err)
+	}
+
+	ttl := time.Duration(constants.Config.PoolConfig.POOL_STATE_CACHE_TTL) * time.Second
+	if ttl <= 0 {
+		ttl = defaultStateCacheTTL
+	}
+
+	return &PoolStateService{
+		client:  rpcClient,
+		poolABI: poolABI,
+		cache:   newStateCache(ttl),
+		Prices:  prices,
+	}
+}
+
+// GetPoolState returns the state of a pool at blockNumber, or at the latest
+// block if nil
+func (s *PoolStateService) GetPoolState(ctx context.Context, address string, blockNumber *uint64) (*PoolState, error) {
+	log := logger.Logger(ctx)
+	addr := common.HexToAddress(address)
+
+	key := stateKey{address: addr, latest: blockNumber == nil}
+	if blockNumber != nil {
+		key.block = *blockNumber
+	}
+	if state, ok := s.cache.get(key); ok {
+		return state, nil
+	}
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Latest reads are pinned to the head so that all fields are consistent
+	block := head
+	if blockNumber != nil {
+		if *blockNumber > head {
+			return nil, fmt.Errorf("%w: %d is past head %d", ErrBlockNotMined, *blockNumber, head)
+		}
+		block = *blockNumber
+	}
+
+	state, err := s.read(ctx, addr, new(big.Int).SetUint64(block))
+	if err != nil {
+		return nil, err
+	}
+	state.BlockNumber = block
+
+	state.Price, err = s.Prices.GetPrice(ctx, state.Address, state.Slot0.SqrtPriceX96.Big())
+	if err != nil {
+		log.Warnf("Error pricing pool %s: %v", state.Address, err)
+	}
+
+	s.cache.put(key, state)
+	return state, nil
+}
+
+func (s *PoolStateService) read(ctx context.Context, address common.Address, blockNumber *big.Int) (*PoolState, error) {
+	slot0, err := callPool(ctx, s.client, s.poolABI, address, "slot0", blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	liquidity, err := callPool(ctx, s.client, s.poolABI, address, "liquidity", blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	feeGrowthGlobal0X128, err := callPool(ctx, s.client, s.poolABI, address, "feeGrowthGlobal0X128", blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	feeGrowthGlobal1X128, err := callPool(ctx, s.client, s.poolABI, address, "feeGrowthGlobal1X128", blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	protocolFees, err := callPool(ctx, s.client, s.poolABI, address, "protocolFees", blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PoolState{
+		Address: address.String(),
+		Slot0: Slot0{
+			SqrtPriceX96:               numeric.NewBigInt(slot0[0].(*big.Int)),
+			Tick:                       int32(slot0[1].(*big.Int).Int64()),
+			ObservationIndex:           slot0[2].(uint16),
+			ObservationCardinality:     slot0[3].(uint16),
+			ObservationCardinalityNext: slot0[4].(uint16),
+			FeeProtocol:                slot0[5].(uint8),
+			Unlocked:                   slot0[6].(bool),
+		},
+		Liquidity:            numeric.NewBigInt(liquidity[0].(*big.Int)),
+		FeeGrowthGlobal0X128: numeric.NewBigInt(feeGrowthGlobal0X128[0].(*big.Int)),
+		FeeGrowthGlobal1X128: numeric.NewBigInt(feeGrowthGlobal1X128[0].(*big.Int)),
+		ProtocolFees: ProtocolFees{
+			Token0: numeric.NewBigInt(protocolFees[0].(*big.Int)),
+			Token1: numeric.NewBigInt(protocolFees[1].(*big.Int)),
+		},
+	}, nil
+}
+
+// stateKey identifies a cached state, either at a block or at the latest one
+type stateKey struct {
+	address common.Address
+	block   uint64
+	latest  bool
+}
+
+type stateEntry struct {
+	state   *PoolState
+	expires time.Time
+}
+
+// stateCache keeps pool states for a short time. Expired entries are
+// dropped whenever a new one is stored.
+type stateCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[stateKey]stateEntry
+}
+
+func newStateCache(ttl time.Duration) *stateCache {
+	return &stateCache{ttl: ttl, entries: make(map[stateKey]stateEntry)}
+}
+
+func (c *stateCache) get(key stateKey) (*PoolState, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.state, true
+}
+
+func (c *stateCache) put(key stateKey, state *PoolState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = stateEntry{state: state, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,127 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateCache(t *testing.T) {
+	cache := newStateCache(20 * time.Millisecond)
+	pool := common.HexToAddress(usdcWethPool)
+
+	latest := stateKey{address: pool, latest: true}
+	historical := stateKey{address: pool, block: 7}
+	cache.put(latest, &PoolState{BlockNumber: 10})
+	cache.put(historical, &PoolState{BlockNumber: 7})
+
+	// The latest state and the state at a block are cached apart
+	state, ok := cache.get(latest)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), state.BlockNumber)
+	state, ok = cache.get(historical)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(7), state.BlockNumber)
+
+	_, ok = cache.get(stateKey{address: pool, block: 10})
+	assert.False(t, ok)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok = cache.get(latest)
+	assert.False(t, ok)
+
+	// Expired entries are evicted by the next put
+	cache.put(stateKey{address: pool, block: 8}, &PoolState{BlockNumber: 8})
+	assert.Len(t, cache.entries, 1)
+}
+
+func TestGetPoolState(t *testing.T) {
+	setupTest(t)
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	assert.NoError(t, err)
+	q96 := new(big.Int).Lsh(big.NewInt(1), 96)
+
+	node, client := newTestNode(t, 10)
+	prices := &countingPrices{}
+	s := &PoolStateService{
+		client:  client,
+		poolABI: poolABI,
+		cache:   newStateCache(time.Minute),
+		Prices:  prices,
+	}
+
+	// Every field encodes the block it is read at, and a block is mined
+	// after each read
+	reads := make(map[string][]uint64)
+	node.Call = func(_ common.Address, data []byte, block uint64) ([]byte, error) {
+		defer node.Mine(1)
+
+		method, err := poolABI.MethodById(data)
+		if err != nil {
+			return nil, err
+		}
+		reads[method.Name] = append(reads[method.Name], block)
+
+		n := new(big.Int).SetUint64(block)
+		var values []interface{}
+		switch method.Name {
+		case "slot0":
+			values = []interface{}{new(big.Int).Add(q96, n), big.NewInt(-int64(block)), uint16(1), uint16(2), uint16(3), uint8(4), true}
+		case "protocolFees":
+			values = []interface{}{n, n}
+		default:
+			values = []interface{}{n}
+		}
+		return method.Outputs.Pack(values...)
+	}
+
+	address := common.HexToAddress(usdcWethPool).String()
+
+	// The latest state is pinned to the head when the read starts, although
+	// the chain moves on in between
+	state, err := s.GetPoolState(context.Background(), address, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), state.BlockNumber)
+	for _, method := range []string{"slot0", "liquidity", "feeGrowthGlobal0X128", "feeGrowthGlobal1X128", "protocolFees"} {
+		assert.Equal(t, []uint64{10}, reads[method], method)
+	}
+	assert.Equal(t, new(big.Int).Add(q96, big.NewInt(10)), state.Slot0.SqrtPriceX96.Big())
+	assert.Equal(t, int32(-10), state.Slot0.Tick)
+	assert.Equal(t, "10", state.Liquidity.String())
+	assert.Equal(t, "10", state.ProtocolFees.Token1.String())
+	assert.NotNil(t, state.Price)
+
+	// Served from the cache
+	cached, err := s.GetPoolState(context.Background(), address, nil)
+	assert.NoError(t, err)
+	assert.Same(t, state, cached)
+	assert.Equal(t, 5, node.Requests("eth_call"))
+
+	// A historical read is not served the latest state, even at its block
+	block := uint64(10)
+	historical, err := s.GetPoolState(context.Background(), address, &block)
+	assert.NoError(t, err)
+	assert.NotSame(t, state, historical)
+	assert.Equal(t, []uint64{10, 10}, reads["slot0"])
+	assert.Equal(t, 10, node.Requests("eth_call"))
+
+	block = 7
+	historical, err = s.GetPoolState(context.Background(), address, &block)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), historical.BlockNumber)
+	assert.Equal(t, "7", historical.FeeGrowthGlobal0X128.String())
+	assert.Equal(t, 3, prices.calls)
+
+	// The chain is at block 25 after 15 reads
+	block = 26
+	_, err = s.GetPoolState(context.Background(), address, &block)
+	assert.ErrorIs(t, err, ErrBlockNotMined)
+	assert.Equal(t, 15, node.Requests("eth_call"))
+}
@@ -0,0 +1,204 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/rpc"
+)
+
+// storeEvent writes a decoded pool event to its per-event table, and swaps
+// to the candles and rollups too. Initialize events carry no amounts and are
+// not stored, the price they set is read with the pool state; SetFeeProtocol
+// events only update the protocol fee applied to the following swaps.
+func (u *UniswapV3Pool) storeEvent(ctx context.Context, event interface{}) error {
+	switch e := event.(type) {
+	case *SwapEvent:
+		_, err := u.storeSwap(ctx, e)
+		return err
+	case *MintEvent:
+		blockTime, err := u.blockTimes.get(ctx, e.Raw.BlockNumber)
+		if err != nil {
+			return err
+		}
+		return u.PoolEventsDBClient.StoreMint(ctx, posts.Mint{
+			PoolAddress:    e.Raw.Address.String(),
+			TxnId:          e.Raw.TxHash.String(),
+			BlockNumber:    e.Raw.BlockNumber,
+			BlockHash:      e.Raw.BlockHash.String(),
+			LogIndex:       e.Raw.Index,
+			BlockTimestamp: blockTime,
+			Sender:         e.Sender.String(),
+			Owner:          e.Owner.String(),
+			TickLower:      e.TickLower.Int64(),
+			TickUpper:      e.TickUpper.Int64(),
+			Amount:         numeric.NewBigInt(e.Amount),
+			Amount0:        numeric.NewBigInt(e.Amount0),
+			Amount1:        numeric.NewBigInt(e.Amount1),
+		})
+	case *BurnEvent:
+		blockTime, err := u.blockTimes.get(ctx, e.Raw.BlockNumber)
+		if err != nil {
+			return err
+		}
+		return u.PoolEventsDBClient.StoreBurn(ctx, posts.Burn{
+			PoolAddress:    e.Raw.Address.String(),
+			TxnId:          e.Raw.TxHash.String(),
+			BlockNumber:    e.Raw.BlockNumber,
+			BlockHash:      e.Raw.BlockHash.String(),
+			LogIndex:       e.Raw.Index,
+			BlockTimestamp: blockTime,
+			Owner:          e.Owner.String(),
+			TickLower:      e.TickLower.Int64(),
+			TickUpper:      e.TickUpper.Int64(),
+			Amount:         numeric.NewBigInt(e.Amount),
+			Amount0:        numeric.NewBigInt(e.Amount0),
+			Amount1:        numeric.NewBigInt(e.Amount1),
+		})
+	case *CollectEvent:
+		blockTime, err := u.blockTimes.get(ctx, e.Raw.BlockNumber)
+		if err != nil {
+			return err
+		}
+		return u.PoolEventsDBClient.StoreCollect(ctx, posts.Collect{
+			PoolAddress:    e.Raw.Address.String(),
+			TxnId:          e.Raw.TxHash.String(),
+			BlockNumber:    e.Raw.BlockNumber,
+			BlockHash:      e.Raw.BlockHash.String(),
+			LogIndex:       e.Raw.Index,
+			BlockTimestamp: blockTime,
+			Owner:          e.Owner.String(),
+			Recipient:      e.Recipient.String(),
+			TickLower:      e.TickLower.Int64(),
+			TickUpper:      e.TickUpper.Int64(),
+			Amount0:        numeric.NewBigInt(e.Amount0),
+			Amount1:        numeric.NewBigInt(e.Amount1),
+		})
+	case *FlashEvent:
+		blockTime, err := u.blockTimes.get(ctx, e.Raw.BlockNumber)
+		if err != nil {
+			return err
+		}
+		return u.PoolEventsDBClient.StoreFlash(ctx, posts.Flash{
+			PoolAddress:    e.Raw.Address.String(),
+			TxnId:          e.Raw.TxHash.String(),
+			BlockNumber:    e.Raw.BlockNumber,
+			BlockHash:      e.Raw.BlockHash.String(),
+			LogIndex:       e.Raw.Index,
+			BlockTimestamp: blockTime,
+			Sender:         e.Sender.String(),
+			Recipient:      e.Recipient.String(),
+			Amount0:        numeric.NewBigInt(e.Amount0),
+			Amount1:        numeric.NewBigInt(e.Amount1),
+			Paid0:          numeric.NewBigInt(e.Paid0),
+			Paid1:          numeric.NewBigInt(e.Paid1),
+		})
+	case *SetFeeProtocolEvent:
+		u.feeProtocols[e.Raw.Address] = e.FeeProtocol0New | e.FeeProtocol1New<<4
+	}
+	return nil
+}
+
+// storeSwap stores a swap with its block time, prices and fees, and applies
+// it to the candles and rollups. The stored row is returned so that the
+// prices are not derived again for the pool_logs snapshot.
+func (u *UniswapV3Pool) storeSwap(ctx context.Context, e *SwapEvent) (*posts.Swap, error) {
+	blockTime, err := u.blockTimes.get(ctx, e.Raw.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	token1PerToken0, token0PerToken1 := u.swapPrices(ctx, e)
+	feeAmount, protocolFeeAmount := u.swapFees(ctx, e)
+	swap := posts.Swap{
+		PoolAddress:       e.Raw.Address.String(),
+		TxnId:             e.Raw.TxHash.String(),
+		BlockNumber:       e.Raw.BlockNumber,
+		BlockHash:         e.Raw.BlockHash.String(),
+		LogIndex:          e.Raw.Index,
+		BlockTimestamp:    blockTime,
+		Sender:            e.Sender.String(),
+		Recipient:         e.Recipient.String(),
+		Amount0:           numeric.NewBigInt(e.Amount0),
+		Amount1:           numeric.NewBigInt(e.Amount1),
+		SqrtPriceX96:      numeric.NewBigInt(e.SqrtPriceX96),
+		Liquidity:         numeric.NewBigInt(e.Liquidity),
+		Tick:              e.Tick.Int64(),
+		Token1PerToken0:   token1PerToken0,
+		Token0PerToken1:   token0PerToken1,
+		FeeAmount:         feeAmount,
+		ProtocolFeeAmount: protocolFeeAmount,
+	}
+	if err := u.PoolEventsDBClient.StoreSwap(ctx, swap); err != nil {
+		return nil, err
+	}
+	if err := u.CandleDBClient.ApplySwap(ctx, swap); err != nil {
+		return nil, err
+	}
+	if err := u.RollupDBClient.ApplySwap(ctx, swap); err != nil {
+		return nil, err
+	}
+	return &swap, nil
+}
+
+// swapPrices derives the decimal-adjusted prices after a swap. The swap is
+// stored without them when the pool's token decimals cannot be resolved.
+func (u *UniswapV3Pool) swapPrices(ctx context.Context, e *SwapEvent) (*string, *string) {
+	log := logger.Logger(ctx)
+
+	price, err := u.Prices.GetPrice(ctx, e.Raw.Address.String(), e.SqrtPriceX96)
+	if err != nil {
+		log.Warnf("error while pricing swap %s of pool %s: %v", e.Raw.TxHash.String(), e.Raw.Address.String(), err)
+		return nil, nil
+	}
+	if price == nil {
+		return nil, nil
+	}
+	return &price.Token1PerToken0, &price.Token0PerToken1
+}
+
+// blockTimeCache remembers the timestamp of the most recently seen blocks so
+// that consecutive logs from the same block cost a single header lookup
+type blockTimeCache struct {
+	mu     sync.Mutex
+	client *rpc.Client
+	times  map[uint64]time.Time
+}
+
+const blockTimeCacheSize = 256
+
+func newBlockTimeCache(client *rpc.Client) *blockTimeCache {
+	return &blockTimeCache{client: client, times: make(map[uint64]time.Time)}
+}
+
+func (c *blockTimeCache) get(ctx context.Context, number uint64) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t, ok := c.times[number]; ok {
+		return t, nil
+	}
+
+	header, err := c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(c.times) >= blockTimeCacheSize {
+		c.times = make(map[uint64]time.Time)
+	}
+	t := time.Unix(int64(header.Time), 0).UTC()
+	c.times[number] = t
+	return t, nil
+}
+
+// reset drops every cached timestamp, e.g. after the blocks were reorganized
+func (c *blockTimeCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.times = make(map[uint64]time.Time)
+}
@@ -0,0 +1,73 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingPrices prices every swap the same and counts the lookups
+type countingPrices struct {
+	IPriceService
+	calls int
+}
+
+func (p *countingPrices) GetPrice(context.Context, string, *big.Int) (*Price, error) {
+	p.calls++
+	return &Price{Token1PerToken0: "0.0005", Token0PerToken1: "2000"}, nil
+}
+
+// missingMetadata fails to resolve any pool
+type missingMetadata struct {
+	IPoolMetadataService
+}
+
+func (missingMetadata) GetPoolMetadata(context.Context, string) (*PoolMetadata, error) {
+	return nil, errors.New("pool not found")
+}
+
+func TestHandleSwapLog(t *testing.T) {
+	setupTest(t)
+
+	node, client := newTestNode(t, 20)
+	u, stores := newTestPool(t, client, 100)
+	prices := &countingPrices{}
+	u.Prices = prices
+	u.Metadata = missingMetadata{}
+
+	vLog := goldenLog(
+		[]string{swapTopic, routerTopic, routerTopic},
+		"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffff6afd0700"+
+			"0000000000000000000000000000000000000000000000000de0b6b3a7640000"+
+			"00000000000000000000000000000000000061ffb97edec2183ed8fd6f5fc5eb"+
+			"000000000000000000000000000000000000000000000000ab54a98ceb1f0ad2"+
+			"0000000000000000000000000000000000000000000000000000000000031212",
+	)
+	vLog.BlockNumber = 12
+	vLog = node.AddLog(vLog)
+
+	stores.events.EXPECT().StoreSwap(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, swap posts.Swap) error {
+		assert.Equal(t, "0.0005", *swap.Token1PerToken0)
+		assert.Equal(t, node.Header(12).Time, uint64(swap.BlockTimestamp.Unix()))
+		return nil
+	})
+	stores.candles.EXPECT().ApplySwap(gomock.Any(), gomock.Any()).Return(nil)
+	stores.rollups.EXPECT().ApplySwap(gomock.Any(), gomock.Any()).Return(nil)
+	// The pool log carries the prices of the stored swap
+	stores.logs.EXPECT().StorePoolLogs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, logs posts.Logs) error {
+		assert.Equal(t, "0.0005", *logs.Token1PerToken0)
+		assert.Equal(t, "2000", *logs.Token0PerToken1)
+		assert.Equal(t, int64(201234), logs.Tick)
+		return nil
+	})
+
+	err := u.handleLog(context.Background(), vLog)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, prices.calls)
+	assert.Equal(t, 1, node.Requests("eth_getBlockByNumber"))
+}
@@ -0,0 +1,238 @@
+//go:generate mockgen -package=mock -destination=../util/testutils/mocks/service/pool/twap_mock.go uniswapper/internal/app/service/pool ITWAPService
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/db/repository/pool"
+	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/rpc"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNoTickHistory is returned when neither the oracle of a pool nor its
+// stored swaps cover the requested window
+var ErrNoTickHistory = errors.New("no tick history covers the window")
+
+const (
+	// TWAP_SOURCE_ORACLE marks averages read from the observe() oracle
+	TWAP_SOURCE_ORACLE = "oracle"
+	// TWAP_SOURCE_EVENTS marks averages computed from the stored swaps
+	TWAP_SOURCE_EVENTS = "events"
+)
+
+var (
+	maxUint128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	maxUint160 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+)
+
+// TWAP is the time-weighted average state of a pool over the Window seconds
+// ending at BlockNumber. Price is the price at the arithmetic mean tick.
+type TWAP struct {
+	Address               string         `json:"address"`
+	Source                string         `json:"source"`
+	BlockNumber           uint64         `json:"block_number"`
+	From                  time.Time      `json:"from"`
+	To                    time.Time      `json:"to"`
+	Window                uint32         `json:"window"`
+	ArithmeticMeanTick    int64          `json:"arithmetic_mean_tick"`
+	HarmonicMeanLiquidity numeric.BigInt `json:"harmonic_mean_liquidity"`
+	Price                 *Price         `json:"price"`
+}
+
+// ITWAPService serves the time-weighted average price of a pool
+type ITWAPService interface {
+	GetTWAP(ctx context.Context, address string, window uint32) (*TWAP, error)
+}
+
+// TWAPService reads the oracle of a pool at the latest block. Pools whose
+// observations do not reach back far enough are averaged from the swaps
+// stored by the ingestor, up to the last block it processed.
+type TWAPService struct {
+	client             *rpc.Client
+	poolABI            abi.ABI
+	Metadata           IPoolMetadataService
+	EventsDBClient     pool.IPoolEventsRepository
+	CheckpointDBClient pool.ICheckpointRepository
+}
+
+func NewTWAPService(
+	ctx context.Context,
+	rpcClient *rpc.Client,
+	metadata IPoolMetadataService,
+	eventsDBClient pool.IPoolEventsRepository,
+	checkpointDBClient pool.ICheckpointRepository,
+) ITWAPService {
+	log := logger.Logger(ctx)
+
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	if err != nil {
+		log.Fatalf("Failed to parse contract ABI: %v", err)
+	}
+
+	return &TWAPService{
+		client:             rpcClient,
+		poolABI:            poolABI,
+		Metadata:           metadata,
+		EventsDBClient:     eventsDBClient,
+		CheckpointDBClient: checkpointDBClient,
+	}
+}
+
+func (s *TWAPService) GetTWAP(ctx context.Context, address string, window uint32) (*TWAP, error) {
+	log := logger.Logger(ctx)
+	addr := common.HexToAddress(address)
+
+	metadata, err := s.Metadata.GetPoolMetadata(ctx, addr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	twap := &TWAP{Address: addr.String(), Window: window}
+
+	out, err := callContract(ctx, s.client, s.poolABI, addr, "observe", head.Number, []uint32{window, 0})
+	switch {
+	case err == nil:
+		twap.Source = TWAP_SOURCE_ORACLE
+		twap.BlockNumber = head.Number.Uint64()
+		twap.To = time.Unix(int64(head.Time), 0).UTC()
+		tick, liquidity := ObserveAverages(out[0].([]*big.Int), out[1].([]*big.Int), window)
+		twap.ArithmeticMeanTick, twap.HarmonicMeanLiquidity = tick, numeric.NewBigInt(liquidity)
+	case rpc.IsRevert(err):
+		// observe() reverts when the oldest observation is younger than the window
+		log.Infof("Oracle of pool %s does not cover %d seconds, averaging stored swaps: %v", addr.String(), window, err)
+		if err := s.fromHistory(ctx, twap); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	twap.From = twap.To.Add(-time.Duration(window) * time.Second)
+
+	twap.Price, err = PriceAtTick(int(twap.ArithmeticMeanTick), metadata.Token0.Decimals, metadata.Token1.Decimals)
+	if err != nil {
+		log.Warnf("Error pricing the mean tick %d of pool %s: %v", twap.ArithmeticMeanTick, addr.String(), err)
+	}
+	return twap, nil
+}
+
+// fromHistory averages the swaps stored for the window ending at the last
+// block ingested for the pool
+func (s *TWAPService) fromHistory(ctx context.Context, twap *TWAP) error {
+	checkpoint, err := s.CheckpointDBClient.GetCheckpoint(ctx, twap.Address)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		return fmt.Errorf("%w: pool %s is not ingested", ErrNoTickHistory, twap.Address)
+	}
+
+	header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(checkpoint.BlockNumber))
+	if err != nil {
+		return err
+	}
+
+	to := time.Unix(int64(header.Time), 0).UTC()
+	from := to.Add(-time.Duration(twap.Window) * time.Second)
+
+	swaps, err := s.EventsDBClient.GetSwapsSince(ctx, twap.Address, from, to)
+	if err != nil {
+		return err
+	}
+
+	tick, liquidity, err := HistoryAverages(swaps, from, to)
+	if err != nil {
+		return err
+	}
+
+	twap.Source = TWAP_SOURCE_EVENTS
+	twap.BlockNumber = checkpoint.BlockNumber
+	twap.To = to
+	twap.ArithmeticMeanTick = tick
+	twap.HarmonicMeanLiquidity = numeric.NewBigInt(liquidity)
+	return nil
+}
+
+// ObserveAverages derives the arithmetic mean tick and the harmonic mean
+// liquidity from the result of observe([window, 0])
+func ObserveAverages(tickCumulatives, secondsPerLiquidityCumulativeX128s []*big.Int, window uint32) (int64, *big.Int) {
+	tickCumulativesDelta := new(big.Int).Sub(tickCumulatives[1], tickCumulatives[0])
+
+	// The accumulator is a uint160 and may have overflowed within the window
+	secondsPerLiquidityDelta := new(big.Int).Sub(secondsPerLiquidityCumulativeX128s[1], secondsPerLiquidityCumulativeX128s[0])
+	secondsPerLiquidityDelta.And(secondsPerLiquidityDelta, maxUint160)
+
+	return consult(tickCumulativesDelta, secondsPerLiquidityDelta, window)
+}
+
+// HistoryAverages accumulates the ticks and liquidity set by swaps over
+// [from, to] like the oracle does, each lasting until the next swap. The
+// first swap must be at or before from. Liquidity changed by mints and burns
+// between swaps is not seen.
+func HistoryAverages(swaps []posts.Swap, from, to time.Time) (int64, *big.Int, error) {
+	if len(swaps) == 0 || swaps[0].BlockTimestamp.After(from) {
+		return 0, nil, fmt.Errorf("%w: no swap stored at or before %s", ErrNoTickHistory, from.Format(time.RFC3339))
+	}
+
+	tickCumulative := new(big.Int)
+	secondsPerLiquidityCumulativeX128 := new(big.Int)
+	for i, swap := range swaps {
+		start, end := swap.BlockTimestamp, to
+		if start.Before(from) {
+			start = from
+		}
+		if i+1 < len(swaps) && swaps[i+1].BlockTimestamp.Before(to) {
+			end = swaps[i+1].BlockTimestamp
+		}
+
+		elapsed := end.Unix() - start.Unix()
+		if elapsed <= 0 {
+			continue
+		}
+
+		tickCumulative.Add(tickCumulative, new(big.Int).Mul(big.NewInt(swap.Tick), big.NewInt(elapsed)))
+
+		liquidity := swap.Liquidity.Big()
+		if liquidity.Sign() <= 0 {
+			liquidity.SetInt64(1)
+		}
+		secondsPerLiquidity := new(big.Int).Lsh(big.NewInt(elapsed), 128)
+		secondsPerLiquidityCumulativeX128.Add(secondsPerLiquidityCumulativeX128, secondsPerLiquidity.Quo(secondsPerLiquidity, liquidity))
+	}
+
+	tick, liquidity := consult(tickCumulative, secondsPerLiquidityCumulativeX128, uint32(to.Unix()-from.Unix()))
+	return tick, liquidity, nil
+}
+
+// consult ports OracleLibrary.consult: the mean tick rounds towards negative
+// infinity and the harmonic mean liquidity is truncated to a uint128
+func consult(tickCumulativesDelta, secondsPerLiquidityDelta *big.Int, window uint32) (int64, *big.Int) {
+	seconds := big.NewInt(int64(window))
+
+	tick, remainder := new(big.Int).QuoRem(tickCumulativesDelta, seconds, new(big.Int))
+	if tickCumulativesDelta.Sign() < 0 && remainder.Sign() != 0 {
+		tick.Sub(tick, big.NewInt(1))
+	}
+
+	liquidity := new(big.Int)
+	if secondsPerLiquidityDelta.Sign() > 0 {
+		secondsX160 := new(big.Int).Mul(seconds, maxUint160)
+		liquidity.Quo(secondsX160, new(big.Int).Lsh(secondsPerLiquidityDelta, 32))
+		liquidity.And(liquidity, maxUint128)
+	}
+	return tick.Int64(), liquidity
+}
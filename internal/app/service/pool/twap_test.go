@@ -0,0 +1,151 @@
+package pool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+	"uniswapper/internal/app/db/dto/numeric"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveAverages(t *testing.T) {
+	secondsPerLiquidity := func(seconds, liquidity int64) *big.Int {
+		x128 := new(big.Int).Lsh(big.NewInt(seconds), 128)
+		return x128.Quo(x128, big.NewInt(liquidity))
+	}
+
+	testCases := []struct {
+		name                string
+		tickCumulatives     []*big.Int
+		secondsPerLiquidity []*big.Int
+		window              uint32
+		tick                int64
+		liquidity           string
+	}{
+		{
+			name:                "positive tick",
+			tickCumulatives:     []*big.Int{big.NewInt(1000), big.NewInt(1100)},
+			secondsPerLiquidity: []*big.Int{big.NewInt(0), secondsPerLiquidity(10, 1000)},
+			window:              10,
+			tick:                10,
+			liquidity:           "1000",
+		},
+		{
+			name:                "negative tick rounds down",
+			tickCumulatives:     []*big.Int{big.NewInt(0), big.NewInt(-15)},
+			secondsPerLiquidity: []*big.Int{big.NewInt(0), secondsPerLiquidity(10, 1000)},
+			window:              10,
+			tick:                -2,
+			liquidity:           "1000",
+		},
+		{
+			name:                "negative tick exact",
+			tickCumulatives:     []*big.Int{big.NewInt(-100), big.NewInt(-300)},
+			secondsPerLiquidity: []*big.Int{big.NewInt(0), secondsPerLiquidity(10, 1000)},
+			window:              10,
+			tick:                -20,
+			liquidity:           "1000",
+		},
+		{
+			name:            "seconds per liquidity overflowed",
+			tickCumulatives: []*big.Int{big.NewInt(0), big.NewInt(0)},
+			secondsPerLiquidity: []*big.Int{
+				new(big.Int).Sub(maxUint160, big.NewInt(99)),
+				new(big.Int).Sub(secondsPerLiquidity(10, 1000), big.NewInt(100)),
+			},
+			window:    10,
+			tick:      0,
+			liquidity: "1000",
+		},
+		{
+			name:                "no elapsed liquidity",
+			tickCumulatives:     []*big.Int{big.NewInt(0), big.NewInt(0)},
+			secondsPerLiquidity: []*big.Int{big.NewInt(5), big.NewInt(5)},
+			window:              10,
+			tick:                0,
+			liquidity:           "0",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tick, liquidity := ObserveAverages(tc.tickCumulatives, tc.secondsPerLiquidity, tc.window)
+			assert.Equal(t, tc.tick, tick)
+			assert.Equal(t, tc.liquidity, liquidity.String())
+		})
+	}
+}
+
+func TestHistoryAverages(t *testing.T) {
+	from := time.Unix(1700000000, 0).UTC()
+	to := from.Add(10 * time.Second)
+	swap := func(offset, tick, liquidity int64) posts.Swap {
+		return posts.Swap{
+			BlockTimestamp: from.Add(time.Duration(offset) * time.Second),
+			Tick:           tick,
+			Liquidity:      numeric.NewBigInt(big.NewInt(liquidity)),
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		swaps     []posts.Swap
+		tick      int64
+		liquidity string
+		err       error
+	}{
+		{
+			name:      "no swap within the window",
+			swaps:     []posts.Swap{swap(-5, 10, 1000)},
+			tick:      10,
+			liquidity: "1000",
+		},
+		{
+			name:      "swap at the start",
+			swaps:     []posts.Swap{swap(0, -10, 1000)},
+			tick:      -10,
+			liquidity: "1000",
+		},
+		{
+			name:      "swaps within the window",
+			swaps:     []posts.Swap{swap(-5, 10, 1000), swap(4, 20, 4000)},
+			tick:      16,
+			liquidity: "1818",
+		},
+		{
+			name:      "last swap of a block holds",
+			swaps:     []posts.Swap{swap(-5, 10, 1000), swap(4, 500, 1), swap(4, 20, 4000), swap(10, 30, 4000)},
+			tick:      16,
+			liquidity: "1818",
+		},
+		{
+			name:  "no swap before the window",
+			swaps: []posts.Swap{swap(1, 10, 1000)},
+			err:   ErrNoTickHistory,
+		},
+		{
+			name: "no swaps",
+			err:  ErrNoTickHistory,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tick, liquidity, err := HistoryAverages(tc.swaps, from, to)
+			if tc.err != nil {
+				assert.True(t, errors.Is(err, tc.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.tick, tick)
+			assert.Equal(t, tc.liquidity, liquidity.String())
+		})
+	}
+}
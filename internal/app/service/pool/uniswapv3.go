@@ -3,42 +3,102 @@ package pool
 import (
 	"context"
 	"encoding/json"
-	"math/big"
+	"errors"
+	"fmt"
 	"strings"
+	"sync/atomic"
+	"time"
 	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/db/dto/numeric"
 	posts "uniswapper/internal/app/db/dto/pool"
 	"uniswapper/internal/app/db/repository/pool"
 	"uniswapper/internal/app/service/logger"
+	"uniswapper/internal/app/service/rpc"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type IUniswapV3Pool interface {
 	RunUniswapV3Pool(ctx context.Context)
+	ReconnectCount() uint64
 }
 
 type UniswapV3Pool struct {
-	client           *ethclient.Client
-	addresses        []common.Address
-	PoolLogsDBClient pool.IPoolLogsRepository
+	client             *rpc.Client
+	configured         []common.Address
+	pools              *poolSet
+	following          []common.Address
+	factory            *factoryWatch
+	poolABI            abi.ABI
+	policy             ingestionPolicy
+	mode               string
+	pollInterval       time.Duration
+	headTimeout        time.Duration
+	minBackoff         time.Duration
+	maxBackoff         time.Duration
+	backfillChunkSize  uint64
+	confirmations      uint64
+	blocks             *blockTracker
+	blockTimes         *blockTimeCache
+	erc20ABI           abi.ABI
+	snapshotInterval   uint64
+	lastSnapshots      map[common.Address]uint64
+	feeProtocols       map[common.Address]uint8
+	seeder             *liquiditySeeder
+	reconnects         atomic.Uint64
+	PoolLogsDBClient   pool.IPoolLogsRepository
+	PoolEventsDBClient pool.IPoolEventsRepository
+	CheckpointDBClient pool.ICheckpointRepository
+	RegistryDBClient   pool.IPoolRegistryRepository
+	SnapshotDBClient   pool.ISnapshotRepository
+	LiquidityDBClient  pool.ILiquidityRepository
+	CandleDBClient     pool.ICandleRepository
+	RollupDBClient     pool.IRollupRepository
+	Registry           IPoolRegistry
+	Metadata           IPoolMetadataService
+	Prices             IPriceService
+	PoolState          IPoolStateService
 }
 
-func NewUniswapV3Pool(ctx context.Context, poolLogsDBClient pool.IPoolLogsRepository) IUniswapV3Pool {
+// defaultHeadTimeout is how long a session may go without a new head before
+// it is restarted, in both ingestion modes
+const defaultHeadTimeout = 2 * time.Minute
+
+// errPoolsChanged ends a session so that the next one follows the new pools
+var errPoolsChanged = errors.New("tracked pools changed")
+
+func NewUniswapV3Pool(
+	ctx context.Context,
+	rpcClient *rpc.Client,
+	registry IPoolRegistry,
+	metadata IPoolMetadataService,
+	prices IPriceService,
+	poolState IPoolStateService,
+	poolLogsDBClient pool.IPoolLogsRepository,
+	poolEventsDBClient pool.IPoolEventsRepository,
+	checkpointDBClient pool.ICheckpointRepository,
+	registryDBClient pool.IPoolRegistryRepository,
+	snapshotDBClient pool.ISnapshotRepository,
+	liquidityDBClient pool.ILiquidityRepository,
+	candleDBClient pool.ICandleRepository,
+	rollupDBClient pool.IRollupRepository,
+) IUniswapV3Pool {
 	log := logger.Logger(ctx)
-	client, err := ethclient.Dial(constants.Config.PoolConfig.INFURA_MAINNET)
+
+	factory, err := newFactoryWatch()
 	if err != nil {
-		log.Fatalf("Failed to connect to the Ethereum client: %v", err)
+		log.Fatalf("Invalid pool factory configuration: %v", err)
 	}
 
 	var poolAddresses []string
 
 	// Use json.Unmarshal to convert the string to a []string
+	// An empty list is fine: pools can be added through the registry API
 	err = json.Unmarshal([]byte(constants.Config.PoolConfig.POOL_ADDRESSES), &poolAddresses)
-	if err != nil || len(poolAddresses) == 0 {
+	if err != nil {
 		log.Fatalf("Error while reading pool addresses")
 	}
 
@@ -47,351 +107,326 @@ func NewUniswapV3Pool(ctx context.Context, poolLogsDBClient pool.IPoolLogsReposi
 		addresses = append(addresses, common.HexToAddress(addr))
 	}
 
-	return &UniswapV3Pool{client: client, addresses: addresses, PoolLogsDBClient: poolLogsDBClient}
+	poolABI, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+	if err != nil {
+		log.Fatalf("Failed to parse contract ABI: %v", err)
+	}
+
+	erc20, err := abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		log.Fatalf("Failed to parse ERC-20 ABI: %v", err)
+	}
+
+	policy, err := newIngestionPolicy(
+		constants.Config.PoolConfig.POOL_INGESTION_POLICY,
+		constants.Config.PoolConfig.POOL_SNAPSHOT_BLOCKS,
+		time.Duration(constants.Config.PoolConfig.POOL_SNAPSHOT_INTERVAL)*time.Second,
+	)
+	if err != nil {
+		log.Fatalf("Invalid pool ingestion policy: %v", err)
+	}
+
+	mode := constants.Config.PoolConfig.POOL_INGESTION_MODE
+	if mode != INGESTION_MODE_SUBSCRIPTION && mode != INGESTION_MODE_POLLING {
+		log.Fatalf("Invalid pool ingestion mode: %q", mode)
+	}
+
+	// Heads and logs arrive on separate subscriptions, so a head may be
+	// handled before the logs of its block have been read. The block is only
+	// confirmed once a later head arrives.
+	confirmations := constants.Config.PoolConfig.POOL_CONFIRMATIONS
+	if mode == INGESTION_MODE_SUBSCRIPTION && confirmations == 0 {
+		log.Warnf("Using 1 confirmation instead of 0 in subscription mode")
+		confirmations = 1
+	}
+
+	pollInterval := time.Duration(constants.Config.PoolConfig.POOL_POLL_INTERVAL) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	return &UniswapV3Pool{
+		client:             rpcClient,
+		configured:         addresses,
+		pools:              newPoolSet(),
+		factory:            factory,
+		poolABI:            poolABI,
+		policy:             policy,
+		mode:               mode,
+		pollInterval:       pollInterval,
+		headTimeout:        defaultHeadTimeout,
+		minBackoff:         time.Duration(constants.Config.PoolConfig.POOL_RECONNECT_MIN_BACKOFF) * time.Second,
+		maxBackoff:         time.Duration(constants.Config.PoolConfig.POOL_RECONNECT_MAX_BACKOFF) * time.Second,
+		backfillChunkSize:  constants.Config.PoolConfig.POOL_BACKFILL_CHUNK_SIZE,
+		confirmations:      confirmations,
+		erc20ABI:           erc20,
+		snapshotInterval:   constants.Config.PoolConfig.POOL_STATE_SNAPSHOT_BLOCKS,
+		lastSnapshots:      make(map[common.Address]uint64),
+		feeProtocols:       make(map[common.Address]uint8),
+		seeder:             newLiquiditySeeder(),
+		PoolLogsDBClient:   poolLogsDBClient,
+		PoolEventsDBClient: poolEventsDBClient,
+		CheckpointDBClient: checkpointDBClient,
+		RegistryDBClient:   registryDBClient,
+		SnapshotDBClient:   snapshotDBClient,
+		LiquidityDBClient:  liquidityDBClient,
+		CandleDBClient:     candleDBClient,
+		RollupDBClient:     rollupDBClient,
+		Registry:           registry,
+		Metadata:           metadata,
+		Prices:             prices,
+		PoolState:          poolState,
+	}
 }
 
-func (u UniswapV3Pool) RunUniswapV3Pool(ctx context.Context) {
+// RunUniswapV3Pool supervises the ingestion sessions, reconnecting with
+// exponential backoff whenever one fails so that the API keeps serving
+func (u *UniswapV3Pool) RunUniswapV3Pool(ctx context.Context) {
 	log := logger.Logger(ctx)
+	backoff := newBackoff(u.minBackoff, u.maxBackoff)
+
+	go u.runSeeder(ctx)
+
+	for {
+		started := time.Now()
+
+		var err error
+		if u.mode == INGESTION_MODE_POLLING {
+			err = u.runPollingSession(ctx)
+		} else {
+			err = u.runSession(ctx)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		if errors.Is(err, errPoolsChanged) {
+			log.Infof("Restarting pool ingestion to follow %d pools", len(u.pools.list()))
+			continue
+		}
+
+		// A session that stayed up for a while starts the backoff afresh
+		if time.Since(started) > backoff.max {
+			backoff.reset()
+		}
+
+		reconnects := u.reconnects.Add(1)
+		delay := backoff.next()
+		log.Errorf("Pool ingestion stopped with error: %v, reconnecting in %s (reconnect #%d)", err, delay, reconnects)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// ReconnectCount returns how many times the ingestion had to reconnect
+func (u *UniswapV3Pool) ReconnectCount() uint64 {
+	return u.reconnects.Load()
+}
+
+// startSession loads the active pools from the registry and fixes the set
+// of addresses followed by the session
+func (u *UniswapV3Pool) startSession(ctx context.Context) error {
+	if err := u.Registry.Seed(ctx, u.configured); err != nil {
+		return fmt.Errorf("registering configured pools: %w", err)
+	}
+
+	tracked, err := u.RegistryDBClient.GetTrackedPools(ctx)
+	if err != nil {
+		return fmt.Errorf("loading tracked pools: %w", err)
+	}
+	u.pools.replace(tracked)
+	u.pools.drain()
+	drain(u.Registry.Changed())
+
+	u.following = u.pools.list()
+	if u.factory != nil {
+		u.following = append(u.following, u.factory.address)
+	}
+
+	u.blocks = newBlockTracker(u.confirmations)
+	u.blockTimes = newBlockTimeCache(u.client)
+	return nil
+}
+
+// runSession subscribes through the best healthy WebSocket endpoint, replays
+// the gap since the last checkpoint and follows the live logs until the
+// subscription fails or the endpoint stops being healthy
+func (u *UniswapV3Pool) runSession(ctx context.Context) error {
+	log := logger.Logger(ctx)
+
+	// Cross-check the endpoints before trusting one with the subscription
+	if err := u.client.CheckHealth(ctx); err != nil {
+		return fmt.Errorf("checking RPC endpoints: %w", err)
+	}
+
+	endpoint, err := u.client.Subscriber()
+	if err != nil {
+		return err
+	}
+
+	client, err := endpoint.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", endpoint.Host(), err)
+	}
+
+	if err := u.startSession(ctx); err != nil {
+		return err
+	}
+
 	query := ethereum.FilterQuery{
-		Addresses: u.addresses,
+		Addresses: u.following,
 	}
 
 	logs := make(chan types.Log)
-	sub, err := u.client.SubscribeFilterLogs(context.Background(), query, logs)
+	sub, err := client.SubscribeFilterLogs(ctx, query, logs)
 	if err != nil {
-		log.Fatalf("Failed to subscribe to logs: %v", err)
-	}
-
-	uniswapPoolABI := `[
-		{
-		  "inputs": [],
-		  "stateMutability": "nonpayable",
-		  "type": "constructor"
-		},
-		{
-		"anonymous": false,
-		"inputs": [
-		  {
-			"indexed": true,
-			"internalType": "uint128",
-			"name": "token0Balance",
-			"type": "uint128"
-		  },
-		  {
-			"indexed": true,
-			"internalType": "uint128",
-			"name": "token1Balance",
-			"type": "uint128"
-		  },
-		  {
-			"indexed": false,
-			"internalType": "int24",
-			"name": "tick",
-			"type": "int24"
-		  },
-		  {
-			"indexed": false,
-			"internalType": "uint256",
-			"name": "token0Delta",
-			"type": "uint256"
-		  },
-		  {
-			"indexed": false,
-			"internalType": "uint256",
-			"name": "token1Delta",
-			"type": "uint256"
-		  }
-		],
-		"name": "PoolUpdate",
-		"type": "event"
-	  },
-		{
-		  "anonymous": false,
-		  "inputs": [
-			{
-			  "indexed": true,
-			  "internalType": "uint24",
-			  "name": "fee",
-			  "type": "uint24"
-			},
-			{
-			  "indexed": true,
-			  "internalType": "int24",
-			  "name": "tickSpacing",
-			  "type": "int24"
-			}
-		  ],
-		  "name": "FeeAmountEnabled",
-		  "type": "event"
-		},
-		{
-		  "anonymous": false,
-		  "inputs": [
-			{
-			  "indexed": true,
-			  "internalType": "address",
-			  "name": "oldOwner",
-			  "type": "address"
-			},
-			{
-			  "indexed": true,
-			  "internalType": "address",
-			  "name": "newOwner",
-			  "type": "address"
-			}
-		  ],
-		  "name": "OwnerChanged",
-		  "type": "event"
-		},
-		{
-		  "anonymous": false,
-		  "inputs": [
-			{
-			  "indexed": true,
-			  "internalType": "address",
-			  "name": "token0",
-			  "type": "address"
-			},
-			{
-			  "indexed": true,
-			  "internalType": "address",
-			  "name": "token1",
-			  "type": "address"
-			},
-			{
-			  "indexed": true,
-			  "internalType": "uint24",
-			  "name": "fee",
-			  "type": "uint24"
-			},
-			{
-			  "indexed": false,
-			  "internalType": "int24",
-			  "name": "tickSpacing",
-			  "type": "int24"
-			},
-			{
-			  "indexed": false,
-			  "internalType": "address",
-			  "name": "pool",
-			  "type": "address"
-			}
-		  ],
-		  "name": "PoolCreated",
-		  "type": "event"
-		},
-		{
-		  "inputs": [
-			{
-			  "internalType": "address",
-			  "name": "tokenA",
-			  "type": "address"
-			},
-			{
-			  "internalType": "address",
-			  "name": "tokenB",
-			  "type": "address"
-			},
-			{
-			  "internalType": "uint24",
-			  "name": "fee",
-			  "type": "uint24"
-			}
-		  ],
-		  "name": "createPool",
-		  "outputs": [
-			{
-			  "internalType": "address",
-			  "name": "pool",
-			  "type": "address"
-			}
-		  ],
-		  "stateMutability": "nonpayable",
-		  "type": "function"
-		},
-		{
-		  "inputs": [
-			{
-			  "internalType": "uint24",
-			  "name": "fee",
-			  "type": "uint24"
-			},
-			{
-			  "internalType": "int24",
-			  "name": "tickSpacing",
-			  "type": "int24"
-			}
-		  ],
-		  "name": "enableFeeAmount",
-		  "outputs": [],
-		  "stateMutability": "nonpayable",
-		  "type": "function"
-		},
-		{
-		  "inputs": [
-			{
-			  "internalType": "uint24",
-			  "name": "",
-			  "type": "uint24"
-			}
-		  ],
-		  "name": "feeAmountTickSpacing",
-		  "outputs": [
-			{
-			  "internalType": "int24",
-			  "name": "",
-			  "type": "int24"
-			}
-		  ],
-		  "stateMutability": "view",
-		  "type": "function"
-		},
-		{
-		  "inputs": [
-			{
-			  "internalType": "address",
-			  "name": "",
-			  "type": "address"
-			},
-			{
-			  "internalType": "address",
-			  "name": "",
-			  "type": "address"
-			},
-			{
-			  "internalType": "uint24",
-			  "name": "",
-			  "type": "uint24"
+		return fmt.Errorf("subscribing to logs: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	heads := make(chan *types.Header)
+	headSub, err := client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return fmt.Errorf("subscribing to new heads: %w", err)
+	}
+	defer headSub.Unsubscribe()
+
+	// Live logs are buffered by the subscription while the gap since the last
+	// checkpoint is replayed, and skipped if they fall inside the replayed range
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("fetching chain head: %w", err)
+	}
+	head := header.Number.Uint64()
+
+	if err := u.catchUp(ctx, head); err != nil {
+		return fmt.Errorf("catching up to block %d: %w", head, err)
+	}
+	u.blocks.add(head, header.Hash())
+
+	log.Infof("Following pool logs from block %d through %s", head, endpoint.Host())
+
+	// A connection that stops delivering heads is treated as failed
+	watchdog := time.NewTimer(u.headTimeout)
+	defer watchdog.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			err = fmt.Errorf("log subscription: %w", err)
+			endpoint.MarkFailed(err)
+			return err
+		case err := <-headSub.Err():
+			err = fmt.Errorf("new head subscription: %w", err)
+			endpoint.MarkFailed(err)
+			return err
+		case <-watchdog.C:
+			err := fmt.Errorf("no new head received for %s", u.headTimeout)
+			endpoint.MarkFailed(err)
+			return err
+		case <-u.pools.changed:
+			return errPoolsChanged
+		case <-u.Registry.Changed():
+			return errPoolsChanged
+		case header := <-heads:
+			if !watchdog.Stop() {
+				<-watchdog.C
 			}
-		  ],
-		  "name": "getPool",
-		  "outputs": [
-			{
-			  "internalType": "address",
-			  "name": "",
-			  "type": "address"
+			watchdog.Reset(u.headTimeout)
+
+			// Fail over once the health checks find the endpoint lagging or forked
+			if !endpoint.Healthy() {
+				return fmt.Errorf("endpoint %s is no longer healthy", endpoint.Host())
 			}
-		  ],
-		  "stateMutability": "view",
-		  "type": "function"
-		},
-		{
-		  "inputs": [],
-		  "name": "owner",
-		  "outputs": [
-			{
-			  "internalType": "address",
-			  "name": "",
-			  "type": "address"
+
+			// A rollback that failed partway has not replayed the orphaned
+			// range, so the session is restarted from the checkpoint rather
+			// than confirming the next head past it
+			if err := u.handleHead(ctx, header); err != nil {
+				return fmt.Errorf("processing block %d: %w", header.Number.Uint64(), err)
 			}
-		  ],
-		  "stateMutability": "view",
-		  "type": "function"
-		},
-		{
-		  "inputs": [],
-		  "name": "parameters",
-		  "outputs": [
-			{
-			  "internalType": "address",
-			  "name": "factory",
-			  "type": "address"
-			},
-			{
-			  "internalType": "address",
-			  "name": "token0",
-			  "type": "address"
-			},
-			{
-			  "internalType": "address",
-			  "name": "token1",
-			  "type": "address"
-			},
-			{
-			  "internalType": "uint24",
-			  "name": "fee",
-			  "type": "uint24"
-			},
-			{
-			  "internalType": "int24",
-			  "name": "tickSpacing",
-			  "type": "int24"
+		case vLog := <-logs:
+			if !vLog.Removed && vLog.BlockNumber <= head {
+				continue
 			}
-		  ],
-		  "stateMutability": "view",
-		  "type": "function"
-		},
-		{
-		  "inputs": [
-			{
-			  "internalType": "address",
-			  "name": "_owner",
-			  "type": "address"
+			// The session is restarted from the checkpoint, which has not
+			// moved past the failed log
+			if err := u.handleLog(ctx, vLog); err != nil {
+				return err
 			}
-		  ],
-		  "name": "setOwner",
-		  "outputs": [],
-		  "stateMutability": "nonpayable",
-		  "type": "function"
 		}
-	  ]`
-	contractAbi, err := abi.JSON(strings.NewReader(uniswapPoolABI))
+	}
+}
+
+// handleLog decodes a single pool log and persists it. Logs of events that
+// are not stored are skipped; any other failure is returned so that the
+// caller stops before the checkpoint moves past the log.
+func (u *UniswapV3Pool) handleLog(ctx context.Context, vLog types.Log) error {
+	log := logger.Logger(ctx)
+
+	if u.isFactory(vLog.Address) {
+		return u.handleFactoryLog(ctx, vLog)
+	}
+
+	if vLog.Removed {
+		log.Warnf("Removing log %d of txn %s from orphaned block %s", vLog.Index, vLog.TxHash.String(), vLog.BlockHash.String())
+		if err := u.removeLog(ctx, vLog); err != nil {
+			return fmt.Errorf("removing orphaned log %d of txn %s: %w", vLog.Index, vLog.TxHash.String(), err)
+		}
+		return nil
+	}
+
+	event, err := decodeLog(u.poolABI, vLog)
 	if err != nil {
-		log.Fatalf("Failed to parse contract ABI: %v", err)
+		if errors.Is(err, errUnhandledEvent) {
+			log.Debugf("Skipping log %s: %v", vLog.TxHash.String(), err)
+			return nil
+		}
+		return fmt.Errorf("decoding log %d of txn %s: %w", vLog.Index, vLog.TxHash.String(), err)
 	}
-	var count uint64 = 0
-
-	go func() {
-		for {
-			select {
-			case err := <-sub.Err():
-				log.Fatalf("Received subscription error: %v", err)
-			case vLog := <-logs:
-				count++
-				if count%12 == 0 {
-					event := struct {
-						Token0Balance *big.Int
-						Token1Balance *big.Int
-						Tick          *big.Int
-						Token0Delta   *big.Int
-						Token1Delta   *big.Int
-					}{}
-
-					err := contractAbi.UnpackIntoInterface(&event, "PoolUpdate", vLog.Data)
-					if err != nil {
-						log.Fatalf("Failed to unpack data into PoolUpdate event: %v", err)
-					}
-
-					var token0Bal, token1Bal *big.Int
-					if len(vLog.Topics) > 1 {
-						token0Bal = vLog.Topics[1].Big()
-					}
-
-					if len(vLog.Topics) > 2 {
-						token1Bal = vLog.Topics[2].Big()
-					}
-
-					logs := posts.Logs{
-						PoolAddress:   vLog.Address.String(),
-						TxnId:         vLog.TxHash.String(),
-						BlockNumber:   vLog.BlockNumber,
-						Token0Balance: token0Bal.Int64(),
-						Token1Balance: token1Bal.Int64(),
-						Token0Delta:   event.Token0Delta.Int64(),
-						Token1Delta:   event.Token1Delta.Int64(),
-						Tick:          event.Tick.Int64(),
-					}
-
-					log.Info("Get Block Info", logs)
-
-					if err := u.PoolLogsDBClient.StorePoolLogs(ctx, logs); err != nil {
-						log.Error("error while storing logs")
-					}
-				}
-			}
+
+	log.Infof("Received pool event %T in txn %s", event, vLog.TxHash.String())
+
+	swap, ok := event.(*SwapEvent)
+	if !ok {
+		if err := u.storeEvent(ctx, event); err != nil {
+			return fmt.Errorf("storing %T event of txn %s: %w", event, vLog.TxHash.String(), err)
 		}
-	}()
+		return nil
+	}
+
+	stored, err := u.storeSwap(ctx, swap)
+	if err != nil {
+		return fmt.Errorf("storing %T event of txn %s: %w", event, vLog.TxHash.String(), err)
+	}
 
-	// Keep the program running indefinitely
-	select {}
+	if !u.policy.shouldStore(vLog.Address, vLog.BlockNumber, stored.BlockTimestamp) {
+		return nil
+	}
+
+	logs := posts.Logs{
+		PoolAddress:     vLog.Address.String(),
+		TxnId:           vLog.TxHash.String(),
+		BlockNumber:     vLog.BlockNumber,
+		BlockHash:       vLog.BlockHash.String(),
+		LogIndex:        vLog.Index,
+		Token0Delta:     numeric.NewBigInt(swap.Amount0),
+		Token1Delta:     numeric.NewBigInt(swap.Amount1),
+		Tick:            swap.Tick.Int64(),
+		Token1PerToken0: stored.Token1PerToken0,
+		Token0PerToken1: stored.Token0PerToken1,
+	}
+
+	log.Info("Get Block Info", logs)
+
+	if err := u.PoolLogsDBClient.StorePoolLogs(ctx, logs); err != nil {
+		return fmt.Errorf("storing pool log of txn %s: %w", vLog.TxHash.String(), err)
+	}
+	return nil
 }
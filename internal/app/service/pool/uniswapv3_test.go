@@ -0,0 +1,103 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"uniswapper/internal/app/constants"
+	posts "uniswapper/internal/app/db/dto/pool"
+	"uniswapper/internal/app/service/util/testutils/ethnode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUniswapV3PoolConfirmations(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name          string
+		mode          string
+		confirmations uint64
+		expected      uint64
+	}{
+		{name: "subscription", mode: INGESTION_MODE_SUBSCRIPTION, confirmations: 12, expected: 12},
+		// A head is never confirmed before the logs of its block are read
+		{name: "subscription without confirmations", mode: INGESTION_MODE_SUBSCRIPTION, expected: 1},
+		{name: "polling without confirmations", mode: INGESTION_MODE_POLLING, expected: 0},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := constants.Config.PoolConfig
+			defer func() { constants.Config.PoolConfig = cfg }()
+
+			constants.Config.PoolConfig.POOL_INGESTION_MODE = tc.mode
+			constants.Config.PoolConfig.POOL_CONFIRMATIONS = tc.confirmations
+
+			u := NewUniswapV3Pool(context.Background(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			assert.Equal(t, tc.expected, u.(*UniswapV3Pool).confirmations)
+		})
+	}
+}
+
+func TestRunUniswapV3PoolReconnects(t *testing.T) {
+	setupTest(t)
+
+	node, client := newTestNode(t, 10)
+	u, stores := newTestPool(t, client, 100)
+	u.Registry = staticRegistry{}
+	u.mode = INGESTION_MODE_POLLING
+	u.pollInterval = time.Hour
+	u.headTimeout = time.Hour
+	u.minBackoff = time.Millisecond
+	u.maxBackoff = 2 * time.Millisecond
+	node.AddLog(mintLog(8))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pool := common.HexToAddress(usdcWethPool).String()
+	stores.registry.EXPECT().GetTrackedPools(gomock.Any()).Return([]posts.Pool{{Address: pool, Tracked: true}}, nil).Times(2)
+	// The checkpoint is not advanced by the failed session, so both sessions
+	// replay the blocks after it
+	stores.checkpoints.EXPECT().GetCheckpoint(gomock.Any(), pool).Return(&posts.Checkpoint{PoolAddress: pool, BlockNumber: 5}, nil).Times(2)
+	stores.events.EXPECT().DeleteEventsAfterBlock(gomock.Any(), pool, uint64(5)).Return(nil).Times(2)
+	stores.candles.EXPECT().RebuildCandlesAfterBlock(gomock.Any(), pool, uint64(5)).Return(nil).Times(2)
+	stores.rollups.EXPECT().RebuildRollupsAfterBlock(gomock.Any(), pool, uint64(5)).Return(nil).Times(2)
+	stores.logs.EXPECT().DeletePoolLogsAfterBlock(gomock.Any(), pool, uint64(5)).Return(nil).Times(2)
+	stores.liquidity.EXPECT().DeleteSeedAfterBlock(gomock.Any(), pool, uint64(5)).Return(nil).Times(2)
+	stores.snapshots.EXPECT().DeleteSnapshotsAfterBlock(gomock.Any(), pool, uint64(5)).Return(nil).Times(2)
+	stores.liquidity.EXPECT().GetSeed(gomock.Any(), pool).Return(&posts.LiquiditySeed{PoolAddress: pool}, nil).Times(2)
+
+	// The mint fails to store in the first session and is stored by the
+	// second, which then ends the test
+	stored := 0
+	stores.events.EXPECT().StoreMint(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, posts.Mint) error {
+		stored++
+		if stored == 1 {
+			return errors.New("connection reset")
+		}
+		cancel()
+		return nil
+	}).Times(2)
+
+	done := make(chan struct{})
+	go func() {
+		u.RunUniswapV3Pool(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the ingestion did not stop")
+	}
+
+	assert.Equal(t, uint64(1), u.ReconnectCount())
+	assert.Equal(t, []ethnode.Range{{From: 6, To: 10}, {From: 6, To: 10}}, node.Queries())
+}
@@ -0,0 +1,508 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"uniswapper/internal/app/constants"
+	"uniswapper/internal/app/service/logger"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+var errNoEndpoints = errors.New("no RPC endpoint available")
+
+const defaultHealthCheckInterval = 30 * time.Second
+
+// Client spreads requests over several RPC endpoints. Each endpoint is scored
+// by its observed latency and error rate; requests go to the best scoring
+// healthy endpoint and fail over to the next one on any error but a revert.
+// Subscriptions are only served by WebSocket endpoints.
+type Client struct {
+	endpoints  []*Endpoint
+	maxHeadLag uint64
+}
+
+// NewClient creates a client over the configured endpoints, falling back to
+// INFURA_MAINNET when no endpoint list is configured
+func NewClient(ctx context.Context) *Client {
+	log := logger.Logger(ctx)
+
+	var wsURLs, httpURLs []string
+	if err := parseEndpoints(constants.Config.RPCConfig.RPC_WS_ENDPOINTS, &wsURLs); err != nil {
+		log.Fatalf("Error while reading RPC WebSocket endpoints: %v", err)
+	}
+	if err := parseEndpoints(constants.Config.RPCConfig.RPC_HTTP_ENDPOINTS, &httpURLs); err != nil {
+		log.Fatalf("Error while reading RPC HTTP endpoints: %v", err)
+	}
+
+	if len(wsURLs)+len(httpURLs) == 0 {
+		fallback := constants.Config.PoolConfig.INFURA_MAINNET
+		if strings.HasPrefix(fallback, "ws") {
+			wsURLs = append(wsURLs, fallback)
+		} else {
+			httpURLs = append(httpURLs, fallback)
+		}
+	}
+
+	client, err := newClient(wsURLs, httpURLs, constants.Config.RPCConfig.RPC_MAX_HEAD_LAG)
+	if err != nil {
+		log.Fatalf("Error while creating RPC client: %v", err)
+	}
+	return client
+}
+
+func parseEndpoints(raw string, out *[]string) error {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(raw), out)
+}
+
+// NewClientWithEndpoints creates a client over the given endpoints instead of
+// the configured ones
+func NewClientWithEndpoints(wsURLs, httpURLs []string, maxHeadLag uint64) (*Client, error) {
+	return newClient(wsURLs, httpURLs, maxHeadLag)
+}
+
+// newClient creates a client over the given endpoints. Connections are
+// established lazily so that an unreachable provider does not prevent startup.
+func newClient(wsURLs, httpURLs []string, maxHeadLag uint64) (*Client, error) {
+	if len(wsURLs)+len(httpURLs) == 0 {
+		return nil, errNoEndpoints
+	}
+
+	c := &Client{maxHeadLag: maxHeadLag}
+	for _, rawURL := range wsURLs {
+		c.endpoints = append(c.endpoints, newEndpoint(rawURL, true))
+	}
+	for _, rawURL := range httpURLs {
+		c.endpoints = append(c.endpoints, newEndpoint(rawURL, false))
+	}
+	return c, nil
+}
+
+// Close closes every open connection
+func (c *Client) Close() {
+	for _, e := range c.endpoints {
+		e.close()
+	}
+}
+
+// RunHealthChecks checks the endpoints on the configured interval until ctx is done
+func (c *Client) RunHealthChecks(ctx context.Context) {
+	log := logger.Logger(ctx)
+
+	interval := time.Duration(constants.Config.RPCConfig.RPC_HEALTH_CHECK_INTERVAL) * time.Second
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.CheckHealth(ctx); err != nil {
+				log.Errorf("RPC health check failed: %v", err)
+			}
+		}
+	}
+}
+
+// Subscriber returns the best scoring healthy WebSocket endpoint
+func (c *Client) Subscriber() (*Endpoint, error) {
+	for _, e := range c.ranked() {
+		if e.websocket && e.Healthy() {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no healthy WebSocket endpoint", errNoEndpoints)
+}
+
+// Stats returns a snapshot of the health of every endpoint
+func (c *Client) Stats() []EndpointStats {
+	stats := make([]EndpointStats, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		stats = append(stats, e.stats())
+	}
+	return stats
+}
+
+// CheckHealth refreshes the head of every endpoint, marks endpoints lagging
+// more than maxHeadLag blocks behind the highest head as unhealthy, and
+// cross-checks the block hash at a common height so that an endpoint serving
+// a different chain than the majority is not trusted
+func (c *Client) CheckHealth(ctx context.Context) error {
+	var maxHead uint64
+	var reachable []*Endpoint
+	for _, e := range c.endpoints {
+		header, err := e.headerByNumber(ctx, nil)
+		if err != nil {
+			e.setHealthy(false)
+			continue
+		}
+		e.setHead(header.Number.Uint64())
+		reachable = append(reachable, e)
+		if header.Number.Uint64() > maxHead {
+			maxHead = header.Number.Uint64()
+		}
+	}
+
+	var synced []*Endpoint
+	minHead := maxHead
+	for _, e := range reachable {
+		head := e.stats().Head
+		if head+c.maxHeadLag < maxHead {
+			e.setHealthy(false)
+			continue
+		}
+		synced = append(synced, e)
+		if head < minHead {
+			minHead = head
+		}
+	}
+
+	if len(synced) == 0 {
+		return fmt.Errorf("%w: all endpoints are unreachable or lagging", errNoEndpoints)
+	}
+
+	// Compare the block hash at the lowest common head between the synced endpoints
+	votes := make(map[common.Hash][]*Endpoint)
+	hashes := make(map[*Endpoint]common.Hash)
+	for _, e := range synced {
+		header, err := e.headerByNumber(ctx, new(big.Int).SetUint64(minHead))
+		if err != nil {
+			e.setHealthy(false)
+			continue
+		}
+		votes[header.Hash()] = append(votes[header.Hash()], e)
+		hashes[e] = header.Hash()
+	}
+
+	// On a tie, the hash returned by the best scoring endpoint wins
+	var majority []*Endpoint
+	for _, e := range c.ranked() {
+		hash, ok := hashes[e]
+		if ok && len(votes[hash]) > len(majority) {
+			majority = votes[hash]
+		}
+	}
+	for _, endpoints := range votes {
+		healthy := endpoints[0] == majority[0]
+		for _, e := range endpoints {
+			e.setHealthy(healthy)
+		}
+	}
+
+	if len(majority) == 0 {
+		return fmt.Errorf("%w: no endpoint returned block %d", errNoEndpoints, minHead)
+	}
+	return nil
+}
+
+// ranked returns the endpoints ordered from best to worst score, healthy first
+func (c *Client) ranked() []*Endpoint {
+	ranked := make([]*Endpoint, len(c.endpoints))
+	copy(ranked, c.endpoints)
+
+	scores := make(map[*Endpoint]float64, len(ranked))
+	for _, e := range ranked {
+		scores[e] = e.score()
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i]] < scores[ranked[j]]
+	})
+	return ranked
+}
+
+// do runs fn against the ranked endpoints until one succeeds. A reverted call
+// would revert on any endpoint, so it is returned without failing over and is
+// not held against the endpoint. Missing data is asked of the next endpoint,
+// which may be further ahead, and only reported as not found once every
+// endpoint is missing it. Any other error, including rate limits and state an
+// endpoint has pruned, fails over and counts in the endpoint's error rate.
+func (c *Client) do(ctx context.Context, fn func(client *ethclient.Client) error) error {
+	lastErr := errNoEndpoints
+	endpoints := c.ranked()
+	notFound := 0
+	for _, e := range endpoints {
+		client, err := e.conn(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		start := time.Now()
+		err = fn(client)
+		if errors.Is(err, ethereum.NotFound) {
+			e.record(time.Since(start), nil)
+			notFound++
+			continue
+		}
+		if err == nil || IsRevert(err) {
+			e.record(time.Since(start), nil)
+			return err
+		}
+		e.record(time.Since(start), err)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		lastErr = err
+	}
+	if notFound == len(endpoints) {
+		return ethereum.NotFound
+	}
+	return lastErr
+}
+
+// revertCode is the JSON-RPC error code of a call reverted by the EVM
+const revertCode = 3
+
+// IsRevert reports whether err is a call reverted by the contract, rather
+// than a failure of the endpoint. Some providers answer reverts with the
+// generic error code, so the message is checked as well.
+func IsRevert(err error) bool {
+	var rpcErr gethrpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return rpcErr.ErrorCode() == revertCode || strings.Contains(rpcErr.Error(), "execution reverted")
+}
+
+// RevertReason returns the reason of a reverted call, taken from the revert
+// data when the node returned it and from the error message otherwise
+func RevertReason(err error) string {
+	var dataErr gethrpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, err := abi.UnpackRevert(common.FromHex(data)); err == nil {
+				return reason
+			}
+		}
+	}
+
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) {
+		if _, reason, ok := strings.Cut(rpcErr.Error(), "execution reverted: "); ok {
+			return reason
+		}
+	}
+	return ""
+}
+
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	var number uint64
+	err := c.do(ctx, func(client *ethclient.Client) (err error) {
+		number, err = client.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := c.do(ctx, func(client *ethclient.Client) (err error) {
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := c.do(ctx, func(client *ethclient.Client) (err error) {
+		logs, err = client.FilterLogs(ctx, q)
+		return err
+	})
+	return logs, err
+}
+
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := c.do(ctx, func(client *ethclient.Client) (err error) {
+		result, err = client.CallContract(ctx, msg, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// EndpointStats describes the observed health of an endpoint
+type EndpointStats struct {
+	Host      string  `json:"host"`
+	WebSocket bool    `json:"websocket"`
+	Healthy   bool    `json:"healthy"`
+	LatencyMs float64 `json:"latency_ms"`
+	ErrorRate float64 `json:"error_rate"`
+	Head      uint64  `json:"head"`
+	Requests  uint64  `json:"requests"`
+	Failures  uint64  `json:"failures"`
+}
+
+// ewmaWeight is the weight of the latest observation in the moving averages
+const ewmaWeight = 0.2
+
+// Endpoint is a single RPC provider URL with its health statistics
+type Endpoint struct {
+	url       string
+	websocket bool
+
+	mu        sync.Mutex
+	client    *ethclient.Client
+	healthy   bool
+	latency   float64
+	errorRate float64
+	head      uint64
+	requests  uint64
+	failures  uint64
+}
+
+func newEndpoint(rawURL string, websocket bool) *Endpoint {
+	return &Endpoint{url: rawURL, websocket: websocket, healthy: true}
+}
+
+// Client returns the connection of the endpoint, dialing it if needed
+func (e *Endpoint) Client(ctx context.Context) (*ethclient.Client, error) {
+	return e.conn(ctx)
+}
+
+// Host returns the endpoint host, which unlike the URL carries no API key
+func (e *Endpoint) Host() string {
+	if u, err := url.Parse(e.url); err == nil {
+		return u.Host
+	}
+	return ""
+}
+
+// Healthy reports whether the endpoint passed its last health check
+func (e *Endpoint) Healthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.healthy
+}
+
+// MarkFailed records a failure observed outside of the client, such as a
+// dropped subscription, and drops the connection so that it is redialed
+func (e *Endpoint) MarkFailed(err error) {
+	e.record(0, err)
+	e.close()
+}
+
+func (e *Endpoint) conn(ctx context.Context) (*ethclient.Client, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		return e.client, nil
+	}
+
+	client, err := ethclient.DialContext(ctx, e.url)
+	if err != nil {
+		e.observe(0, err)
+		return nil, fmt.Errorf("dialing %s: %w", e.Host(), err)
+	}
+	e.client = client
+	return client, nil
+}
+
+func (e *Endpoint) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		e.client.Close()
+		e.client = nil
+	}
+}
+
+func (e *Endpoint) headerByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	client, err := e.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	header, err := client.HeaderByNumber(ctx, number)
+	e.record(time.Since(start), err)
+	return header, err
+}
+
+func (e *Endpoint) record(latency time.Duration, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.observe(latency, err)
+}
+
+// observe updates the moving averages, the caller must hold the lock
+func (e *Endpoint) observe(latency time.Duration, err error) {
+	e.requests++
+	failed := 0.0
+	if err != nil {
+		e.failures++
+		failed = 1
+	} else {
+		ms := float64(latency) / float64(time.Millisecond)
+		if e.latency == 0 {
+			e.latency = ms
+		} else {
+			e.latency = ewmaWeight*ms + (1-ewmaWeight)*e.latency
+		}
+	}
+	e.errorRate = ewmaWeight*failed + (1-ewmaWeight)*e.errorRate
+}
+
+// score is lower for faster and more reliable endpoints. Unhealthy endpoints
+// are only tried after every healthy one.
+func (e *Endpoint) score() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	score := (e.latency + 1) * (1 + 10*e.errorRate)
+	if !e.healthy {
+		score += 1e9
+	}
+	return score
+}
+
+func (e *Endpoint) setHealthy(healthy bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy = healthy
+}
+
+func (e *Endpoint) setHead(head uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.head = head
+}
+
+func (e *Endpoint) stats() EndpointStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return EndpointStats{
+		Host:      e.Host(),
+		WebSocket: e.websocket,
+		Healthy:   e.healthy,
+		LatencyMs: e.latency,
+		ErrorRate: e.errorRate,
+		Head:      e.head,
+		Requests:  e.requests,
+		Failures:  e.failures,
+	}
+}
@@ -0,0 +1,392 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+	"uniswapper/internal/app/service/util/testutils/ethnode"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient starts a fake node per endpoint, each mined to head
+func newTestClient(t *testing.T, endpoints int, head uint64, maxHeadLag uint64) (*Client, []*ethnode.Node) {
+	var nodes []*ethnode.Node
+	var urls []string
+	for i := 0; i < endpoints; i++ {
+		node := ethnode.New()
+		node.Mine(head)
+		t.Cleanup(node.Close)
+		nodes = append(nodes, node)
+		urls = append(urls, node.URL())
+	}
+
+	client, err := newClient(nil, urls, maxHeadLag)
+	assert.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client, nodes
+}
+
+func TestEndpointScore(t *testing.T) {
+	errTimeout := errors.New("i/o timeout")
+
+	type observation struct {
+		latency time.Duration
+		err     error
+	}
+
+	testCases := []struct {
+		name         string
+		observations []observation
+		unhealthy    bool
+		latency      float64
+		errorRate    float64
+		failures     uint64
+		score        float64
+	}{
+		{
+			name:  "unused",
+			score: 1,
+		},
+		{
+			name:         "first latency is taken as is",
+			observations: []observation{{latency: 10 * time.Millisecond}},
+			latency:      10,
+			score:        11,
+		},
+		{
+			name: "moving average",
+			observations: []observation{
+				{latency: 10 * time.Millisecond},
+				{latency: 20 * time.Millisecond},
+				{latency: 20 * time.Millisecond},
+			},
+			// 0.2*20 + 0.8*10, then 0.2*20 + 0.8*12
+			latency: 13.6,
+			score:   14.6,
+		},
+		{
+			name: "errors keep the latency",
+			observations: []observation{
+				{latency: 10 * time.Millisecond},
+				{err: errTimeout},
+				{err: errTimeout},
+			},
+			latency: 10,
+			// 0.2, then 0.2 + 0.8*0.2
+			errorRate: 0.36,
+			failures:  2,
+			score:     11 * 4.6,
+		},
+		{
+			name: "errors fade out",
+			observations: []observation{
+				{err: errTimeout},
+				{latency: 4 * time.Millisecond},
+			},
+			latency:   4,
+			errorRate: 0.16,
+			failures:  1,
+			score:     5 * 2.6,
+		},
+		{
+			name:         "unhealthy",
+			observations: []observation{{latency: 10 * time.Millisecond}},
+			unhealthy:    true,
+			latency:      10,
+			score:        11 + 1e9,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			e := newEndpoint("http://localhost:8545", false)
+			for _, o := range tc.observations {
+				e.record(o.latency, o.err)
+			}
+			e.setHealthy(!tc.unhealthy)
+
+			stats := e.stats()
+			assert.InDelta(t, tc.latency, stats.LatencyMs, 1e-9)
+			assert.InDelta(t, tc.errorRate, stats.ErrorRate, 1e-9)
+			assert.Equal(t, uint64(len(tc.observations)), stats.Requests)
+			assert.Equal(t, tc.failures, stats.Failures)
+			assert.InDelta(t, tc.score, e.score(), 1e-6)
+		})
+	}
+}
+
+func TestRanked(t *testing.T) {
+	client, err := newClient(nil, []string{"http://a", "http://b", "http://c", "http://d"}, 0)
+	assert.NoError(t, err)
+	a, b, c, d := client.endpoints[0], client.endpoints[1], client.endpoints[2], client.endpoints[3]
+
+	a.record(10*time.Millisecond, nil)
+	b.record(2*time.Millisecond, nil)
+	// Faster than a, but failing
+	c.record(5*time.Millisecond, nil)
+	c.record(0, errors.New("connection refused"))
+	// The fastest, but unhealthy
+	d.record(time.Millisecond, nil)
+	d.setHealthy(false)
+
+	assert.Equal(t, []*Endpoint{b, a, c, d}, client.ranked())
+}
+
+func TestFailover(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("transport error", func(t *testing.T) {
+		client, nodes := newTestClient(t, 2, 10, 0)
+		nodes[0].SetDown(true)
+
+		number, err := client.BlockNumber(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10), number)
+
+		stats := client.Stats()
+		assert.Equal(t, uint64(1), stats[0].Failures)
+		assert.Equal(t, uint64(0), stats[1].Failures)
+		assert.Equal(t, 1, nodes[1].Requests("eth_blockNumber"))
+
+		// The failing endpoint is now ranked last
+		assert.Equal(t, client.endpoints[1], client.ranked()[0])
+	})
+
+	t.Run("all endpoints down", func(t *testing.T) {
+		client, nodes := newTestClient(t, 2, 10, 0)
+		nodes[0].SetDown(true)
+		nodes[1].SetDown(true)
+
+		_, err := client.BlockNumber(ctx)
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ethereum.NotFound))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// The best endpoint is behind and does not have block 10 yet
+		client, nodes := newTestClient(t, 2, 10, 0)
+		nodes[0].SetLag(2)
+
+		header, err := client.HeaderByNumber(ctx, big.NewInt(10))
+		assert.NoError(t, err)
+		assert.Equal(t, nodes[1].Header(10).Hash(), header.Hash())
+
+		// Missing data is not a failure of the endpoint
+		stats := client.Stats()
+		assert.Equal(t, uint64(1), stats[0].Requests)
+		assert.Equal(t, uint64(0), stats[0].Failures)
+	})
+
+	t.Run("not found anywhere", func(t *testing.T) {
+		client, nodes := newTestClient(t, 2, 10, 0)
+
+		_, err := client.HeaderByNumber(ctx, big.NewInt(11))
+		assert.ErrorIs(t, err, ethereum.NotFound)
+		assert.Equal(t, 1, nodes[0].Requests("eth_getBlockByNumber"))
+		assert.Equal(t, 1, nodes[1].Requests("eth_getBlockByNumber"))
+	})
+
+	t.Run("revert", func(t *testing.T) {
+		// A reverted call would revert on any endpoint
+		client, nodes := newTestClient(t, 2, 10, 0)
+		to := common.HexToAddress("0x01")
+
+		_, err := client.CallContract(ctx, ethereum.CallMsg{To: &to}, nil)
+		assert.Error(t, err)
+		assert.True(t, IsRevert(err))
+		assert.Equal(t, 0, nodes[1].Requests("eth_call"))
+		assert.Equal(t, uint64(0), client.Stats()[0].Failures)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		client, nodes := newTestClient(t, 2, 10, 0)
+		nodes[0].LogsError = func(from, to uint64) error {
+			return &ethnode.Error{Code: -32005, Message: "limit exceeded"}
+		}
+
+		_, err := client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(10)})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, nodes[1].Requests("eth_getLogs"))
+		assert.Equal(t, uint64(1), client.Stats()[0].Failures)
+		assert.Equal(t, client.endpoints[1], client.ranked()[0])
+	})
+
+	t.Run("pruned state", func(t *testing.T) {
+		// The best endpoint is not an archive node
+		client, nodes := newTestClient(t, 2, 10, 0)
+		nodes[0].Call = func(common.Address, []byte, uint64) ([]byte, error) {
+			return nil, &ethnode.Error{Code: -32000, Message: "missing trie node"}
+		}
+		nodes[1].Call = func(common.Address, []byte, uint64) ([]byte, error) {
+			return []byte{1}, nil
+		}
+		to := common.HexToAddress("0x01")
+
+		result, err := client.CallContract(ctx, ethereum.CallMsg{To: &to}, big.NewInt(2))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1}, result)
+		assert.Equal(t, uint64(1), client.Stats()[0].Failures)
+	})
+}
+
+func TestRevertReason(t *testing.T) {
+	client, nodes := newTestClient(t, 1, 10, 0)
+	// The reason is only in the revert data
+	revert := ethnode.Revert("Invalid token ID").(*ethnode.Error)
+	nodes[0].Call = func(common.Address, []byte, uint64) ([]byte, error) {
+		return nil, &ethnode.Error{Code: revert.Code, Message: "execution reverted", Data: revert.Data}
+	}
+	to := common.HexToAddress("0x01")
+
+	_, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &to}, nil)
+	assert.True(t, IsRevert(err))
+	assert.Equal(t, "Invalid token ID", RevertReason(err))
+
+	// Providers that only return the message
+	assert.Equal(t, "Invalid token ID", RevertReason(&ethnode.Error{Code: -32000, Message: "execution reverted: Invalid token ID"}))
+	assert.Equal(t, "", RevertReason(&ethnode.Error{Code: 3, Message: "execution reverted"}))
+	assert.Equal(t, "", RevertReason(errors.New("connection refused")))
+}
+
+func TestIsRevert(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		revert bool
+	}{
+		{name: "revert code", err: &ethnode.Error{Code: 3, Message: "execution reverted: Invalid token ID"}, revert: true},
+		{name: "revert message", err: &ethnode.Error{Code: -32000, Message: "execution reverted"}, revert: true},
+		{name: "rate limit", err: &ethnode.Error{Code: -32005, Message: "limit exceeded"}},
+		{name: "header not found", err: &ethnode.Error{Code: -32000, Message: "header not found"}},
+		{name: "missing trie node", err: &ethnode.Error{Code: -32000, Message: "missing trie node"}},
+		{name: "transport", err: errors.New("connection refused")},
+		{name: "wrapped", err: fmt.Errorf("calling decimals(): %w", &ethnode.Error{Code: 3, Message: "execution reverted"}), revert: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.revert, IsRevert(tc.err))
+		})
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	testCases := []struct {
+		name      string
+		endpoints int
+		// setup diverges the nodes and endpoints before the check
+		setup   func(client *Client, nodes []*ethnode.Node)
+		healthy []bool
+		err     error
+	}{
+		{
+			name:      "in sync",
+			endpoints: 3,
+			setup:     func(*Client, []*ethnode.Node) {},
+			healthy:   []bool{true, true, true},
+		},
+		{
+			name:      "lag within the limit",
+			endpoints: 2,
+			setup: func(_ *Client, nodes []*ethnode.Node) {
+				nodes[1].SetLag(2)
+			},
+			healthy: []bool{true, true},
+		},
+		{
+			name:      "lagging",
+			endpoints: 3,
+			setup: func(_ *Client, nodes []*ethnode.Node) {
+				nodes[2].SetLag(3)
+			},
+			healthy: []bool{true, true, false},
+		},
+		{
+			name:      "unreachable",
+			endpoints: 2,
+			setup: func(_ *Client, nodes []*ethnode.Node) {
+				nodes[0].SetDown(true)
+			},
+			healthy: []bool{false, true},
+		},
+		{
+			name:      "minority fork",
+			endpoints: 3,
+			setup: func(_ *Client, nodes []*ethnode.Node) {
+				nodes[0].Reorg(8)
+			},
+			healthy: []bool{false, true, true},
+		},
+		{
+			// The common height is the head of the lagging endpoint, where
+			// the fork is visible
+			name:      "fork below the lagging head",
+			endpoints: 3,
+			setup: func(_ *Client, nodes []*ethnode.Node) {
+				nodes[1].SetLag(1)
+				nodes[2].Reorg(9)
+			},
+			healthy: []bool{true, true, false},
+		},
+		{
+			name:      "tie won by the best endpoint",
+			endpoints: 2,
+			setup: func(client *Client, nodes []*ethnode.Node) {
+				nodes[0].Reorg(8)
+				client.endpoints[1].record(0, errors.New("connection refused"))
+				client.endpoints[1].setHealthy(false)
+			},
+			healthy: []bool{true, false},
+		},
+		{
+			name:      "tie won by the other best endpoint",
+			endpoints: 2,
+			setup: func(client *Client, nodes []*ethnode.Node) {
+				nodes[0].Reorg(8)
+				client.endpoints[0].record(0, errors.New("connection refused"))
+				client.endpoints[0].setHealthy(false)
+			},
+			healthy: []bool{false, true},
+		},
+		{
+			name:      "all down",
+			endpoints: 2,
+			setup: func(_ *Client, nodes []*ethnode.Node) {
+				nodes[0].SetDown(true)
+				nodes[1].SetDown(true)
+			},
+			healthy: []bool{false, false},
+			err:     errNoEndpoints,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			client, nodes := newTestClient(t, tc.endpoints, 10, 2)
+			tc.setup(client, nodes)
+
+			err := client.CheckHealth(context.Background())
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			for i, e := range client.endpoints {
+				assert.Equal(t, tc.healthy[i], e.Healthy(), "endpoint %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+// Package ethnode serves a fake Ethereum chain over JSON-RPC, so that code
+// using an RPC client can be tested against real requests and responses.
+package ethnode
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// Range is the block range of an eth_getLogs request
+type Range struct {
+	From uint64
+	To   uint64
+}
+
+// Node is an in-memory chain served over HTTP. Blocks are linked by their
+// parent hash; logs are returned by eth_getLogs for the blocks they are in.
+type Node struct {
+	mu       sync.Mutex
+	headers  map[uint64]*types.Header
+	head     uint64
+	lag      uint64
+	logs     []types.Log
+	queries  []Range
+	requests map[string]int
+	down     bool
+	fork     byte
+
+	// LogsError, if set, fails the eth_getLogs requests it returns an error for
+	LogsError func(from, to uint64) error
+	// Call, if set, answers eth_call
+	Call func(to common.Address, data []byte, block uint64) ([]byte, error)
+
+	server *httptest.Server
+}
+
+// New starts a node whose chain holds the genesis block
+func New() *Node {
+	n := &Node{headers: make(map[uint64]*types.Header), requests: make(map[string]int)}
+	n.headers[0] = n.header(0, common.Hash{})
+
+	server := gethrpc.NewServer()
+	if err := server.RegisterName("eth", &service{node: n}); err != nil {
+		panic(err)
+	}
+
+	n.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.mu.Lock()
+		down := n.down
+		n.mu.Unlock()
+		if down {
+			http.Error(w, "node is down", http.StatusServiceUnavailable)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	return n
+}
+
+// URL returns the HTTP endpoint of the node
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// Close stops serving the node
+func (n *Node) Close() {
+	n.server.Close()
+}
+
+// Mine appends count blocks to the chain, twelve seconds apart
+func (n *Node) Mine(count uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for i := uint64(0); i < count; i++ {
+		parent := n.headers[n.head]
+		n.head++
+		n.headers[n.head] = n.header(n.head, parent.Hash())
+	}
+}
+
+// Reorg replaces the blocks from number on with a fork of the same length.
+// Logs in the replaced blocks are dropped.
+func (n *Node) Reorg(number uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.fork++
+	for i := number; i <= n.head; i++ {
+		n.headers[i] = n.header(i, n.headers[i-1].Hash())
+	}
+
+	logs := n.logs[:0]
+	for _, l := range n.logs {
+		if l.BlockNumber < number {
+			logs = append(logs, l)
+		}
+	}
+	n.logs = logs
+}
+
+// header builds a block of the current fork
+func (n *Node) header(number uint64, parent common.Hash) *types.Header {
+	return &types.Header{
+		ParentHash: parent,
+		Number:     new(big.Int).SetUint64(number),
+		Time:       1700000000 + number*12,
+		Difficulty: new(big.Int),
+		Extra:      []byte{n.fork},
+	}
+}
+
+// Header returns the canonical block at number
+func (n *Node) Header(number uint64) *types.Header {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return types.CopyHeader(n.headers[number])
+}
+
+// Head returns the number of the last block
+func (n *Node) Head() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.head
+}
+
+// AddLog adds a log to its block, filling in the block hash
+func (n *Node) AddLog(l types.Log) types.Log {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	l.BlockHash = n.headers[l.BlockNumber].Hash()
+	n.logs = append(n.logs, l)
+	return l
+}
+
+// SetDown makes every request fail at the transport level
+func (n *Node) SetDown(down bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.down = down
+}
+
+// SetLag hides the last lag blocks, like an endpoint that is behind
+func (n *Node) SetLag(lag uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.lag = lag
+}
+
+// Queries returns the ranges requested through eth_getLogs, in order
+func (n *Node) Queries() []Range {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Range(nil), n.queries...)
+}
+
+// Requests returns how many times a JSON-RPC method was served
+func (n *Node) Requests(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.requests[method]
+}
+
+// visibleHead is the head served to clients, the caller must hold the lock
+func (n *Node) visibleHead() uint64 {
+	if n.lag > n.head {
+		return 0
+	}
+	return n.head - n.lag
+}
+
+// Error is a JSON-RPC error with the code a real node would answer with
+type Error struct {
+	Code    int
+	Message string
+	// Data is the hex encoded revert data, if any
+	Data string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) ErrorCode() int {
+	return e.Code
+}
+
+func (e *Error) ErrorData() interface{} {
+	if e.Data == "" {
+		return nil
+	}
+	return e.Data
+}
+
+// Revert returns the error of a call that reverted, with an optional reason
+// encoded as Error(string) the way geth returns it
+func Revert(reason string) error {
+	if reason == "" {
+		return &Error{Code: 3, Message: "execution reverted"}
+	}
+
+	stringType, _ := abi.NewType("string", "", nil)
+	data, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	if err != nil {
+		panic(err)
+	}
+	selector := crypto.Keccak256([]byte("Error(string)"))[:4]
+	return &Error{
+		Code:    3,
+		Message: "execution reverted: " + reason,
+		Data:    hexutil.Encode(append(selector, data...)),
+	}
+}
+
+// service implements the eth namespace of the node
+type service struct {
+	node *Node
+}
+
+func (s *service) BlockNumber() hexutil.Uint64 {
+	n := s.node
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.requests["eth_blockNumber"]++
+	return hexutil.Uint64(n.visibleHead())
+}
+
+// GetBlockByNumber returns the header of a block, or null if the node does
+// not have it yet
+func (s *service) GetBlockByNumber(number gethrpc.BlockNumber, _ bool) (*types.Header, error) {
+	n := s.node
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.requests["eth_getBlockByNumber"]++
+	head := n.visibleHead()
+	block := head
+	if number >= 0 {
+		block = uint64(number)
+	}
+	if block > head {
+		return nil, nil
+	}
+	return n.headers[block], nil
+}
+
+type filterArgs struct {
+	FromBlock *gethrpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *gethrpc.BlockNumber `json:"toBlock"`
+	Addresses []common.Address     `json:"address"`
+	Topics    [][]common.Hash      `json:"topics"`
+}
+
+func (s *service) GetLogs(args filterArgs) ([]types.Log, error) {
+	n := s.node
+	n.mu.Lock()
+	n.requests["eth_getLogs"]++
+
+	from, to := uint64(0), n.visibleHead()
+	if args.FromBlock != nil && *args.FromBlock >= 0 {
+		from = uint64(*args.FromBlock)
+	}
+	if args.ToBlock != nil && *args.ToBlock >= 0 {
+		to = uint64(*args.ToBlock)
+	}
+	n.queries = append(n.queries, Range{From: from, To: to})
+	logsError := n.LogsError
+	n.mu.Unlock()
+
+	if logsError != nil {
+		if err := logsError(from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	logs := []types.Log{}
+	for _, l := range n.logs {
+		if l.BlockNumber < from || l.BlockNumber > to || !matches(l, args) {
+			continue
+		}
+		logs = append(logs, l)
+	}
+	return logs, nil
+}
+
+// matches applies the address and topic filters of a query to a log
+func matches(l types.Log, args filterArgs) bool {
+	if len(args.Addresses) > 0 {
+		found := false
+		for _, address := range args.Addresses {
+			found = found || address == l.Address
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for i, topics := range args.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		if i >= len(l.Topics) {
+			return false
+		}
+		found := false
+		for _, topic := range topics {
+			found = found || topic == l.Topics[i]
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type callArgs struct {
+	To   *common.Address `json:"to"`
+	Data hexutil.Bytes   `json:"data"`
+}
+
+func (s *service) Call(ctx context.Context, args callArgs, number gethrpc.BlockNumber) (hexutil.Bytes, error) {
+	n := s.node
+	n.mu.Lock()
+	n.requests["eth_call"]++
+	call := n.Call
+	block := n.visibleHead()
+	if number >= 0 {
+		block = uint64(number)
+	}
+	n.mu.Unlock()
+
+	if call == nil || args.To == nil {
+		return nil, Revert("")
+	}
+	return call(*args.To, args.Data, block)
+}
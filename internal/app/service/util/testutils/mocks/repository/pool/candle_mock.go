@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: ICandleRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICandleRepository is a mock of ICandleRepository interface.
+type MockICandleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICandleRepositoryMockRecorder
+}
+
+// MockICandleRepositoryMockRecorder is the mock recorder for MockICandleRepository.
+type MockICandleRepositoryMockRecorder struct {
+	mock *MockICandleRepository
+}
+
+// NewMockICandleRepository creates a new mock instance.
+func NewMockICandleRepository(ctrl *gomock.Controller) *MockICandleRepository {
+	mock := &MockICandleRepository{ctrl: ctrl}
+	mock.recorder = &MockICandleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICandleRepository) EXPECT() *MockICandleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplySwap mocks base method.
+func (m *MockICandleRepository) ApplySwap(arg0 context.Context, arg1 posts.Swap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySwap", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySwap indicates an expected call of ApplySwap.
+func (mr *MockICandleRepositoryMockRecorder) ApplySwap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySwap", reflect.TypeOf((*MockICandleRepository)(nil).ApplySwap), arg0, arg1)
+}
+
+// GetCandles mocks base method.
+func (m *MockICandleRepository) GetCandles(arg0 context.Context, arg1, arg2 string, arg3, arg4 time.Time, arg5 int) ([]posts.Candle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandles", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]posts.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandles indicates an expected call of GetCandles.
+func (mr *MockICandleRepositoryMockRecorder) GetCandles(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandles", reflect.TypeOf((*MockICandleRepository)(nil).GetCandles), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// RebuildCandlesAfterBlock mocks base method.
+func (m *MockICandleRepository) RebuildCandlesAfterBlock(arg0 context.Context, arg1 string, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildCandlesAfterBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildCandlesAfterBlock indicates an expected call of RebuildCandlesAfterBlock.
+func (mr *MockICandleRepositoryMockRecorder) RebuildCandlesAfterBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildCandlesAfterBlock", reflect.TypeOf((*MockICandleRepository)(nil).RebuildCandlesAfterBlock), arg0, arg1, arg2)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: ICheckpointRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICheckpointRepository is a mock of ICheckpointRepository interface.
+type MockICheckpointRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICheckpointRepositoryMockRecorder
+}
+
+// MockICheckpointRepositoryMockRecorder is the mock recorder for MockICheckpointRepository.
+type MockICheckpointRepositoryMockRecorder struct {
+	mock *MockICheckpointRepository
+}
+
+// NewMockICheckpointRepository creates a new mock instance.
+func NewMockICheckpointRepository(ctrl *gomock.Controller) *MockICheckpointRepository {
+	mock := &MockICheckpointRepository{ctrl: ctrl}
+	mock.recorder = &MockICheckpointRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICheckpointRepository) EXPECT() *MockICheckpointRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCheckpoint mocks base method.
+func (m *MockICheckpointRepository) GetCheckpoint(arg0 context.Context, arg1 string) (*posts.Checkpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(*posts.Checkpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpoint indicates an expected call of GetCheckpoint.
+func (mr *MockICheckpointRepositoryMockRecorder) GetCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpoint", reflect.TypeOf((*MockICheckpointRepository)(nil).GetCheckpoint), arg0, arg1)
+}
+
+// StoreCheckpoint mocks base method.
+func (m *MockICheckpointRepository) StoreCheckpoint(arg0 context.Context, arg1 string, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreCheckpoint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreCheckpoint indicates an expected call of StoreCheckpoint.
+func (mr *MockICheckpointRepositoryMockRecorder) StoreCheckpoint(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreCheckpoint", reflect.TypeOf((*MockICheckpointRepository)(nil).StoreCheckpoint), arg0, arg1, arg2)
+}
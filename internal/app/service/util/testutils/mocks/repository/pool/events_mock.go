@@ -0,0 +1,225 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: IPoolEventsRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPoolEventsRepository is a mock of IPoolEventsRepository interface.
+type MockIPoolEventsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPoolEventsRepositoryMockRecorder
+}
+
+// MockIPoolEventsRepositoryMockRecorder is the mock recorder for MockIPoolEventsRepository.
+type MockIPoolEventsRepositoryMockRecorder struct {
+	mock *MockIPoolEventsRepository
+}
+
+// NewMockIPoolEventsRepository creates a new mock instance.
+func NewMockIPoolEventsRepository(ctrl *gomock.Controller) *MockIPoolEventsRepository {
+	mock := &MockIPoolEventsRepository{ctrl: ctrl}
+	mock.recorder = &MockIPoolEventsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPoolEventsRepository) EXPECT() *MockIPoolEventsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteEvent mocks base method.
+func (m *MockIPoolEventsRepository) DeleteEvent(arg0 context.Context, arg1, arg2 string, arg3 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent.
+func (mr *MockIPoolEventsRepositoryMockRecorder) DeleteEvent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockIPoolEventsRepository)(nil).DeleteEvent), arg0, arg1, arg2, arg3)
+}
+
+// DeleteEventsAfterBlock mocks base method.
+func (m *MockIPoolEventsRepository) DeleteEventsAfterBlock(arg0 context.Context, arg1 string, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventsAfterBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEventsAfterBlock indicates an expected call of DeleteEventsAfterBlock.
+func (mr *MockIPoolEventsRepositoryMockRecorder) DeleteEventsAfterBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventsAfterBlock", reflect.TypeOf((*MockIPoolEventsRepository)(nil).DeleteEventsAfterBlock), arg0, arg1, arg2)
+}
+
+// GetBurns mocks base method.
+func (m *MockIPoolEventsRepository) GetBurns(arg0 context.Context, arg1 string, arg2, arg3 uint64) ([]posts.Burn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBurns", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]posts.Burn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBurns indicates an expected call of GetBurns.
+func (mr *MockIPoolEventsRepositoryMockRecorder) GetBurns(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBurns", reflect.TypeOf((*MockIPoolEventsRepository)(nil).GetBurns), arg0, arg1, arg2, arg3)
+}
+
+// GetCollects mocks base method.
+func (m *MockIPoolEventsRepository) GetCollects(arg0 context.Context, arg1 string, arg2, arg3 uint64) ([]posts.Collect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollects", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]posts.Collect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollects indicates an expected call of GetCollects.
+func (mr *MockIPoolEventsRepositoryMockRecorder) GetCollects(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollects", reflect.TypeOf((*MockIPoolEventsRepository)(nil).GetCollects), arg0, arg1, arg2, arg3)
+}
+
+// GetFlashes mocks base method.
+func (m *MockIPoolEventsRepository) GetFlashes(arg0 context.Context, arg1 string, arg2, arg3 uint64) ([]posts.Flash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlashes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]posts.Flash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlashes indicates an expected call of GetFlashes.
+func (mr *MockIPoolEventsRepositoryMockRecorder) GetFlashes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlashes", reflect.TypeOf((*MockIPoolEventsRepository)(nil).GetFlashes), arg0, arg1, arg2, arg3)
+}
+
+// GetMints mocks base method.
+func (m *MockIPoolEventsRepository) GetMints(arg0 context.Context, arg1 string, arg2, arg3 uint64) ([]posts.Mint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMints", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]posts.Mint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMints indicates an expected call of GetMints.
+func (mr *MockIPoolEventsRepositoryMockRecorder) GetMints(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMints", reflect.TypeOf((*MockIPoolEventsRepository)(nil).GetMints), arg0, arg1, arg2, arg3)
+}
+
+// GetSwaps mocks base method.
+func (m *MockIPoolEventsRepository) GetSwaps(arg0 context.Context, arg1 string, arg2, arg3 uint64) ([]posts.Swap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwaps", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]posts.Swap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwaps indicates an expected call of GetSwaps.
+func (mr *MockIPoolEventsRepositoryMockRecorder) GetSwaps(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwaps", reflect.TypeOf((*MockIPoolEventsRepository)(nil).GetSwaps), arg0, arg1, arg2, arg3)
+}
+
+// GetSwapsSince mocks base method.
+func (m *MockIPoolEventsRepository) GetSwapsSince(arg0 context.Context, arg1 string, arg2, arg3 time.Time) ([]posts.Swap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwapsSince", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]posts.Swap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwapsSince indicates an expected call of GetSwapsSince.
+func (mr *MockIPoolEventsRepositoryMockRecorder) GetSwapsSince(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapsSince", reflect.TypeOf((*MockIPoolEventsRepository)(nil).GetSwapsSince), arg0, arg1, arg2, arg3)
+}
+
+// StoreBurn mocks base method.
+func (m *MockIPoolEventsRepository) StoreBurn(arg0 context.Context, arg1 posts.Burn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBurn", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreBurn indicates an expected call of StoreBurn.
+func (mr *MockIPoolEventsRepositoryMockRecorder) StoreBurn(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBurn", reflect.TypeOf((*MockIPoolEventsRepository)(nil).StoreBurn), arg0, arg1)
+}
+
+// StoreCollect mocks base method.
+func (m *MockIPoolEventsRepository) StoreCollect(arg0 context.Context, arg1 posts.Collect) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreCollect", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreCollect indicates an expected call of StoreCollect.
+func (mr *MockIPoolEventsRepositoryMockRecorder) StoreCollect(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreCollect", reflect.TypeOf((*MockIPoolEventsRepository)(nil).StoreCollect), arg0, arg1)
+}
+
+// StoreFlash mocks base method.
+func (m *MockIPoolEventsRepository) StoreFlash(arg0 context.Context, arg1 posts.Flash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreFlash", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreFlash indicates an expected call of StoreFlash.
+func (mr *MockIPoolEventsRepositoryMockRecorder) StoreFlash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreFlash", reflect.TypeOf((*MockIPoolEventsRepository)(nil).StoreFlash), arg0, arg1)
+}
+
+// StoreMint mocks base method.
+func (m *MockIPoolEventsRepository) StoreMint(arg0 context.Context, arg1 posts.Mint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreMint", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMint indicates an expected call of StoreMint.
+func (mr *MockIPoolEventsRepositoryMockRecorder) StoreMint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMint", reflect.TypeOf((*MockIPoolEventsRepository)(nil).StoreMint), arg0, arg1)
+}
+
+// StoreSwap mocks base method.
+func (m *MockIPoolEventsRepository) StoreSwap(arg0 context.Context, arg1 posts.Swap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSwap", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSwap indicates an expected call of StoreSwap.
+func (mr *MockIPoolEventsRepositoryMockRecorder) StoreSwap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSwap", reflect.TypeOf((*MockIPoolEventsRepository)(nil).StoreSwap), arg0, arg1)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: ILiquidityRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILiquidityRepository is a mock of ILiquidityRepository interface.
+type MockILiquidityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILiquidityRepositoryMockRecorder
+}
+
+// MockILiquidityRepositoryMockRecorder is the mock recorder for MockILiquidityRepository.
+type MockILiquidityRepositoryMockRecorder struct {
+	mock *MockILiquidityRepository
+}
+
+// NewMockILiquidityRepository creates a new mock instance.
+func NewMockILiquidityRepository(ctrl *gomock.Controller) *MockILiquidityRepository {
+	mock := &MockILiquidityRepository{ctrl: ctrl}
+	mock.recorder = &MockILiquidityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILiquidityRepository) EXPECT() *MockILiquidityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteSeedAfterBlock mocks base method.
+func (m *MockILiquidityRepository) DeleteSeedAfterBlock(arg0 context.Context, arg1 string, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeedAfterBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeedAfterBlock indicates an expected call of DeleteSeedAfterBlock.
+func (mr *MockILiquidityRepositoryMockRecorder) DeleteSeedAfterBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeedAfterBlock", reflect.TypeOf((*MockILiquidityRepository)(nil).DeleteSeedAfterBlock), arg0, arg1, arg2)
+}
+
+// GetSeed mocks base method.
+func (m *MockILiquidityRepository) GetSeed(arg0 context.Context, arg1 string) (*posts.LiquiditySeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeed", arg0, arg1)
+	ret0, _ := ret[0].(*posts.LiquiditySeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeed indicates an expected call of GetSeed.
+func (mr *MockILiquidityRepositoryMockRecorder) GetSeed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeed", reflect.TypeOf((*MockILiquidityRepository)(nil).GetSeed), arg0, arg1)
+}
+
+// GetSeedTicks mocks base method.
+func (m *MockILiquidityRepository) GetSeedTicks(arg0 context.Context, arg1 string) ([]posts.Tick, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeedTicks", arg0, arg1)
+	ret0, _ := ret[0].([]posts.Tick)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeedTicks indicates an expected call of GetSeedTicks.
+func (mr *MockILiquidityRepositoryMockRecorder) GetSeedTicks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeedTicks", reflect.TypeOf((*MockILiquidityRepository)(nil).GetSeedTicks), arg0, arg1)
+}
+
+// GetTickDeltas mocks base method.
+func (m *MockILiquidityRepository) GetTickDeltas(arg0 context.Context, arg1 string, arg2, arg3 uint64) ([]posts.Tick, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTickDeltas", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]posts.Tick)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTickDeltas indicates an expected call of GetTickDeltas.
+func (mr *MockILiquidityRepositoryMockRecorder) GetTickDeltas(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickDeltas", reflect.TypeOf((*MockILiquidityRepository)(nil).GetTickDeltas), arg0, arg1, arg2, arg3)
+}
+
+// StoreSeed mocks base method.
+func (m *MockILiquidityRepository) StoreSeed(arg0 context.Context, arg1 posts.LiquiditySeed, arg2 []posts.Tick) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSeed", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSeed indicates an expected call of StoreSeed.
+func (mr *MockILiquidityRepositoryMockRecorder) StoreSeed(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSeed", reflect.TypeOf((*MockILiquidityRepository)(nil).StoreSeed), arg0, arg1, arg2)
+}
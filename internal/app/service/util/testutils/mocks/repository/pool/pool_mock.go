@@ -35,6 +35,34 @@ func (m *MockIPoolLogsRepository) EXPECT() *MockIPoolLogsRepositoryMockRecorder
 	return m.recorder
 }
 
+// DeletePoolLog mocks base method.
+func (m *MockIPoolLogsRepository) DeletePoolLog(arg0 context.Context, arg1, arg2 string, arg3 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePoolLog", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePoolLog indicates an expected call of DeletePoolLog.
+func (mr *MockIPoolLogsRepositoryMockRecorder) DeletePoolLog(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoolLog", reflect.TypeOf((*MockIPoolLogsRepository)(nil).DeletePoolLog), arg0, arg1, arg2, arg3)
+}
+
+// DeletePoolLogsAfterBlock mocks base method.
+func (m *MockIPoolLogsRepository) DeletePoolLogsAfterBlock(arg0 context.Context, arg1 string, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePoolLogsAfterBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePoolLogsAfterBlock indicates an expected call of DeletePoolLogsAfterBlock.
+func (mr *MockIPoolLogsRepositoryMockRecorder) DeletePoolLogsAfterBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoolLogsAfterBlock", reflect.TypeOf((*MockIPoolLogsRepository)(nil).DeletePoolLogsAfterBlock), arg0, arg1, arg2)
+}
+
 // GetPoolLogs mocks base method.
 func (m *MockIPoolLogsRepository) GetPoolLogs(arg0 context.Context, arg1, arg2 string) (posts.Logs, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: IPositionRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPositionRepository is a mock of IPositionRepository interface.
+type MockIPositionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPositionRepositoryMockRecorder
+}
+
+// MockIPositionRepositoryMockRecorder is the mock recorder for MockIPositionRepository.
+type MockIPositionRepositoryMockRecorder struct {
+	mock *MockIPositionRepository
+}
+
+// NewMockIPositionRepository creates a new mock instance.
+func NewMockIPositionRepository(ctrl *gomock.Controller) *MockIPositionRepository {
+	mock := &MockIPositionRepository{ctrl: ctrl}
+	mock.recorder = &MockIPositionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPositionRepository) EXPECT() *MockIPositionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLiquidityEvents mocks base method.
+func (m *MockIPositionRepository) GetLiquidityEvents(arg0 context.Context, arg1 string, arg2 time.Time) ([]posts.PositionEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiquidityEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]posts.PositionEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiquidityEvents indicates an expected call of GetLiquidityEvents.
+func (mr *MockIPositionRepositoryMockRecorder) GetLiquidityEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiquidityEvents", reflect.TypeOf((*MockIPositionRepository)(nil).GetLiquidityEvents), arg0, arg1, arg2)
+}
+
+// GetPosition mocks base method.
+func (m *MockIPositionRepository) GetPosition(arg0 context.Context, arg1 string) (*posts.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosition", arg0, arg1)
+	ret0, _ := ret[0].(*posts.Position)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPosition indicates an expected call of GetPosition.
+func (mr *MockIPositionRepositoryMockRecorder) GetPosition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosition", reflect.TypeOf((*MockIPositionRepository)(nil).GetPosition), arg0, arg1)
+}
+
+// GetPositions mocks base method.
+func (m *MockIPositionRepository) GetPositions(arg0 context.Context, arg1 string) ([]posts.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPositions", arg0, arg1)
+	ret0, _ := ret[0].([]posts.Position)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPositions indicates an expected call of GetPositions.
+func (mr *MockIPositionRepositoryMockRecorder) GetPositions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPositions", reflect.TypeOf((*MockIPositionRepository)(nil).GetPositions), arg0, arg1)
+}
+
+// StorePosition mocks base method.
+func (m *MockIPositionRepository) StorePosition(arg0 context.Context, arg1 posts.Position) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePosition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePosition indicates an expected call of StorePosition.
+func (mr *MockIPositionRepositoryMockRecorder) StorePosition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePosition", reflect.TypeOf((*MockIPositionRepository)(nil).StorePosition), arg0, arg1)
+}
+
+// StorePositionEvent mocks base method.
+func (m *MockIPositionRepository) StorePositionEvent(arg0 context.Context, arg1 posts.PositionEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePositionEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePositionEvent indicates an expected call of StorePositionEvent.
+func (mr *MockIPositionRepositoryMockRecorder) StorePositionEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePositionEvent", reflect.TypeOf((*MockIPositionRepository)(nil).StorePositionEvent), arg0, arg1)
+}
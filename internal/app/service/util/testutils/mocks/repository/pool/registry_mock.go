@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: IPoolRegistryRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPoolRegistryRepository is a mock of IPoolRegistryRepository interface.
+type MockIPoolRegistryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPoolRegistryRepositoryMockRecorder
+}
+
+// MockIPoolRegistryRepositoryMockRecorder is the mock recorder for MockIPoolRegistryRepository.
+type MockIPoolRegistryRepositoryMockRecorder struct {
+	mock *MockIPoolRegistryRepository
+}
+
+// NewMockIPoolRegistryRepository creates a new mock instance.
+func NewMockIPoolRegistryRepository(ctrl *gomock.Controller) *MockIPoolRegistryRepository {
+	mock := &MockIPoolRegistryRepository{ctrl: ctrl}
+	mock.recorder = &MockIPoolRegistryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPoolRegistryRepository) EXPECT() *MockIPoolRegistryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeletePool mocks base method.
+func (m *MockIPoolRegistryRepository) DeletePool(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePool", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePool indicates an expected call of DeletePool.
+func (mr *MockIPoolRegistryRepositoryMockRecorder) DeletePool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePool", reflect.TypeOf((*MockIPoolRegistryRepository)(nil).DeletePool), arg0, arg1)
+}
+
+// GetFactoryPoolsAfterBlock mocks base method.
+func (m *MockIPoolRegistryRepository) GetFactoryPoolsAfterBlock(arg0 context.Context, arg1 uint64) ([]posts.Pool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFactoryPoolsAfterBlock", arg0, arg1)
+	ret0, _ := ret[0].([]posts.Pool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFactoryPoolsAfterBlock indicates an expected call of GetFactoryPoolsAfterBlock.
+func (mr *MockIPoolRegistryRepositoryMockRecorder) GetFactoryPoolsAfterBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFactoryPoolsAfterBlock", reflect.TypeOf((*MockIPoolRegistryRepository)(nil).GetFactoryPoolsAfterBlock), arg0, arg1)
+}
+
+// GetPool mocks base method.
+func (m *MockIPoolRegistryRepository) GetPool(arg0 context.Context, arg1 string) (*posts.Pool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPool", arg0, arg1)
+	ret0, _ := ret[0].(*posts.Pool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPool indicates an expected call of GetPool.
+func (mr *MockIPoolRegistryRepositoryMockRecorder) GetPool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPool", reflect.TypeOf((*MockIPoolRegistryRepository)(nil).GetPool), arg0, arg1)
+}
+
+// GetTrackedPools mocks base method.
+func (m *MockIPoolRegistryRepository) GetTrackedPools(arg0 context.Context) ([]posts.Pool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackedPools", arg0)
+	ret0, _ := ret[0].([]posts.Pool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrackedPools indicates an expected call of GetTrackedPools.
+func (mr *MockIPoolRegistryRepositoryMockRecorder) GetTrackedPools(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackedPools", reflect.TypeOf((*MockIPoolRegistryRepository)(nil).GetTrackedPools), arg0)
+}
+
+// SetPaused mocks base method.
+func (m *MockIPoolRegistryRepository) SetPaused(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaused", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaused indicates an expected call of SetPaused.
+func (mr *MockIPoolRegistryRepositoryMockRecorder) SetPaused(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaused", reflect.TypeOf((*MockIPoolRegistryRepository)(nil).SetPaused), arg0, arg1, arg2)
+}
+
+// SetTracked mocks base method.
+func (m *MockIPoolRegistryRepository) SetTracked(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTracked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTracked indicates an expected call of SetTracked.
+func (mr *MockIPoolRegistryRepositoryMockRecorder) SetTracked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTracked", reflect.TypeOf((*MockIPoolRegistryRepository)(nil).SetTracked), arg0, arg1, arg2)
+}
+
+// StoreFactoryPool mocks base method.
+func (m *MockIPoolRegistryRepository) StoreFactoryPool(arg0 context.Context, arg1 posts.Pool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreFactoryPool", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreFactoryPool indicates an expected call of StoreFactoryPool.
+func (mr *MockIPoolRegistryRepositoryMockRecorder) StoreFactoryPool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreFactoryPool", reflect.TypeOf((*MockIPoolRegistryRepository)(nil).StoreFactoryPool), arg0, arg1)
+}
+
+// StorePool mocks base method.
+func (m *MockIPoolRegistryRepository) StorePool(arg0 context.Context, arg1 posts.Pool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePool", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorePool indicates an expected call of StorePool.
+func (mr *MockIPoolRegistryRepositoryMockRecorder) StorePool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePool", reflect.TypeOf((*MockIPoolRegistryRepository)(nil).StorePool), arg0, arg1)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: IRollupRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRollupRepository is a mock of IRollupRepository interface.
+type MockIRollupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRollupRepositoryMockRecorder
+}
+
+// MockIRollupRepositoryMockRecorder is the mock recorder for MockIRollupRepository.
+type MockIRollupRepositoryMockRecorder struct {
+	mock *MockIRollupRepository
+}
+
+// NewMockIRollupRepository creates a new mock instance.
+func NewMockIRollupRepository(ctrl *gomock.Controller) *MockIRollupRepository {
+	mock := &MockIRollupRepository{ctrl: ctrl}
+	mock.recorder = &MockIRollupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRollupRepository) EXPECT() *MockIRollupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplySwap mocks base method.
+func (m *MockIRollupRepository) ApplySwap(arg0 context.Context, arg1 posts.Swap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySwap", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySwap indicates an expected call of ApplySwap.
+func (mr *MockIRollupRepositoryMockRecorder) ApplySwap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySwap", reflect.TypeOf((*MockIRollupRepository)(nil).ApplySwap), arg0, arg1)
+}
+
+// GetTotals mocks base method.
+func (m *MockIRollupRepository) GetTotals(arg0 context.Context, arg1 string, arg2, arg3 time.Time) (*posts.Rollup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotals", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*posts.Rollup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotals indicates an expected call of GetTotals.
+func (mr *MockIRollupRepositoryMockRecorder) GetTotals(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotals", reflect.TypeOf((*MockIRollupRepository)(nil).GetTotals), arg0, arg1, arg2, arg3)
+}
+
+// RebuildRollupsAfterBlock mocks base method.
+func (m *MockIRollupRepository) RebuildRollupsAfterBlock(arg0 context.Context, arg1 string, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildRollupsAfterBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildRollupsAfterBlock indicates an expected call of RebuildRollupsAfterBlock.
+func (mr *MockIRollupRepositoryMockRecorder) RebuildRollupsAfterBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildRollupsAfterBlock", reflect.TypeOf((*MockIRollupRepository)(nil).RebuildRollupsAfterBlock), arg0, arg1, arg2)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: ISnapshotRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISnapshotRepository is a mock of ISnapshotRepository interface.
+type MockISnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISnapshotRepositoryMockRecorder
+}
+
+// MockISnapshotRepositoryMockRecorder is the mock recorder for MockISnapshotRepository.
+type MockISnapshotRepositoryMockRecorder struct {
+	mock *MockISnapshotRepository
+}
+
+// NewMockISnapshotRepository creates a new mock instance.
+func NewMockISnapshotRepository(ctrl *gomock.Controller) *MockISnapshotRepository {
+	mock := &MockISnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockISnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISnapshotRepository) EXPECT() *MockISnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteSnapshotsAfterBlock mocks base method.
+func (m *MockISnapshotRepository) DeleteSnapshotsAfterBlock(arg0 context.Context, arg1 string, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSnapshotsAfterBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSnapshotsAfterBlock indicates an expected call of DeleteSnapshotsAfterBlock.
+func (mr *MockISnapshotRepositoryMockRecorder) DeleteSnapshotsAfterBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSnapshotsAfterBlock", reflect.TypeOf((*MockISnapshotRepository)(nil).DeleteSnapshotsAfterBlock), arg0, arg1, arg2)
+}
+
+// GetLatestSnapshot mocks base method.
+func (m *MockISnapshotRepository) GetLatestSnapshot(arg0 context.Context, arg1 string) (*posts.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(*posts.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSnapshot indicates an expected call of GetLatestSnapshot.
+func (mr *MockISnapshotRepositoryMockRecorder) GetLatestSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSnapshot", reflect.TypeOf((*MockISnapshotRepository)(nil).GetLatestSnapshot), arg0, arg1)
+}
+
+// GetSnapshotAt mocks base method.
+func (m *MockISnapshotRepository) GetSnapshotAt(arg0 context.Context, arg1 string, arg2 time.Time) (*posts.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshotAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*posts.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshotAt indicates an expected call of GetSnapshotAt.
+func (mr *MockISnapshotRepositoryMockRecorder) GetSnapshotAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshotAt", reflect.TypeOf((*MockISnapshotRepository)(nil).GetSnapshotAt), arg0, arg1, arg2)
+}
+
+// StoreSnapshot mocks base method.
+func (m *MockISnapshotRepository) StoreSnapshot(arg0 context.Context, arg1 posts.Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSnapshot indicates an expected call of StoreSnapshot.
+func (mr *MockISnapshotRepositoryMockRecorder) StoreSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSnapshot", reflect.TypeOf((*MockISnapshotRepository)(nil).StoreSnapshot), arg0, arg1)
+}
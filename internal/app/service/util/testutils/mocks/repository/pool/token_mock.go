@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/db/repository/pool (interfaces: ITokenRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockITokenRepository is a mock of ITokenRepository interface.
+type MockITokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenRepositoryMockRecorder
+}
+
+// MockITokenRepositoryMockRecorder is the mock recorder for MockITokenRepository.
+type MockITokenRepositoryMockRecorder struct {
+	mock *MockITokenRepository
+}
+
+// NewMockITokenRepository creates a new mock instance.
+func NewMockITokenRepository(ctrl *gomock.Controller) *MockITokenRepository {
+	mock := &MockITokenRepository{ctrl: ctrl}
+	mock.recorder = &MockITokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenRepository) EXPECT() *MockITokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetToken mocks base method.
+func (m *MockITokenRepository) GetToken(arg0 context.Context, arg1 string) (*posts.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToken", arg0, arg1)
+	ret0, _ := ret[0].(*posts.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken indicates an expected call of GetToken.
+func (mr *MockITokenRepositoryMockRecorder) GetToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockITokenRepository)(nil).GetToken), arg0, arg1)
+}
+
+// StoreToken mocks base method.
+func (m *MockITokenRepository) StoreToken(arg0 context.Context, arg1 posts.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreToken indicates an expected call of StoreToken.
+func (mr *MockITokenRepositoryMockRecorder) StoreToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreToken", reflect.TypeOf((*MockITokenRepository)(nil).StoreToken), arg0, arg1)
+}
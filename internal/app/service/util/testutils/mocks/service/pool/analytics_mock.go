@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: IAnalyticsService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAnalyticsService is a mock of IAnalyticsService interface.
+type MockIAnalyticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAnalyticsServiceMockRecorder
+}
+
+// MockIAnalyticsServiceMockRecorder is the mock recorder for MockIAnalyticsService.
+type MockIAnalyticsServiceMockRecorder struct {
+	mock *MockIAnalyticsService
+}
+
+// NewMockIAnalyticsService creates a new mock instance.
+func NewMockIAnalyticsService(ctrl *gomock.Controller) *MockIAnalyticsService {
+	mock := &MockIAnalyticsService{ctrl: ctrl}
+	mock.recorder = &MockIAnalyticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAnalyticsService) EXPECT() *MockIAnalyticsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetFees mocks base method.
+func (m *MockIAnalyticsService) GetFees(arg0 context.Context, arg1 string, arg2, arg3 time.Time) (*pool.Fees, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFees", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*pool.Fees)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFees indicates an expected call of GetFees.
+func (mr *MockIAnalyticsServiceMockRecorder) GetFees(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFees", reflect.TypeOf((*MockIAnalyticsService)(nil).GetFees), arg0, arg1, arg2, arg3)
+}
+
+// GetTVL mocks base method.
+func (m *MockIAnalyticsService) GetTVL(arg0 context.Context, arg1 string, arg2, arg3 time.Time) (*pool.TVL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTVL", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*pool.TVL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTVL indicates an expected call of GetTVL.
+func (mr *MockIAnalyticsServiceMockRecorder) GetTVL(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTVL", reflect.TypeOf((*MockIAnalyticsService)(nil).GetTVL), arg0, arg1, arg2, arg3)
+}
+
+// GetVolume mocks base method.
+func (m *MockIAnalyticsService) GetVolume(arg0 context.Context, arg1 string, arg2, arg3 time.Time) (*pool.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVolume", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*pool.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolume indicates an expected call of GetVolume.
+func (mr *MockIAnalyticsServiceMockRecorder) GetVolume(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolume", reflect.TypeOf((*MockIAnalyticsService)(nil).GetVolume), arg0, arg1, arg2, arg3)
+}
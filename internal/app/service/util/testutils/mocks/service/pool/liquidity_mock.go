@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: ILiquidityService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILiquidityService is a mock of ILiquidityService interface.
+type MockILiquidityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockILiquidityServiceMockRecorder
+}
+
+// MockILiquidityServiceMockRecorder is the mock recorder for MockILiquidityService.
+type MockILiquidityServiceMockRecorder struct {
+	mock *MockILiquidityService
+}
+
+// NewMockILiquidityService creates a new mock instance.
+func NewMockILiquidityService(ctrl *gomock.Controller) *MockILiquidityService {
+	mock := &MockILiquidityService{ctrl: ctrl}
+	mock.recorder = &MockILiquidityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILiquidityService) EXPECT() *MockILiquidityServiceMockRecorder {
+	return m.recorder
+}
+
+// GetLiquidity mocks base method.
+func (m *MockILiquidityService) GetLiquidity(arg0 context.Context, arg1 string, arg2 *uint64) (*pool.LiquidityDistribution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiquidity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pool.LiquidityDistribution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiquidity indicates an expected call of GetLiquidity.
+func (mr *MockILiquidityServiceMockRecorder) GetLiquidity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiquidity", reflect.TypeOf((*MockILiquidityService)(nil).GetLiquidity), arg0, arg1, arg2)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: IPoolMetadataService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	posts "uniswapper/internal/app/db/dto/pool"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPoolMetadataService is a mock of IPoolMetadataService interface.
+type MockIPoolMetadataService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPoolMetadataServiceMockRecorder
+}
+
+// MockIPoolMetadataServiceMockRecorder is the mock recorder for MockIPoolMetadataService.
+type MockIPoolMetadataServiceMockRecorder struct {
+	mock *MockIPoolMetadataService
+}
+
+// NewMockIPoolMetadataService creates a new mock instance.
+func NewMockIPoolMetadataService(ctrl *gomock.Controller) *MockIPoolMetadataService {
+	mock := &MockIPoolMetadataService{ctrl: ctrl}
+	mock.recorder = &MockIPoolMetadataServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPoolMetadataService) EXPECT() *MockIPoolMetadataServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPoolMetadata mocks base method.
+func (m *MockIPoolMetadataService) GetPoolMetadata(arg0 context.Context, arg1 string) (*pool.PoolMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoolMetadata", arg0, arg1)
+	ret0, _ := ret[0].(*pool.PoolMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoolMetadata indicates an expected call of GetPoolMetadata.
+func (mr *MockIPoolMetadataServiceMockRecorder) GetPoolMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoolMetadata", reflect.TypeOf((*MockIPoolMetadataService)(nil).GetPoolMetadata), arg0, arg1)
+}
+
+// GetToken mocks base method.
+func (m *MockIPoolMetadataService) GetToken(arg0 context.Context, arg1 string) (*posts.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToken", arg0, arg1)
+	ret0, _ := ret[0].(*posts.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken indicates an expected call of GetToken.
+func (mr *MockIPoolMetadataServiceMockRecorder) GetToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockIPoolMetadataService)(nil).GetToken), arg0, arg1)
+}
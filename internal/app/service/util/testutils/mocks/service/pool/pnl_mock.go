@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: IPnLService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+	time "time"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPnLService is a mock of IPnLService interface.
+type MockIPnLService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPnLServiceMockRecorder
+}
+
+// MockIPnLServiceMockRecorder is the mock recorder for MockIPnLService.
+type MockIPnLServiceMockRecorder struct {
+	mock *MockIPnLService
+}
+
+// NewMockIPnLService creates a new mock instance.
+func NewMockIPnLService(ctrl *gomock.Controller) *MockIPnLService {
+	mock := &MockIPnLService{ctrl: ctrl}
+	mock.recorder = &MockIPnLServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPnLService) EXPECT() *MockIPnLServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPositionPnL mocks base method.
+func (m *MockIPnLService) GetPositionPnL(arg0 context.Context, arg1 *big.Int, arg2, arg3 time.Time) (*pool.PnL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPositionPnL", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*pool.PnL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPositionPnL indicates an expected call of GetPositionPnL.
+func (mr *MockIPnLServiceMockRecorder) GetPositionPnL(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPositionPnL", reflect.TypeOf((*MockIPnLService)(nil).GetPositionPnL), arg0, arg1, arg2, arg3)
+}
+
+// GetRangePnL mocks base method.
+func (m *MockIPnLService) GetRangePnL(arg0 context.Context, arg1 string, arg2, arg3 int32, arg4, arg5 *big.Int, arg6, arg7 time.Time) (*pool.PnL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRangePnL", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*pool.PnL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRangePnL indicates an expected call of GetRangePnL.
+func (mr *MockIPnLServiceMockRecorder) GetRangePnL(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRangePnL", reflect.TypeOf((*MockIPnLService)(nil).GetRangePnL), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: IPositionService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPositionService is a mock of IPositionService interface.
+type MockIPositionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPositionServiceMockRecorder
+}
+
+// MockIPositionServiceMockRecorder is the mock recorder for MockIPositionService.
+type MockIPositionServiceMockRecorder struct {
+	mock *MockIPositionService
+}
+
+// NewMockIPositionService creates a new mock instance.
+func NewMockIPositionService(ctrl *gomock.Controller) *MockIPositionService {
+	mock := &MockIPositionService{ctrl: ctrl}
+	mock.recorder = &MockIPositionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPositionService) EXPECT() *MockIPositionServiceMockRecorder {
+	return m.recorder
+}
+
+// GetFees mocks base method.
+func (m *MockIPositionService) GetFees(arg0 context.Context, arg1 *big.Int) (*pool.PositionFees, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFees", arg0, arg1)
+	ret0, _ := ret[0].(*pool.PositionFees)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFees indicates an expected call of GetFees.
+func (mr *MockIPositionServiceMockRecorder) GetFees(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFees", reflect.TypeOf((*MockIPositionService)(nil).GetFees), arg0, arg1)
+}
+
+// GetPosition mocks base method.
+func (m *MockIPositionService) GetPosition(arg0 context.Context, arg1 *big.Int) (*pool.PositionDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosition", arg0, arg1)
+	ret0, _ := ret[0].(*pool.PositionDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPosition indicates an expected call of GetPosition.
+func (mr *MockIPositionServiceMockRecorder) GetPosition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosition", reflect.TypeOf((*MockIPositionService)(nil).GetPosition), arg0, arg1)
+}
+
+// GetPositions mocks base method.
+func (m *MockIPositionService) GetPositions(arg0 context.Context, arg1 string) ([]pool.PositionDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPositions", arg0, arg1)
+	ret0, _ := ret[0].([]pool.PositionDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPositions indicates an expected call of GetPositions.
+func (mr *MockIPositionServiceMockRecorder) GetPositions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPositions", reflect.TypeOf((*MockIPositionService)(nil).GetPositions), arg0, arg1)
+}
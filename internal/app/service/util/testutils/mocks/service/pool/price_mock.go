@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: IPriceService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPriceService is a mock of IPriceService interface.
+type MockIPriceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPriceServiceMockRecorder
+}
+
+// MockIPriceServiceMockRecorder is the mock recorder for MockIPriceService.
+type MockIPriceServiceMockRecorder struct {
+	mock *MockIPriceService
+}
+
+// NewMockIPriceService creates a new mock instance.
+func NewMockIPriceService(ctrl *gomock.Controller) *MockIPriceService {
+	mock := &MockIPriceService{ctrl: ctrl}
+	mock.recorder = &MockIPriceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPriceService) EXPECT() *MockIPriceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPrice mocks base method.
+func (m *MockIPriceService) GetPrice(arg0 context.Context, arg1 string, arg2 *big.Int) (*pool.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrice", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pool.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrice indicates an expected call of GetPrice.
+func (mr *MockIPriceServiceMockRecorder) GetPrice(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrice", reflect.TypeOf((*MockIPriceService)(nil).GetPrice), arg0, arg1, arg2)
+}
+
+// GetPriceAtTick mocks base method.
+func (m *MockIPriceService) GetPriceAtTick(arg0 context.Context, arg1 string, arg2 int) (*pool.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceAtTick", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pool.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceAtTick indicates an expected call of GetPriceAtTick.
+func (mr *MockIPriceServiceMockRecorder) GetPriceAtTick(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceAtTick", reflect.TypeOf((*MockIPriceService)(nil).GetPriceAtTick), arg0, arg1, arg2)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: IQuoteService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIQuoteService is a mock of IQuoteService interface.
+type MockIQuoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIQuoteServiceMockRecorder
+}
+
+// MockIQuoteServiceMockRecorder is the mock recorder for MockIQuoteService.
+type MockIQuoteServiceMockRecorder struct {
+	mock *MockIQuoteService
+}
+
+// NewMockIQuoteService creates a new mock instance.
+func NewMockIQuoteService(ctrl *gomock.Controller) *MockIQuoteService {
+	mock := &MockIQuoteService{ctrl: ctrl}
+	mock.recorder = &MockIQuoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIQuoteService) EXPECT() *MockIQuoteServiceMockRecorder {
+	return m.recorder
+}
+
+// GetQuote mocks base method.
+func (m *MockIQuoteService) GetQuote(arg0 context.Context, arg1, arg2 string, arg3 *big.Int, arg4 bool) (*pool.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuote", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*pool.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuote indicates an expected call of GetQuote.
+func (mr *MockIQuoteServiceMockRecorder) GetQuote(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuote", reflect.TypeOf((*MockIQuoteService)(nil).GetQuote), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: IPoolRegistry)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	posts "uniswapper/internal/app/db/dto/pool"
+
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPoolRegistry is a mock of IPoolRegistry interface.
+type MockIPoolRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPoolRegistryMockRecorder
+}
+
+// MockIPoolRegistryMockRecorder is the mock recorder for MockIPoolRegistry.
+type MockIPoolRegistryMockRecorder struct {
+	mock *MockIPoolRegistry
+}
+
+// NewMockIPoolRegistry creates a new mock instance.
+func NewMockIPoolRegistry(ctrl *gomock.Controller) *MockIPoolRegistry {
+	mock := &MockIPoolRegistry{ctrl: ctrl}
+	mock.recorder = &MockIPoolRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPoolRegistry) EXPECT() *MockIPoolRegistryMockRecorder {
+	return m.recorder
+}
+
+// AddPool mocks base method.
+func (m *MockIPoolRegistry) AddPool(arg0 context.Context, arg1 string) (*posts.Pool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPool", arg0, arg1)
+	ret0, _ := ret[0].(*posts.Pool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPool indicates an expected call of AddPool.
+func (mr *MockIPoolRegistryMockRecorder) AddPool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPool", reflect.TypeOf((*MockIPoolRegistry)(nil).AddPool), arg0, arg1)
+}
+
+// Changed mocks base method.
+func (m *MockIPoolRegistry) Changed() <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Changed")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Changed indicates an expected call of Changed.
+func (mr *MockIPoolRegistryMockRecorder) Changed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Changed", reflect.TypeOf((*MockIPoolRegistry)(nil).Changed))
+}
+
+// GetPool mocks base method.
+func (m *MockIPoolRegistry) GetPool(arg0 context.Context, arg1 string) (*posts.Pool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPool", arg0, arg1)
+	ret0, _ := ret[0].(*posts.Pool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPool indicates an expected call of GetPool.
+func (mr *MockIPoolRegistryMockRecorder) GetPool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPool", reflect.TypeOf((*MockIPoolRegistry)(nil).GetPool), arg0, arg1)
+}
+
+// GetPools mocks base method.
+func (m *MockIPoolRegistry) GetPools(arg0 context.Context) ([]posts.Pool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPools", arg0)
+	ret0, _ := ret[0].([]posts.Pool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPools indicates an expected call of GetPools.
+func (mr *MockIPoolRegistryMockRecorder) GetPools(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPools", reflect.TypeOf((*MockIPoolRegistry)(nil).GetPools), arg0)
+}
+
+// RemovePool mocks base method.
+func (m *MockIPoolRegistry) RemovePool(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePool", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePool indicates an expected call of RemovePool.
+func (mr *MockIPoolRegistryMockRecorder) RemovePool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePool", reflect.TypeOf((*MockIPoolRegistry)(nil).RemovePool), arg0, arg1)
+}
+
+// Seed mocks base method.
+func (m *MockIPoolRegistry) Seed(arg0 context.Context, arg1 []common.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockIPoolRegistryMockRecorder) Seed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockIPoolRegistry)(nil).Seed), arg0, arg1)
+}
+
+// SetPaused mocks base method.
+func (m *MockIPoolRegistry) SetPaused(arg0 context.Context, arg1 string, arg2 bool) (*posts.Pool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaused", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*posts.Pool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPaused indicates an expected call of SetPaused.
+func (mr *MockIPoolRegistryMockRecorder) SetPaused(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaused", reflect.TypeOf((*MockIPoolRegistry)(nil).SetPaused), arg0, arg1, arg2)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: IPoolStateService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPoolStateService is a mock of IPoolStateService interface.
+type MockIPoolStateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPoolStateServiceMockRecorder
+}
+
+// MockIPoolStateServiceMockRecorder is the mock recorder for MockIPoolStateService.
+type MockIPoolStateServiceMockRecorder struct {
+	mock *MockIPoolStateService
+}
+
+// NewMockIPoolStateService creates a new mock instance.
+func NewMockIPoolStateService(ctrl *gomock.Controller) *MockIPoolStateService {
+	mock := &MockIPoolStateService{ctrl: ctrl}
+	mock.recorder = &MockIPoolStateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPoolStateService) EXPECT() *MockIPoolStateServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPoolState mocks base method.
+func (m *MockIPoolStateService) GetPoolState(arg0 context.Context, arg1 string, arg2 *uint64) (*pool.PoolState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoolState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pool.PoolState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoolState indicates an expected call of GetPoolState.
+func (mr *MockIPoolStateServiceMockRecorder) GetPoolState(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoolState", reflect.TypeOf((*MockIPoolStateService)(nil).GetPoolState), arg0, arg1, arg2)
+}
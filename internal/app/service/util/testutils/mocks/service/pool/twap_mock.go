@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uniswapper/internal/app/service/pool (interfaces: ITWAPService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	pool "uniswapper/internal/app/service/pool"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockITWAPService is a mock of ITWAPService interface.
+type MockITWAPService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITWAPServiceMockRecorder
+}
+
+// MockITWAPServiceMockRecorder is the mock recorder for MockITWAPService.
+type MockITWAPServiceMockRecorder struct {
+	mock *MockITWAPService
+}
+
+// NewMockITWAPService creates a new mock instance.
+func NewMockITWAPService(ctrl *gomock.Controller) *MockITWAPService {
+	mock := &MockITWAPService{ctrl: ctrl}
+	mock.recorder = &MockITWAPServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITWAPService) EXPECT() *MockITWAPServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTWAP mocks base method.
+func (m *MockITWAPService) GetTWAP(arg0 context.Context, arg1 string, arg2 uint32) (*pool.TWAP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTWAP", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pool.TWAP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTWAP indicates an expected call of GetTWAP.
+func (mr *MockITWAPServiceMockRecorder) GetTWAP(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTWAP", reflect.TypeOf((*MockITWAPService)(nil).GetTWAP), arg0, arg1, arg2)
+}
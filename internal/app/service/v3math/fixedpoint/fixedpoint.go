@@ -0,0 +1,22 @@
+// Package fixedpoint holds the binary fixed point scales of Uniswap V3, as in
+// the FixedPoint96 and FixedPoint128 libraries
+// (https://github.com/Uniswap/v3-core/tree/main/contracts/libraries).
+package fixedpoint
+
+import (
+	"math/big"
+)
+
+const (
+	// RESOLUTION_96 is the number of fractional bits of a Q64.96 number, e.g. sqrtPriceX96
+	RESOLUTION_96 = 96
+	// RESOLUTION_128 is the number of fractional bits of a Q128.128 number, e.g. fee growth
+	RESOLUTION_128 = 128
+)
+
+var (
+	// Q96 is 1 as a Q64.96 number
+	Q96 = new(big.Int).Lsh(big.NewInt(1), RESOLUTION_96)
+	// Q128 is 1 as a Q128.128 number
+	Q128 = new(big.Int).Lsh(big.NewInt(1), RESOLUTION_128)
+)
@@ -0,0 +1,64 @@
+// Package fullmath multiplies and divides 256-bit integers without losing
+// precision on the intermediate product, as a port of the Uniswap V3 FullMath
+// and UnsafeMath libraries
+// (https://github.com/Uniswap/v3-core/tree/main/contracts/libraries).
+// Inputs are treated as uint256 and never modified; where the contract
+// reverts an error is returned instead.
+package fullmath
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrDivisionByZero = errors.New("division by zero")
+	ErrOverflow       = errors.New("result overflows uint256")
+)
+
+// MaxUint256 is the largest value of a uint256
+var MaxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// MulDiv calculates floor(a×b÷denominator) with full precision
+func MulDiv(a, b, denominator *big.Int) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	result := new(big.Int).Mul(a, b)
+	result.Quo(result, denominator)
+	if result.Cmp(MaxUint256) > 0 {
+		return nil, ErrOverflow
+	}
+	return result, nil
+}
+
+// MulDivRoundingUp calculates ceil(a×b÷denominator) with full precision
+func MulDivRoundingUp(a, b, denominator *big.Int) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	result, rem := new(big.Int).QuoRem(new(big.Int).Mul(a, b), denominator, new(big.Int))
+	if rem.Sign() != 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	if result.Cmp(MaxUint256) > 0 {
+		return nil, ErrOverflow
+	}
+	return result, nil
+}
+
+// DivRoundingUp calculates ceil(x÷y). Like UnsafeMath.divRoundingUp it
+// returns 0 when y is 0.
+func DivRoundingUp(x, y *big.Int) *big.Int {
+	if y.Sign() == 0 {
+		return new(big.Int)
+	}
+
+	result, rem := new(big.Int).QuoRem(x, y, new(big.Int))
+	if rem.Sign() != 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	return result
+}
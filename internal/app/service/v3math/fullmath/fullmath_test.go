@@ -0,0 +1,85 @@
+package fullmath
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var q128 = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// fraction returns q128 * num / den
+func fraction(num, den int64) *big.Int {
+	x := new(big.Int).Mul(q128, big.NewInt(num))
+	return x.Quo(x, big.NewInt(den))
+}
+
+func TestMulDiv(t *testing.T) {
+	testCases := []struct {
+		name        string
+		a           *big.Int
+		b           *big.Int
+		denominator *big.Int
+		expected    *big.Int
+		err         error
+	}{
+		{name: "all max inputs", a: MaxUint256, b: MaxUint256, denominator: MaxUint256, expected: MaxUint256},
+		{name: "without phantom overflow", a: q128, b: fraction(35, 100), denominator: fraction(8, 1), expected: fraction(4375, 100000)},
+		{name: "with phantom overflow", a: q128, b: fraction(1000, 1), denominator: fraction(3000, 1), expected: fraction(1, 3)},
+		{name: "phantom overflow repeating decimal", a: q128, b: fraction(50, 100), denominator: fraction(150, 100), expected: fraction(1, 3)},
+		{name: "denominator is 0", a: q128, b: big.NewInt(5), denominator: big.NewInt(0), err: ErrDivisionByZero},
+		{name: "output overflows", a: q128, b: q128, denominator: big.NewInt(1), err: ErrOverflow},
+		{name: "overflow with all max inputs", a: MaxUint256, b: MaxUint256, denominator: new(big.Int).Sub(MaxUint256, big.NewInt(1)), err: ErrOverflow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MulDiv(tc.a, tc.b, tc.denominator)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestMulDivRoundingUp(t *testing.T) {
+	testCases := []struct {
+		name        string
+		a           *big.Int
+		b           *big.Int
+		denominator *big.Int
+		expected    *big.Int
+		err         error
+	}{
+		{name: "all max inputs", a: MaxUint256, b: MaxUint256, denominator: MaxUint256, expected: MaxUint256},
+		{name: "without phantom overflow", a: q128, b: fraction(35, 100), denominator: fraction(8, 1), expected: new(big.Int).Add(fraction(4375, 100000), big.NewInt(1))},
+		{name: "with phantom overflow", a: q128, b: fraction(1000, 1), denominator: fraction(3000, 1), expected: new(big.Int).Add(fraction(1, 3), big.NewInt(1))},
+		{name: "denominator is 0", a: q128, b: big.NewInt(5), denominator: big.NewInt(0), err: ErrDivisionByZero},
+		{
+			name:        "overflows after rounding up",
+			a:           big.NewInt(535006138814359),
+			b:           mustParse("432862656469423142931042426214547535783388063929571229938474969"),
+			denominator: big.NewInt(2),
+			err:         ErrOverflow,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MulDivRoundingUp(tc.a, tc.b, tc.denominator)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestDivRoundingUp(t *testing.T) {
+	assert.Equal(t, big.NewInt(4), DivRoundingUp(big.NewInt(10), big.NewInt(3)))
+	assert.Equal(t, big.NewInt(3), DivRoundingUp(big.NewInt(9), big.NewInt(3)))
+	assert.Equal(t, big.NewInt(0), DivRoundingUp(big.NewInt(9), big.NewInt(0)))
+}
+
+func mustParse(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
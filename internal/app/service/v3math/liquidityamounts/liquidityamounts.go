@@ -0,0 +1,152 @@
+// Package liquidityamounts converts between token amounts and liquidity for
+// a price range, as a port of the Uniswap V3 periphery LiquidityAmounts library
+// (https://github.com/Uniswap/v3-periphery/blob/main/contracts/libraries/LiquidityAmounts.sol).
+// Amounts are always rounded down.
+package liquidityamounts
+
+import (
+	"errors"
+	"math/big"
+
+	"uniswapper/internal/app/service/v3math/fixedpoint"
+	"uniswapper/internal/app/service/v3math/fullmath"
+)
+
+var (
+	ErrInvalidRange      = errors.New("price range is empty")
+	ErrLiquidityOverflow = errors.New("liquidity overflows uint128")
+)
+
+var maxUint128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+// GetLiquidityForAmount0 computes the liquidity received for an amount of
+// token0 over a price range:
+// amount0 * (sqrt(upper) * sqrt(lower)) / (sqrt(upper) - sqrt(lower))
+func GetLiquidityForAmount0(sqrtRatioAX96, sqrtRatioBX96, amount0 *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sorted(sqrtRatioAX96, sqrtRatioBX96)
+
+	intermediate, err := fullmath.MulDiv(sqrtRatioAX96, sqrtRatioBX96, fixedpoint.Q96)
+	if err != nil {
+		return nil, err
+	}
+
+	liquidity, err := fullmath.MulDiv(amount0, intermediate, new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96))
+	if err != nil {
+		return nil, rangeError(err)
+	}
+	return toUint128(liquidity)
+}
+
+// GetLiquidityForAmount1 computes the liquidity received for an amount of
+// token1 over a price range: amount1 / (sqrt(upper) - sqrt(lower))
+func GetLiquidityForAmount1(sqrtRatioAX96, sqrtRatioBX96, amount1 *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sorted(sqrtRatioAX96, sqrtRatioBX96)
+
+	liquidity, err := fullmath.MulDiv(amount1, fixedpoint.Q96, new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96))
+	if err != nil {
+		return nil, rangeError(err)
+	}
+	return toUint128(liquidity)
+}
+
+// GetLiquidityForAmounts computes the maximum liquidity received for amounts
+// of token0 and token1 at the current price and the range bounds
+func GetLiquidityForAmounts(sqrtRatioX96, sqrtRatioAX96, sqrtRatioBX96, amount0, amount1 *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sorted(sqrtRatioAX96, sqrtRatioBX96)
+
+	switch {
+	case sqrtRatioX96.Cmp(sqrtRatioAX96) <= 0:
+		return GetLiquidityForAmount0(sqrtRatioAX96, sqrtRatioBX96, amount0)
+	case sqrtRatioX96.Cmp(sqrtRatioBX96) < 0:
+		liquidity0, err := GetLiquidityForAmount0(sqrtRatioX96, sqrtRatioBX96, amount0)
+		if err != nil {
+			return nil, err
+		}
+		liquidity1, err := GetLiquidityForAmount1(sqrtRatioAX96, sqrtRatioX96, amount1)
+		if err != nil {
+			return nil, err
+		}
+		if liquidity0.Cmp(liquidity1) < 0 {
+			return liquidity0, nil
+		}
+		return liquidity1, nil
+	default:
+		return GetLiquidityForAmount1(sqrtRatioAX96, sqrtRatioBX96, amount1)
+	}
+}
+
+// GetAmount0ForLiquidity computes the amount of token0 for liquidity over a
+// price range
+func GetAmount0ForLiquidity(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sorted(sqrtRatioAX96, sqrtRatioBX96)
+	if sqrtRatioAX96.Sign() == 0 {
+		return nil, fullmath.ErrDivisionByZero
+	}
+
+	amount0, err := fullmath.MulDiv(
+		new(big.Int).Lsh(liquidity, fixedpoint.RESOLUTION_96),
+		new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96),
+		sqrtRatioBX96,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return amount0.Quo(amount0, sqrtRatioAX96), nil
+}
+
+// GetAmount1ForLiquidity computes the amount of token1 for liquidity over a
+// price range
+func GetAmount1ForLiquidity(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sorted(sqrtRatioAX96, sqrtRatioBX96)
+
+	return fullmath.MulDiv(liquidity, new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96), fixedpoint.Q96)
+}
+
+// GetAmountsForLiquidity computes the token0 and token1 value of liquidity at
+// the current price and the range bounds
+func GetAmountsForLiquidity(sqrtRatioX96, sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int) (*big.Int, *big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sorted(sqrtRatioAX96, sqrtRatioBX96)
+
+	switch {
+	case sqrtRatioX96.Cmp(sqrtRatioAX96) <= 0:
+		amount0, err := GetAmount0ForLiquidity(sqrtRatioAX96, sqrtRatioBX96, liquidity)
+		return amount0, new(big.Int), err
+	case sqrtRatioX96.Cmp(sqrtRatioBX96) < 0:
+		amount0, err := GetAmount0ForLiquidity(sqrtRatioX96, sqrtRatioBX96, liquidity)
+		if err != nil {
+			return nil, nil, err
+		}
+		amount1, err := GetAmount1ForLiquidity(sqrtRatioAX96, sqrtRatioX96, liquidity)
+		if err != nil {
+			return nil, nil, err
+		}
+		return amount0, amount1, nil
+	default:
+		amount1, err := GetAmount1ForLiquidity(sqrtRatioAX96, sqrtRatioBX96, liquidity)
+		return new(big.Int), amount1, err
+	}
+}
+
+// sorted returns the two prices in ascending order
+func sorted(a, b *big.Int) (*big.Int, *big.Int) {
+	if a.Cmp(b) > 0 {
+		return b, a
+	}
+	return a, b
+}
+
+// rangeError reports a division by an empty range as such
+func rangeError(err error) error {
+	if errors.Is(err, fullmath.ErrDivisionByZero) {
+		return ErrInvalidRange
+	}
+	return err
+}
+
+// toUint128 fails like the periphery's toUint128 on liquidity of 2**128 and above
+func toUint128(liquidity *big.Int) (*big.Int, error) {
+	if liquidity.Cmp(maxUint128) > 0 {
+		return nil, ErrLiquidityOverflow
+	}
+	return liquidity, nil
+}
@@ -0,0 +1,72 @@
+package liquidityamounts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodePriceSqrt returns floor(sqrt(reserve1 / reserve0) * 2^96)
+func encodePriceSqrt(reserve1, reserve0 int64) *big.Int {
+	x := new(big.Int).Lsh(big.NewInt(reserve1), 192)
+	x.Quo(x, big.NewInt(reserve0))
+	return x.Sqrt(x)
+}
+
+func TestGetLiquidityForAmounts(t *testing.T) {
+	lower, upper := encodePriceSqrt(100, 110), encodePriceSqrt(110, 100)
+
+	testCases := []struct {
+		name     string
+		price    *big.Int
+		expected string
+	}{
+		{name: "price inside", price: encodePriceSqrt(1, 1), expected: "2148"},
+		{name: "price below", price: encodePriceSqrt(99, 110), expected: "1048"},
+		{name: "price above", price: encodePriceSqrt(111, 100), expected: "2097"},
+		{name: "price equal to lower boundary", price: lower, expected: "1048"},
+		{name: "price equal to upper boundary", price: upper, expected: "2097"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			liquidity, err := GetLiquidityForAmounts(tc.price, lower, upper, big.NewInt(100), big.NewInt(200))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, liquidity.String())
+		})
+	}
+
+	_, err := GetLiquidityForAmounts(encodePriceSqrt(1, 1), lower, lower, big.NewInt(100), big.NewInt(200))
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = GetLiquidityForAmount1(lower, upper, new(big.Int).Lsh(big.NewInt(1), 200))
+	assert.ErrorIs(t, err, ErrLiquidityOverflow)
+}
+
+func TestGetAmountsForLiquidity(t *testing.T) {
+	lower, upper := encodePriceSqrt(100, 110), encodePriceSqrt(110, 100)
+
+	testCases := []struct {
+		name      string
+		price     *big.Int
+		liquidity int64
+		amount0   string
+		amount1   string
+	}{
+		{name: "price inside", price: encodePriceSqrt(1, 1), liquidity: 2148, amount0: "99", amount1: "99"},
+		{name: "price below", price: encodePriceSqrt(99, 110), liquidity: 1048, amount0: "99", amount1: "0"},
+		{name: "price above", price: encodePriceSqrt(111, 100), liquidity: 2097, amount0: "0", amount1: "199"},
+		{name: "price on lower boundary", price: lower, liquidity: 1048, amount0: "99", amount1: "0"},
+		{name: "price on upper boundary", price: upper, liquidity: 2097, amount0: "0", amount1: "199"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount0, amount1, err := GetAmountsForLiquidity(tc.price, lower, upper, big.NewInt(tc.liquidity))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.amount0, amount0.String())
+			assert.Equal(t, tc.amount1, amount1.String())
+		})
+	}
+}
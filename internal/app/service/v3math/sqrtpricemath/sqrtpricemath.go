@@ -0,0 +1,224 @@
+// Package sqrtpricemath moves a Q64.96 sqrt price by token amounts and
+// computes the token amounts between two prices, as a port of the Uniswap
+// V3 SqrtPriceMath library
+// (https://github.com/Uniswap/v3-core/blob/main/contracts/libraries/SqrtPriceMath.sol).
+// The uint256 overflow checks of the contract are reproduced since they pick
+// between differently rounded formulas, so results match bit for bit.
+package sqrtpricemath
+
+import (
+	"errors"
+	"math/big"
+
+	"uniswapper/internal/app/service/v3math/fixedpoint"
+	"uniswapper/internal/app/service/v3math/fullmath"
+)
+
+var (
+	ErrInvalidPrice          = errors.New("sqrt price must be positive")
+	ErrInvalidLiquidity      = errors.New("liquidity must be positive")
+	ErrInsufficientLiquidity = errors.New("amount exceeds the virtual reserves")
+	ErrPriceOverflow         = errors.New("sqrt price overflows uint160")
+	ErrAmountOverflow        = errors.New("amount overflows int256")
+)
+
+var (
+	maxUint160 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+	maxInt256  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+)
+
+// GetNextSqrtPriceFromAmount0RoundingUp gets the next sqrt price given a
+// delta of token0, always rounding up so that the price moves far enough
+// when adding and not too far when removing
+func GetNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	// Short circuit amount == 0 because the result is otherwise not
+	// guaranteed to equal the input price
+	if amount.Sign() == 0 {
+		return new(big.Int).Set(sqrtPX96), nil
+	}
+
+	numerator1 := new(big.Int).Lsh(liquidity, fixedpoint.RESOLUTION_96)
+	product := new(big.Int).Mul(amount, sqrtPX96)
+	productFits := product.Cmp(fullmath.MaxUint256) <= 0
+
+	if add {
+		if productFits {
+			denominator := new(big.Int).Add(numerator1, product)
+			if denominator.Cmp(fullmath.MaxUint256) <= 0 && denominator.Cmp(numerator1) >= 0 {
+				// Always fits in 160 bits
+				return fullmath.MulDivRoundingUp(numerator1, sqrtPX96, denominator)
+			}
+		}
+
+		denominator := new(big.Int).Quo(numerator1, sqrtPX96)
+		denominator.Add(denominator, amount)
+		if denominator.Cmp(fullmath.MaxUint256) > 0 {
+			return nil, fullmath.ErrOverflow
+		}
+		return fullmath.DivRoundingUp(numerator1, denominator), nil
+	}
+
+	// The product must not overflow and must stay below the virtual reserves
+	if !productFits || numerator1.Cmp(product) <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	denominator := new(big.Int).Sub(numerator1, product)
+	next, err := fullmath.MulDivRoundingUp(numerator1, sqrtPX96, denominator)
+	if err != nil {
+		return nil, err
+	}
+	if next.Cmp(maxUint160) > 0 {
+		return nil, ErrPriceOverflow
+	}
+	return next, nil
+}
+
+// GetNextSqrtPriceFromAmount1RoundingDown gets the next sqrt price given a
+// delta of token1, always rounding down so that the price moves not too far
+// when adding and far enough when removing
+func GetNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if add {
+		quotient, err := fullmath.MulDiv(amount, fixedpoint.Q96, liquidity)
+		if err != nil {
+			return nil, err
+		}
+
+		next := quotient.Add(quotient, sqrtPX96)
+		if next.Cmp(maxUint160) > 0 {
+			return nil, ErrPriceOverflow
+		}
+		return next, nil
+	}
+
+	quotient, err := fullmath.MulDivRoundingUp(amount, fixedpoint.Q96, liquidity)
+	if err != nil {
+		return nil, err
+	}
+	if sqrtPX96.Cmp(quotient) <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	return quotient.Sub(sqrtPX96, quotient), nil
+}
+
+// GetNextSqrtPriceFromInput gets the next sqrt price given an input amount
+// of token0 or token1, rounding so that the target price is not passed
+func GetNextSqrtPriceFromInput(sqrtPX96, liquidity, amountIn *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	if liquidity.Sign() <= 0 {
+		return nil, ErrInvalidLiquidity
+	}
+
+	if zeroForOne {
+		return GetNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountIn, true)
+	}
+	return GetNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountIn, true)
+}
+
+// GetNextSqrtPriceFromOutput gets the next sqrt price given an output amount
+// of token0 or token1, rounding so that the target price is passed
+func GetNextSqrtPriceFromOutput(sqrtPX96, liquidity, amountOut *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	if liquidity.Sign() <= 0 {
+		return nil, ErrInvalidLiquidity
+	}
+
+	if zeroForOne {
+		return GetNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountOut, false)
+	}
+	return GetNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountOut, false)
+}
+
+// GetAmount0Delta gets the amount of token0 between two prices:
+// liquidity / sqrt(lower) - liquidity / sqrt(upper)
+func GetAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sorted(sqrtRatioAX96, sqrtRatioBX96)
+	if sqrtRatioAX96.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	numerator1 := new(big.Int).Lsh(liquidity, fixedpoint.RESOLUTION_96)
+	numerator2 := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+
+	if roundUp {
+		amount, err := fullmath.MulDivRoundingUp(numerator1, numerator2, sqrtRatioBX96)
+		if err != nil {
+			return nil, err
+		}
+		return fullmath.DivRoundingUp(amount, sqrtRatioAX96), nil
+	}
+
+	amount, err := fullmath.MulDiv(numerator1, numerator2, sqrtRatioBX96)
+	if err != nil {
+		return nil, err
+	}
+	return amount.Quo(amount, sqrtRatioAX96), nil
+}
+
+// GetAmount1Delta gets the amount of token1 between two prices:
+// liquidity * (sqrt(upper) - sqrt(lower))
+func GetAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sorted(sqrtRatioAX96, sqrtRatioBX96)
+
+	diff := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+	if roundUp {
+		return fullmath.MulDivRoundingUp(liquidity, diff, fixedpoint.Q96)
+	}
+	return fullmath.MulDiv(liquidity, diff, fixedpoint.Q96)
+}
+
+// GetAmount0DeltaSigned gets the signed token0 delta for a signed liquidity
+// change: owed to the pool when adding liquidity, paid out when removing
+func GetAmount0DeltaSigned(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int) (*big.Int, error) {
+	if liquidity.Sign() < 0 {
+		amount, err := GetAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, new(big.Int).Neg(liquidity), false)
+		if err != nil {
+			return nil, err
+		}
+		return toInt256(amount.Neg(amount))
+	}
+
+	amount, err := GetAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity, true)
+	if err != nil {
+		return nil, err
+	}
+	return toInt256(amount)
+}
+
+// GetAmount1DeltaSigned gets the signed token1 delta for a signed liquidity
+// change: owed to the pool when adding liquidity, paid out when removing
+func GetAmount1DeltaSigned(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int) (*big.Int, error) {
+	if liquidity.Sign() < 0 {
+		amount, err := GetAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, new(big.Int).Neg(liquidity), false)
+		if err != nil {
+			return nil, err
+		}
+		return toInt256(amount.Neg(amount))
+	}
+
+	amount, err := GetAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity, true)
+	if err != nil {
+		return nil, err
+	}
+	return toInt256(amount)
+}
+
+// sorted returns the two prices in ascending order
+func sorted(a, b *big.Int) (*big.Int, *big.Int) {
+	if a.Cmp(b) > 0 {
+		return b, a
+	}
+	return a, b
+}
+
+// toInt256 fails like SafeCast.toInt256 on amounts of 2**255 and above
+func toInt256(amount *big.Int) (*big.Int, error) {
+	if new(big.Int).Abs(amount).Cmp(maxInt256) > 0 {
+		return nil, ErrAmountOverflow
+	}
+	return amount, nil
+}
@@ -0,0 +1,156 @@
+package sqrtpricemath
+
+import (
+	"math/big"
+	"testing"
+
+	"uniswapper/internal/app/service/v3math/fullmath"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodePriceSqrt returns floor(sqrt(reserve1 / reserve0) * 2^96)
+func encodePriceSqrt(reserve1, reserve0 int64) *big.Int {
+	x := new(big.Int).Lsh(big.NewInt(reserve1), 192)
+	x.Quo(x, big.NewInt(reserve0))
+	return x.Sqrt(x)
+}
+
+// expandTo18Decimals returns n * 10^18 / den
+func expandTo18Decimals(n, den int64) *big.Int {
+	x := new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	return x.Quo(x, big.NewInt(den))
+}
+
+func mustParse(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+func TestGetNextSqrtPriceFromInput(t *testing.T) {
+	testCases := []struct {
+		name       string
+		price      *big.Int
+		liquidity  *big.Int
+		amountIn   *big.Int
+		zeroForOne bool
+		expected   *big.Int
+		err        error
+	}{
+		{name: "price is zero", price: big.NewInt(0), liquidity: big.NewInt(1), amountIn: expandTo18Decimals(1, 10), zeroForOne: false, err: ErrInvalidPrice},
+		{name: "liquidity is zero", price: big.NewInt(1), liquidity: big.NewInt(0), amountIn: expandTo18Decimals(1, 10), zeroForOne: true, err: ErrInvalidLiquidity},
+		{name: "input amount overflows the price", price: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1)), liquidity: big.NewInt(1024), amountIn: big.NewInt(1024), zeroForOne: false, err: ErrPriceOverflow},
+		{name: "any input amount cannot underflow the price", price: big.NewInt(1), liquidity: big.NewInt(1), amountIn: new(big.Int).Lsh(big.NewInt(1), 255), zeroForOne: true, expected: big.NewInt(1)},
+		{name: "input price if amount in is zero and zeroForOne", price: encodePriceSqrt(1, 1), liquidity: expandTo18Decimals(1, 10), amountIn: big.NewInt(0), zeroForOne: true, expected: encodePriceSqrt(1, 1)},
+		{name: "input price if amount in is zero and oneForZero", price: encodePriceSqrt(1, 1), liquidity: expandTo18Decimals(1, 10), amountIn: big.NewInt(0), zeroForOne: false, expected: encodePriceSqrt(1, 1)},
+		{name: "input amount of 0.1 token1", price: encodePriceSqrt(1, 1), liquidity: expandTo18Decimals(1, 1), amountIn: expandTo18Decimals(1, 10), zeroForOne: false, expected: mustParse("87150978765690771352898345369")},
+		{name: "input amount of 0.1 token0", price: encodePriceSqrt(1, 1), liquidity: expandTo18Decimals(1, 1), amountIn: expandTo18Decimals(1, 10), zeroForOne: true, expected: mustParse("72025602285694852357767227579")},
+		{name: "amountIn > type(uint96).max and zeroForOne", price: encodePriceSqrt(1, 1), liquidity: expandTo18Decimals(10, 1), amountIn: new(big.Int).Lsh(big.NewInt(1), 100), zeroForOne: true, expected: mustParse("624999999995069620")},
+		{name: "can return 1 with enough amountIn and zeroForOne", price: encodePriceSqrt(1, 1), liquidity: big.NewInt(1), amountIn: new(big.Int).Rsh(new(big.Int).Lsh(big.NewInt(1), 256), 1), zeroForOne: true, expected: big.NewInt(1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := GetNextSqrtPriceFromInput(tc.price, tc.liquidity, tc.amountIn, tc.zeroForOne)
+			assert.ErrorIs(t, err, tc.err)
+			if tc.err == nil {
+				assert.Equal(t, tc.expected.String(), next.String())
+			}
+		})
+	}
+}
+
+func TestGetNextSqrtPriceFromOutput(t *testing.T) {
+	price := mustParse("20282409603651670423947251286016")
+
+	testCases := []struct {
+		name       string
+		price      *big.Int
+		liquidity  *big.Int
+		amountOut  *big.Int
+		zeroForOne bool
+		expected   *big.Int
+		err        error
+	}{
+		{name: "price is zero", price: big.NewInt(0), liquidity: big.NewInt(1), amountOut: expandTo18Decimals(1, 10), zeroForOne: false, err: ErrInvalidPrice},
+		{name: "liquidity is zero", price: big.NewInt(1), liquidity: big.NewInt(0), amountOut: expandTo18Decimals(1, 10), zeroForOne: true, err: ErrInvalidLiquidity},
+		{name: "output amount is exactly the virtual reserves of token0", price: price, liquidity: big.NewInt(1024), amountOut: big.NewInt(4), zeroForOne: false, err: ErrInsufficientLiquidity},
+		{name: "output amount is greater than the virtual reserves of token0", price: price, liquidity: big.NewInt(1024), amountOut: big.NewInt(5), zeroForOne: false, err: ErrInsufficientLiquidity},
+		{name: "output amount is greater than the virtual reserves of token1", price: price, liquidity: big.NewInt(1024), amountOut: big.NewInt(262145), zeroForOne: true, err: ErrInsufficientLiquidity},
+		{name: "output amount is exactly the virtual reserves of token1", price: price, liquidity: big.NewInt(1024), amountOut: big.NewInt(262144), zeroForOne: true, err: ErrInsufficientLiquidity},
+		{name: "output amount is just less than the virtual reserves of token1", price: price, liquidity: big.NewInt(1024), amountOut: big.NewInt(262143), zeroForOne: true, expected: mustParse("77371252455336267181195264")},
+		{name: "input price if amount out is zero and zeroForOne", price: encodePriceSqrt(1, 1), liquidity: expandTo18Decimals(1, 10), amountOut: big.NewInt(0), zeroForOne: true, expected: encodePriceSqrt(1, 1)},
+		{name: "output amount of 0.1 token1", price: encodePriceSqrt(1, 1), liquidity: expandTo18Decimals(1, 1), amountOut: expandTo18Decimals(1, 10), zeroForOne: false, expected: mustParse("88031291682515930659493278152")},
+		{name: "output amount of 0.1 token0", price: encodePriceSqrt(1, 1), liquidity: expandTo18Decimals(1, 1), amountOut: expandTo18Decimals(1, 10), zeroForOne: true, expected: mustParse("71305346262837903834189555302")},
+		{name: "amountOut is impossible in zero for one direction", price: encodePriceSqrt(1, 1), liquidity: big.NewInt(1), amountOut: new(big.Int).Lsh(big.NewInt(1), 255), zeroForOne: true, err: fullmath.ErrOverflow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := GetNextSqrtPriceFromOutput(tc.price, tc.liquidity, tc.amountOut, tc.zeroForOne)
+			assert.ErrorIs(t, err, tc.err)
+			if tc.err == nil {
+				assert.Equal(t, tc.expected.String(), next.String())
+			}
+		})
+	}
+}
+
+func TestGetAmountDelta(t *testing.T) {
+	lower, upper := encodePriceSqrt(1, 1), encodePriceSqrt(121, 100)
+	liquidity := expandTo18Decimals(1, 1)
+
+	amount0, err := GetAmount0Delta(lower, upper, big.NewInt(0), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", amount0.String())
+
+	amount0, err = GetAmount0Delta(lower, lower, liquidity, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", amount0.String())
+
+	// 0.1 of token0 for a price moving from 1 to 1.21
+	amount0, err = GetAmount0Delta(lower, upper, liquidity, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "90909090909090910", amount0.String())
+
+	amount0, err = GetAmount0Delta(upper, lower, liquidity, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "90909090909090909", amount0.String())
+
+	amount1, err := GetAmount1Delta(lower, upper, liquidity, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "100000000000000000", amount1.String())
+
+	amount1, err = GetAmount1Delta(upper, lower, liquidity, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "99999999999999999", amount1.String())
+
+	// Prices of 2^90 and 2^96, whose product overflows 256 bits
+	high, higher := new(big.Int).Lsh(big.NewInt(1), 141), new(big.Int).Lsh(big.NewInt(1), 144)
+	roundedUp, err := GetAmount0Delta(high, higher, liquidity, true)
+	assert.NoError(t, err)
+	roundedDown, err := GetAmount0Delta(high, higher, liquidity, false)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Add(roundedDown, big.NewInt(1)), roundedUp)
+}
+
+func TestGetAmountDeltaSigned(t *testing.T) {
+	lower, upper := encodePriceSqrt(1, 1), encodePriceSqrt(121, 100)
+	liquidity := expandTo18Decimals(1, 1)
+
+	amount0, err := GetAmount0DeltaSigned(lower, upper, liquidity)
+	assert.NoError(t, err)
+	assert.Equal(t, "90909090909090910", amount0.String())
+
+	amount0, err = GetAmount0DeltaSigned(lower, upper, new(big.Int).Neg(liquidity))
+	assert.NoError(t, err)
+	assert.Equal(t, "-90909090909090909", amount0.String())
+
+	amount1, err := GetAmount1DeltaSigned(lower, upper, liquidity)
+	assert.NoError(t, err)
+	assert.Equal(t, "100000000000000000", amount1.String())
+
+	amount1, err = GetAmount1DeltaSigned(lower, upper, new(big.Int).Neg(liquidity))
+	assert.NoError(t, err)
+	assert.Equal(t, "-99999999999999999", amount1.String())
+}
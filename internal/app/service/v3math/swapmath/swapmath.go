@@ -0,0 +1,142 @@
+// Package swapmath computes the result of swapping within a single tick
+// range, as a port of the Uniswap V3 SwapMath library
+// (https://github.com/Uniswap/v3-core/blob/main/contracts/libraries/SwapMath.sol).
+package swapmath
+
+import (
+	"math/big"
+
+	"uniswapper/internal/app/service/v3math/fullmath"
+	"uniswapper/internal/app/service/v3math/sqrtpricemath"
+)
+
+// FEE_DENOMINATOR is the unit of fees in pips, i.e. hundredths of a bip
+const FEE_DENOMINATOR = 1000000
+
+// SwapStep is the outcome of ComputeSwapStep
+type SwapStep struct {
+	// SqrtRatioNextX96 is the price after swapping, never past the target
+	SqrtRatioNextX96 *big.Int
+	// AmountIn is the amount to be swapped in, of token0 or token1 by direction
+	AmountIn *big.Int
+	// AmountOut is the amount to be received, of token1 or token0 by direction
+	AmountOut *big.Int
+	// FeeAmount is the amount of the input taken as fee
+	FeeAmount *big.Int
+}
+
+// ComputeSwapStep computes the result of swapping some amount in, or
+// amount out, given the parameters of the swap. A positive amountRemaining
+// is an exact input, a negative one an exact output. The fee, in pips,
+// plus the amount in never exceed an exact input.
+func ComputeSwapStep(
+	sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, amountRemaining *big.Int,
+	feePips uint32,
+) (*SwapStep, error) {
+	zeroForOne := sqrtRatioCurrentX96.Cmp(sqrtRatioTargetX96) >= 0
+	exactIn := amountRemaining.Sign() >= 0
+
+	fee := big.NewInt(int64(feePips))
+	feeComplement := big.NewInt(FEE_DENOMINATOR - int64(feePips))
+	amountRemainingAbs := new(big.Int).Abs(amountRemaining)
+
+	var (
+		sqrtRatioNextX96 *big.Int
+		amountIn         *big.Int
+		amountOut        *big.Int
+		err              error
+	)
+
+	if exactIn {
+		amountRemainingLessFee, err := fullmath.MulDiv(amountRemainingAbs, feeComplement, big.NewInt(FEE_DENOMINATOR))
+		if err != nil {
+			return nil, err
+		}
+
+		if zeroForOne {
+			amountIn, err = sqrtpricemath.GetAmount0Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, true)
+		} else {
+			amountIn, err = sqrtpricemath.GetAmount1Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if amountRemainingLessFee.Cmp(amountIn) >= 0 {
+			sqrtRatioNextX96 = new(big.Int).Set(sqrtRatioTargetX96)
+		} else {
+			sqrtRatioNextX96, err = sqrtpricemath.GetNextSqrtPriceFromInput(sqrtRatioCurrentX96, liquidity, amountRemainingLessFee, zeroForOne)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if zeroForOne {
+			amountOut, err = sqrtpricemath.GetAmount1Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, false)
+		} else {
+			amountOut, err = sqrtpricemath.GetAmount0Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if amountRemainingAbs.Cmp(amountOut) >= 0 {
+			sqrtRatioNextX96 = new(big.Int).Set(sqrtRatioTargetX96)
+		} else {
+			sqrtRatioNextX96, err = sqrtpricemath.GetNextSqrtPriceFromOutput(sqrtRatioCurrentX96, liquidity, amountRemainingAbs, zeroForOne)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	max := sqrtRatioTargetX96.Cmp(sqrtRatioNextX96) == 0
+
+	// Get the input and output amounts unless the target price was reached
+	// and the amount is already known
+	if zeroForOne {
+		if !max || !exactIn {
+			if amountIn, err = sqrtpricemath.GetAmount0Delta(sqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, true); err != nil {
+				return nil, err
+			}
+		}
+		if !max || exactIn {
+			if amountOut, err = sqrtpricemath.GetAmount1Delta(sqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, false); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if !max || !exactIn {
+			if amountIn, err = sqrtpricemath.GetAmount1Delta(sqrtRatioCurrentX96, sqrtRatioNextX96, liquidity, true); err != nil {
+				return nil, err
+			}
+		}
+		if !max || exactIn {
+			if amountOut, err = sqrtpricemath.GetAmount0Delta(sqrtRatioCurrentX96, sqrtRatioNextX96, liquidity, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Cap the output amount to not exceed the remaining output amount
+	if !exactIn && amountOut.Cmp(amountRemainingAbs) > 0 {
+		amountOut = new(big.Int).Set(amountRemainingAbs)
+	}
+
+	var feeAmount *big.Int
+	if exactIn && sqrtRatioNextX96.Cmp(sqrtRatioTargetX96) != 0 {
+		// The target was not reached, so the remainder of the input is the fee
+		feeAmount = new(big.Int).Sub(amountRemainingAbs, amountIn)
+	} else {
+		if feeAmount, err = fullmath.MulDivRoundingUp(amountIn, fee, feeComplement); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SwapStep{
+		SqrtRatioNextX96: sqrtRatioNextX96,
+		AmountIn:         amountIn,
+		AmountOut:        amountOut,
+		FeeAmount:        feeAmount,
+	}, nil
+}
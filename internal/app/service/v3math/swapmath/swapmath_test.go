@@ -0,0 +1,185 @@
+package swapmath
+
+import (
+	"math/big"
+	"testing"
+
+	"uniswapper/internal/app/service/v3math/sqrtpricemath"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodePriceSqrt returns floor(sqrt(reserve1 / reserve0) * 2^96)
+func encodePriceSqrt(reserve1, reserve0 int64) *big.Int {
+	x := new(big.Int).Lsh(big.NewInt(reserve1), 192)
+	x.Quo(x, big.NewInt(reserve0))
+	return x.Sqrt(x)
+}
+
+// expandTo18Decimals returns n * 10^18
+func expandTo18Decimals(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+}
+
+func mustParse(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+func TestComputeSwapStep(t *testing.T) {
+	price := encodePriceSqrt(1, 1)
+	lowLiquidityPrice := mustParse("20282409603651670423947251286016")
+
+	nextFromInput, err := sqrtpricemath.GetNextSqrtPriceFromInput(price, expandTo18Decimals(2), mustParse("999400000000000000"), false)
+	assert.NoError(t, err)
+	nextFromOutput, err := sqrtpricemath.GetNextSqrtPriceFromOutput(price, expandTo18Decimals(2), expandTo18Decimals(1), false)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		price           *big.Int
+		target          *big.Int
+		liquidity       *big.Int
+		amountRemaining *big.Int
+		feePips         uint32
+		expected        SwapStep
+	}{
+		{
+			name:            "exact amount in that gets capped at price target in one for zero",
+			price:           price,
+			target:          encodePriceSqrt(101, 100),
+			liquidity:       expandTo18Decimals(2),
+			amountRemaining: expandTo18Decimals(1),
+			feePips:         600,
+			expected: SwapStep{
+				SqrtRatioNextX96: encodePriceSqrt(101, 100),
+				AmountIn:         mustParse("9975124224178055"),
+				AmountOut:        mustParse("9925619580021728"),
+				FeeAmount:        mustParse("5988667735148"),
+			},
+		},
+		{
+			name:            "exact amount out that gets capped at price target in one for zero",
+			price:           price,
+			target:          encodePriceSqrt(101, 100),
+			liquidity:       expandTo18Decimals(2),
+			amountRemaining: new(big.Int).Neg(expandTo18Decimals(1)),
+			feePips:         600,
+			expected: SwapStep{
+				SqrtRatioNextX96: encodePriceSqrt(101, 100),
+				AmountIn:         mustParse("9975124224178055"),
+				AmountOut:        mustParse("9925619580021728"),
+				FeeAmount:        mustParse("5988667735148"),
+			},
+		},
+		{
+			name:            "exact amount in that is fully spent in one for zero",
+			price:           price,
+			target:          encodePriceSqrt(1000, 100),
+			liquidity:       expandTo18Decimals(2),
+			amountRemaining: expandTo18Decimals(1),
+			feePips:         600,
+			expected: SwapStep{
+				SqrtRatioNextX96: nextFromInput,
+				AmountIn:         mustParse("999400000000000000"),
+				AmountOut:        mustParse("666399946655997866"),
+				FeeAmount:        mustParse("600000000000000"),
+			},
+		},
+		{
+			name:            "exact amount out that is fully received in one for zero",
+			price:           price,
+			target:          encodePriceSqrt(10000, 100),
+			liquidity:       expandTo18Decimals(2),
+			amountRemaining: new(big.Int).Neg(expandTo18Decimals(1)),
+			feePips:         600,
+			expected: SwapStep{
+				SqrtRatioNextX96: nextFromOutput,
+				AmountIn:         mustParse("2000000000000000000"),
+				AmountOut:        expandTo18Decimals(1),
+				FeeAmount:        mustParse("1200720432259356"),
+			},
+		},
+		{
+			name:            "amount out is capped at the desired amount out",
+			price:           mustParse("417332158212080721273783715441582"),
+			target:          mustParse("1452870262520218020823638996"),
+			liquidity:       mustParse("159344665391607089467575320103"),
+			amountRemaining: big.NewInt(-1),
+			feePips:         1,
+			expected: SwapStep{
+				SqrtRatioNextX96: mustParse("417332158212080721273783715441581"),
+				AmountIn:         big.NewInt(1),
+				AmountOut:        big.NewInt(1),
+				FeeAmount:        big.NewInt(1),
+			},
+		},
+		{
+			name:            "target price of 1 uses partial input amount",
+			price:           big.NewInt(2),
+			target:          big.NewInt(1),
+			liquidity:       big.NewInt(1),
+			amountRemaining: mustParse("3915081100057732413702495386755767"),
+			feePips:         1,
+			expected: SwapStep{
+				SqrtRatioNextX96: big.NewInt(1),
+				AmountIn:         mustParse("39614081257132168796771975168"),
+				AmountOut:        big.NewInt(0),
+				FeeAmount:        mustParse("39614120871253040049813"),
+			},
+		},
+		{
+			name:            "entire input amount taken as fee",
+			price:           big.NewInt(2413),
+			target:          mustParse("79887613182836312"),
+			liquidity:       mustParse("1985041575832132834610021537970"),
+			amountRemaining: big.NewInt(10),
+			feePips:         1872,
+			expected: SwapStep{
+				SqrtRatioNextX96: big.NewInt(2413),
+				AmountIn:         big.NewInt(0),
+				AmountOut:        big.NewInt(0),
+				FeeAmount:        big.NewInt(10),
+			},
+		},
+		{
+			name:            "intermediate insufficient liquidity in zero for one exact output",
+			price:           lowLiquidityPrice,
+			target:          new(big.Int).Quo(new(big.Int).Mul(lowLiquidityPrice, big.NewInt(11)), big.NewInt(10)),
+			liquidity:       big.NewInt(1024),
+			amountRemaining: big.NewInt(-4),
+			feePips:         3000,
+			expected: SwapStep{
+				SqrtRatioNextX96: new(big.Int).Quo(new(big.Int).Mul(lowLiquidityPrice, big.NewInt(11)), big.NewInt(10)),
+				AmountIn:         big.NewInt(26215),
+				AmountOut:        big.NewInt(0),
+				FeeAmount:        big.NewInt(79),
+			},
+		},
+		{
+			name:            "intermediate insufficient liquidity in one for zero exact output",
+			price:           lowLiquidityPrice,
+			target:          new(big.Int).Quo(new(big.Int).Mul(lowLiquidityPrice, big.NewInt(9)), big.NewInt(10)),
+			liquidity:       big.NewInt(1024),
+			amountRemaining: big.NewInt(-263000),
+			feePips:         3000,
+			expected: SwapStep{
+				SqrtRatioNextX96: new(big.Int).Quo(new(big.Int).Mul(lowLiquidityPrice, big.NewInt(9)), big.NewInt(10)),
+				AmountIn:         big.NewInt(1),
+				AmountOut:        big.NewInt(26214),
+				FeeAmount:        big.NewInt(1),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			step, err := ComputeSwapStep(tc.price, tc.target, tc.liquidity, tc.amountRemaining, tc.feePips)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.SqrtRatioNextX96.String(), step.SqrtRatioNextX96.String())
+			assert.Equal(t, tc.expected.AmountIn.String(), step.AmountIn.String())
+			assert.Equal(t, tc.expected.AmountOut.String(), step.AmountOut.String())
+			assert.Equal(t, tc.expected.FeeAmount.String(), step.FeeAmount.String())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Package tickbitmap finds the next initialized tick of a pool, as a port of
+// the Uniswap V3 TickBitmap library
+// (https://github.com/Uniswap/v3-core/blob/main/contracts/libraries/TickBitmap.sol).
+// Searches stop at the end of a 256-tick word like the contract, since swap
+// steps are bounded by the tick returned and rounding happens per step.
+package tickbitmap
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrTickMisaligned is returned for ticks that are not a multiple of the spacing
+var ErrTickMisaligned = errors.New("tick is not a multiple of the tick spacing")
+
+// TickBitmap holds one bit per compressed tick, by word position
+type TickBitmap map[int16]*big.Int
+
+// FlipTick flips the initialized state of tick
+func (b TickBitmap) FlipTick(tick, tickSpacing int) error {
+	if tick%tickSpacing != 0 {
+		return ErrTickMisaligned
+	}
+
+	wordPos, bitPos := position(tick / tickSpacing)
+	word := b.word(wordPos)
+	word.SetBit(word, int(bitPos), word.Bit(int(bitPos))^1)
+	b[wordPos] = word
+	return nil
+}
+
+// NextInitializedTickWithinOneWord returns the next initialized tick in the
+// same word as tick, to the left (less than or equal to) when lte, or to the
+// right (greater than) otherwise. If no tick is initialized there, the word
+// boundary is returned along with false.
+func (b TickBitmap) NextInitializedTickWithinOneWord(tick, tickSpacing int, lte bool) (int, bool) {
+	compressed := tick / tickSpacing
+	// Round towards negative infinity
+	if tick < 0 && tick%tickSpacing != 0 {
+		compressed--
+	}
+
+	if lte {
+		wordPos, bitPos := position(compressed)
+		// All the bits at or to the right of the current bit
+		masked := new(big.Int).And(b.word(wordPos), lowMask(bitPos))
+		if masked.Sign() == 0 {
+			return (compressed - int(bitPos)) * tickSpacing, false
+		}
+		return (compressed - int(bitPos) + mostSignificantBit(masked)) * tickSpacing, true
+	}
+
+	// Start from the word of the next tick, since the current one is excluded
+	wordPos, bitPos := position(compressed + 1)
+	// All the bits at or to the left of the bit
+	masked := new(big.Int).AndNot(b.word(wordPos), new(big.Int).Rsh(lowMask(bitPos), 1))
+	if masked.Sign() == 0 {
+		return (compressed + 1 + (255 - int(bitPos))) * tickSpacing, false
+	}
+	return (compressed + 1 + leastSignificantBit(masked) - int(bitPos)) * tickSpacing, true
+}
+
+// word returns a copy of the word at wordPos
+func (b TickBitmap) word(wordPos int16) *big.Int {
+	if word, ok := b[wordPos]; ok {
+		return new(big.Int).Set(word)
+	}
+	return new(big.Int)
+}
+
+// position returns the word and bit of a compressed tick
+func position(compressed int) (int16, uint8) {
+	return int16(compressed >> 8), uint8(compressed & 0xff)
+}
+
+// lowMask has the bits up to and including bitPos set
+func lowMask(bitPos uint8) *big.Int {
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(bitPos)+1)
+	return mask.Sub(mask, big.NewInt(1))
+}
+
+func mostSignificantBit(x *big.Int) int {
+	return x.BitLen() - 1
+}
+
+func leastSignificantBit(x *big.Int) int {
+	return int(x.TrailingZeroBits())
+}
@@ -0,0 +1,87 @@
+package tickbitmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// initialized builds the bitmap used by the v3-core TickBitmap spec
+func initialized(t *testing.T) TickBitmap {
+	bitmap := TickBitmap{}
+	for _, tick := range []int{-200, -55, -4, 70, 78, 84, 139, 240, 535} {
+		assert.NoError(t, bitmap.FlipTick(tick, 1))
+	}
+	return bitmap
+}
+
+func TestFlipTick(t *testing.T) {
+	bitmap := TickBitmap{}
+
+	assert.NoError(t, bitmap.FlipTick(-230, 1))
+	next, ok := bitmap.NextInitializedTickWithinOneWord(-230, 1, true)
+	assert.True(t, ok)
+	assert.Equal(t, -230, next)
+
+	assert.NoError(t, bitmap.FlipTick(-230, 1))
+	_, ok = bitmap.NextInitializedTickWithinOneWord(-230, 1, true)
+	assert.False(t, ok)
+
+	assert.ErrorIs(t, bitmap.FlipTick(61, 60), ErrTickMisaligned)
+}
+
+func TestNextInitializedTickWithinOneWord(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tick        int
+		lte         bool
+		next        int
+		initialized bool
+	}{
+		{name: "gt: returns tick to right if at initialized tick", tick: 78, next: 84, initialized: true},
+		{name: "gt: returns tick to right if at initialized tick (negative)", tick: -55, next: -4, initialized: true},
+		{name: "gt: returns the tick directly to the right", tick: 77, next: 78, initialized: true},
+		{name: "gt: returns the tick directly to the right (negative)", tick: -56, next: -55, initialized: true},
+		{name: "gt: returns the next word's initialized tick if on the right boundary", tick: 255, next: 511, initialized: false},
+		{name: "gt: returns the next word's initialized tick if on the right boundary (negative)", tick: -257, next: -200, initialized: true},
+		{name: "gt: does not exceed boundary", tick: 508, next: 511, initialized: false},
+		{name: "gt: skips entire word", tick: 255, next: 511, initialized: false},
+		{name: "gt: skips half word", tick: 383, next: 511, initialized: false},
+		{name: "lte: returns same tick if initialized", tick: 78, lte: true, next: 78, initialized: true},
+		{name: "lte: returns tick directly to the left of input tick if not initialized", tick: 79, lte: true, next: 78, initialized: true},
+		{name: "lte: will not exceed the word boundary", tick: 258, lte: true, next: 256, initialized: false},
+		{name: "lte: at the word boundary", tick: 256, lte: true, next: 256, initialized: false},
+		{name: "lte: word boundary less 1 (next initialized tick in next word)", tick: 72, lte: true, next: 70, initialized: true},
+		{name: "lte: word boundary", tick: -257, lte: true, next: -512, initialized: false},
+		{name: "lte: entire empty word", tick: 1023, lte: true, next: 768, initialized: false},
+		{name: "lte: halfway through empty word", tick: 900, lte: true, next: 768, initialized: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, ok := initialized(t).NextInitializedTickWithinOneWord(tc.tick, 1, tc.lte)
+			assert.Equal(t, tc.next, next)
+			assert.Equal(t, tc.initialized, ok)
+		})
+	}
+
+	t.Run("lte: boundary is initialized", func(t *testing.T) {
+		bitmap := initialized(t)
+		assert.NoError(t, bitmap.FlipTick(329, 1))
+		next, ok := bitmap.NextInitializedTickWithinOneWord(456, 1, true)
+		assert.Equal(t, 329, next)
+		assert.True(t, ok)
+	})
+
+	t.Run("spaced ticks round towards negative infinity", func(t *testing.T) {
+		bitmap := TickBitmap{}
+		assert.NoError(t, bitmap.FlipTick(-120, 60))
+		next, ok := bitmap.NextInitializedTickWithinOneWord(-61, 60, true)
+		assert.Equal(t, -120, next)
+		assert.True(t, ok)
+
+		next, ok = bitmap.NextInitializedTickWithinOneWord(-180, 60, false)
+		assert.Equal(t, -120, next)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,164 @@
+// Package tickmath computes sqrt prices from ticks and back, as a port of the
+// Uniswap V3 TickMath library
+// (https://github.com/Uniswap/v3-core/blob/main/contracts/libraries/TickMath.sol).
+// Prices are Q64.96 square roots of token1/token0 and results match the
+// contract bit for bit.
+package tickmath
+
+import (
+	"errors"
+	"math/big"
+)
+
+const (
+	// MIN_TICK is the minimum tick that may be passed to GetSqrtRatioAtTick, computed from log base 1.0001 of 2**-128
+	MIN_TICK = -887272
+	// MAX_TICK is the maximum tick that may be passed to GetSqrtRatioAtTick, computed from log base 1.0001 of 2**128
+	MAX_TICK = -MIN_TICK
+)
+
+var (
+	// MIN_SQRT_RATIO is the value returned by GetSqrtRatioAtTick(MIN_TICK)
+	MIN_SQRT_RATIO = big.NewInt(4295128739)
+	// MAX_SQRT_RATIO is the value returned by GetSqrtRatioAtTick(MAX_TICK)
+	MAX_SQRT_RATIO = mustParse("1461446703485210103287273052203988822378723970342")
+
+	ErrTickOutOfRange      = errors.New("tick out of range")
+	ErrSqrtRatioOutOfRange = errors.New("sqrt ratio out of range")
+)
+
+var (
+	q32        = new(big.Int).Lsh(big.NewInt(1), 32)
+	q128       = new(big.Int).Lsh(big.NewInt(1), 128)
+	maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	// ratioFactors[i] is 2**128 / sqrt(1.0001)**(2**i), rounded as in the contract
+	ratioFactors = []*big.Int{
+		mustParseHex("fffcb933bd6fad37aa2d162d1a594001"),
+		mustParseHex("fff97272373d413259a46990580e213a"),
+		mustParseHex("fff2e50f5f656932ef12357cf3c7fdcc"),
+		mustParseHex("ffe5caca7e10e4e61c3624eaa0941cd0"),
+		mustParseHex("ffcb9843d60f6159c9db58835c926644"),
+		mustParseHex("ff973b41fa98c081472e6896dfb254c0"),
+		mustParseHex("ff2ea16466c96a3843ec78b326b52861"),
+		mustParseHex("fe5dee046a99a2a811c461f1969c3053"),
+		mustParseHex("fcbe86c7900a88aedcffc83b479aa3a4"),
+		mustParseHex("f987a7253ac413176f2b074cf7815e54"),
+		mustParseHex("f3392b0822b70005940c7a398e4b70f3"),
+		mustParseHex("e7159475a2c29b7443b29c7fa6e889d9"),
+		mustParseHex("d097f3bdfd2022b8845ad8f792aa5825"),
+		mustParseHex("a9f746462d870fdf8a65dc1f90e061e5"),
+		mustParseHex("70d869a156d2a1b890bb3df62baf32f7"),
+		mustParseHex("31be135f97d08fd981231505542fcfa6"),
+		mustParseHex("9aa508b5b7a84e1c677de54f3e99bc9"),
+		mustParseHex("5d6af8dedb81196699c329225ee604"),
+		mustParseHex("2216e584f5fa1ea926041bedfe98"),
+		mustParseHex("48a170391f7dc42444e8fa2"),
+	}
+
+	// log2 of sqrt(1.0001) as a Q128.128 reciprocal, and the error bounds of the approximation
+	log2Sqrt10001   = mustParse("255738958999603826347141")
+	tickLowOffset   = mustParse("3402992956809132418596140100660247210")
+	tickHighOffset  = mustParse("291339464771989622907027621153398088495")
+	log2Iterations  = 14
+	log2FirstBitPos = 63
+)
+
+// GetSqrtRatioAtTick calculates sqrt(1.0001^tick) * 2^96
+func GetSqrtRatioAtTick(tick int) (*big.Int, error) {
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+	if absTick > MAX_TICK {
+		return nil, ErrTickOutOfRange
+	}
+
+	ratio := new(big.Int)
+	if absTick&0x1 != 0 {
+		ratio.Set(ratioFactors[0])
+	} else {
+		ratio.Set(q128)
+	}
+	for i := 1; i < len(ratioFactors); i++ {
+		if absTick&(1<<uint(i)) != 0 {
+			ratio.Mul(ratio, ratioFactors[i])
+			ratio.Rsh(ratio, 128)
+		}
+	}
+
+	if tick > 0 {
+		ratio.Div(maxUint256, ratio)
+	}
+
+	// Divide by 1<<32 rounding up to go from a Q128.128 to a Q128.96, so
+	// that GetTickAtSqrtRatio of the output price is always consistent
+	sqrtPriceX96, rem := new(big.Int).QuoRem(ratio, q32, new(big.Int))
+	if rem.Sign() != 0 {
+		sqrtPriceX96.Add(sqrtPriceX96, big.NewInt(1))
+	}
+	return sqrtPriceX96, nil
+}
+
+// GetTickAtSqrtRatio calculates the greatest tick value such that
+// GetSqrtRatioAtTick(tick) <= sqrtPriceX96
+func GetTickAtSqrtRatio(sqrtPriceX96 *big.Int) (int, error) {
+	if sqrtPriceX96.Cmp(MIN_SQRT_RATIO) < 0 || sqrtPriceX96.Cmp(MAX_SQRT_RATIO) >= 0 {
+		return 0, ErrSqrtRatioOutOfRange
+	}
+
+	ratio := new(big.Int).Lsh(sqrtPriceX96, 32)
+	msb := ratio.BitLen() - 1
+
+	r := new(big.Int)
+	if msb >= 128 {
+		r.Rsh(ratio, uint(msb-127))
+	} else {
+		r.Lsh(ratio, uint(127-msb))
+	}
+
+	log2 := new(big.Int).Lsh(big.NewInt(int64(msb-128)), 64)
+	for i := 0; i < log2Iterations; i++ {
+		r.Mul(r, r)
+		r.Rsh(r, 127)
+		f := r.Bit(128)
+		if f == 1 {
+			log2.Or(log2, new(big.Int).Lsh(big.NewInt(1), uint(log2FirstBitPos-i)))
+			r.Rsh(r, 1)
+		}
+	}
+
+	logSqrt10001 := new(big.Int).Mul(log2, log2Sqrt10001)
+
+	tickLow := int(new(big.Int).Rsh(new(big.Int).Sub(logSqrt10001, tickLowOffset), 128).Int64())
+	tickHigh := int(new(big.Int).Rsh(new(big.Int).Add(logSqrt10001, tickHighOffset), 128).Int64())
+
+	if tickLow == tickHigh {
+		return tickLow, nil
+	}
+
+	sqrtRatio, err := GetSqrtRatioAtTick(tickHigh)
+	if err != nil {
+		return 0, err
+	}
+	if sqrtRatio.Cmp(sqrtPriceX96) <= 0 {
+		return tickHigh, nil
+	}
+	return tickLow, nil
+}
+
+func mustParse(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("tickmath: invalid constant " + s)
+	}
+	return n
+}
+
+func mustParseHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("tickmath: invalid constant " + s)
+	}
+	return n
+}
@@ -0,0 +1,70 @@
+package tickmath
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSqrtRatioAtTick(t *testing.T) {
+	testCases := []struct {
+		tick     int
+		expected string
+	}{
+		{tick: MIN_TICK, expected: "4295128739"},
+		{tick: MIN_TICK + 1, expected: "4295343490"},
+		{tick: -100000, expected: "533968626430936354154228408"},
+		{tick: -1000, expected: "75364347830767020784054125655"},
+		{tick: -50, expected: "79030349367926598376800521322"},
+		{tick: 0, expected: "79228162514264337593543950336"},
+		{tick: 50, expected: "79426470787362580746886972461"},
+		{tick: 100, expected: "79625275426524748796330556128"},
+		{tick: 1000, expected: "83290069058676223003182343270"},
+		{tick: 10000, expected: "130621891405341611593710811006"},
+		{tick: 100000, expected: "11755562826496067164730007768450"},
+		{tick: 200000, expected: "1744244129640337381386292603617838"},
+		{tick: MAX_TICK - 1, expected: "1461373636630004318706518188784493106690254656249"},
+		{tick: MAX_TICK, expected: "1461446703485210103287273052203988822378723970342"},
+	}
+
+	for _, tc := range testCases {
+		sqrtPriceX96, err := GetSqrtRatioAtTick(tc.tick)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, sqrtPriceX96.String(), "tick %d", tc.tick)
+	}
+
+	_, err := GetSqrtRatioAtTick(MIN_TICK - 1)
+	assert.ErrorIs(t, err, ErrTickOutOfRange)
+	_, err = GetSqrtRatioAtTick(MAX_TICK + 1)
+	assert.ErrorIs(t, err, ErrTickOutOfRange)
+}
+
+func TestGetTickAtSqrtRatio(t *testing.T) {
+	tick, err := GetTickAtSqrtRatio(MIN_SQRT_RATIO)
+	assert.NoError(t, err)
+	assert.Equal(t, MIN_TICK, tick)
+
+	tick, err = GetTickAtSqrtRatio(new(big.Int).Sub(MAX_SQRT_RATIO, big.NewInt(1)))
+	assert.NoError(t, err)
+	assert.Equal(t, MAX_TICK-1, tick)
+
+	_, err = GetTickAtSqrtRatio(new(big.Int).Sub(MIN_SQRT_RATIO, big.NewInt(1)))
+	assert.ErrorIs(t, err, ErrSqrtRatioOutOfRange)
+	_, err = GetTickAtSqrtRatio(MAX_SQRT_RATIO)
+	assert.ErrorIs(t, err, ErrSqrtRatioOutOfRange)
+
+	// A tick's own ratio maps back to it, one less maps to the tick below
+	for tick := MIN_TICK + 1; tick < MAX_TICK; tick += 7919 {
+		sqrtPriceX96, err := GetSqrtRatioAtTick(tick)
+		assert.NoError(t, err)
+
+		got, err := GetTickAtSqrtRatio(sqrtPriceX96)
+		assert.NoError(t, err)
+		assert.Equal(t, tick, got)
+
+		got, err = GetTickAtSqrtRatio(new(big.Int).Sub(sqrtPriceX96, big.NewInt(1)))
+		assert.NoError(t, err)
+		assert.Equal(t, tick-1, got)
+	}
+}
@@ -49,8 +49,45 @@ type LogConfig struct {
 }
 
 type PoolConfig struct {
-	INFURA_MAINNET string `env:"INFURA_MAINNET"`
-	POOL_ADDRESSES string `env:"POOL_ADDRESSES"`
+	INFURA_MAINNET         string `env:"INFURA_MAINNET"`
+	POOL_ADDRESSES         string `env:"POOL_ADDRESSES"`
+	POOL_INGESTION_MODE    string `env:"POOL_INGESTION_MODE" envDefault:"subscription"`
+	POOL_POLL_INTERVAL     int    `env:"POOL_POLL_INTERVAL" envDefault:"12"`
+	POOL_INGESTION_POLICY  string `env:"POOL_INGESTION_POLICY" envDefault:"every_event"`
+	POOL_SNAPSHOT_BLOCKS   uint64 `env:"POOL_SNAPSHOT_BLOCKS" envDefault:"12"`
+	POOL_SNAPSHOT_INTERVAL int    `env:"POOL_SNAPSHOT_INTERVAL" envDefault:"60"`
+
+	POOL_BACKFILL_ENABLED     bool   `env:"POOL_BACKFILL_ENABLED"`
+	POOL_BACKFILL_START_BLOCK uint64 `env:"POOL_BACKFILL_START_BLOCK"`
+	POOL_BACKFILL_CHUNK_SIZE  uint64 `env:"POOL_BACKFILL_CHUNK_SIZE" envDefault:"2000"`
+
+	POOL_CONFIRMATIONS uint64 `env:"POOL_CONFIRMATIONS" envDefault:"12"`
+
+	POOL_RECONNECT_MIN_BACKOFF int `env:"POOL_RECONNECT_MIN_BACKOFF" envDefault:"1"`
+	POOL_RECONNECT_MAX_BACKOFF int `env:"POOL_RECONNECT_MAX_BACKOFF" envDefault:"60"`
+
+	POOL_FACTORY_WATCH       bool   `env:"POOL_FACTORY_WATCH"`
+	POOL_FACTORY_ADDRESS     string `env:"POOL_FACTORY_ADDRESS" envDefault:"0x1F98431c8aD98523631AE4a59f267346ea31F984"`
+	POOL_FACTORY_START_BLOCK uint64 `env:"POOL_FACTORY_START_BLOCK" envDefault:"12369621"`
+	POOL_FACTORY_TOKENS      string `env:"POOL_FACTORY_TOKENS"`
+	POOL_FACTORY_FEES        string `env:"POOL_FACTORY_FEES"`
+
+	POOL_STATE_CACHE_TTL       int    `env:"POOL_STATE_CACHE_TTL" envDefault:"5"`
+	POOL_STATE_SNAPSHOT_BLOCKS uint64 `env:"POOL_STATE_SNAPSHOT_BLOCKS" envDefault:"100"`
+}
+
+type PositionConfig struct {
+	POSITION_MANAGER_ADDRESS string `env:"POSITION_MANAGER_ADDRESS" envDefault:"0xC36442b4a4522E871399CD717aBDD847Ab11FE88"`
+	POSITION_START_BLOCK     uint64 `env:"POSITION_START_BLOCK" envDefault:"12369651"`
+	POSITION_OWNERS          string `env:"POSITION_OWNERS"`
+	POSITION_TOKEN_IDS       string `env:"POSITION_TOKEN_IDS"`
+}
+
+type RPCConfig struct {
+	RPC_WS_ENDPOINTS          string `env:"RPC_WS_ENDPOINTS"`
+	RPC_HTTP_ENDPOINTS        string `env:"RPC_HTTP_ENDPOINTS"`
+	RPC_MAX_HEAD_LAG          uint64 `env:"RPC_MAX_HEAD_LAG" envDefault:"3"`
+	RPC_HEALTH_CHECK_INTERVAL int    `env:"RPC_HEALTH_CHECK_INTERVAL" envDefault:"30"`
 }
 
 type ServiceConfig struct {
@@ -60,6 +97,8 @@ type ServiceConfig struct {
 	HTTPServerConfig HTTPServerConfig
 	LogConfig        LogConfig
 	PoolConfig       PoolConfig
+	PositionConfig   PositionConfig
+	RPCConfig        RPCConfig
 	Environment      string `env:"ENVIRONMENT"`
 }
 